@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// A KafkaAccessSpec defines the desired state of a KafkaAccess.
+type KafkaAccessSpec struct {
+	xpv2.ClusterManagedResourceSpec `json:",inline"`
+	ForProvider                     common.KafkaAccessParameters `json:"forProvider"`
+}
+
+// A KafkaAccessStatus represents the observed state of a KafkaAccess.
+type KafkaAccessStatus struct {
+	xpv2.ManagedResourceStatus `json:",inline"`
+	AtProvider                 common.KafkaAccessObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KafkaAccess grants a principal the ACLs of common Kafka client patterns.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PRINCIPAL",type="string",JSONPath=".spec.forProvider.principal"
+// +kubebuilder:printcolumn:name="BINDINGS",type="integer",JSONPath=".status.atProvider.bindings"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type KafkaAccess struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaAccessSpec   `json:"spec"`
+	Status KafkaAccessStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KafkaAccessList contains a list of KafkaAccess
+type KafkaAccessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaAccess `json:"items"`
+}
+
+// KafkaAccess type metadata.
+var (
+	KafkaAccessKind             = reflect.TypeOf(KafkaAccess{}).Name()
+	KafkaAccessGroupKind        = schema.GroupKind{Group: Group, Kind: KafkaAccessKind}.String()
+	KafkaAccessKindAPIVersion   = KafkaAccessKind + "." + SchemeGroupVersion.String()
+	KafkaAccessGroupVersionKind = SchemeGroupVersion.WithKind(KafkaAccessKind)
+)
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &KafkaAccess{}, &KafkaAccessList{})
+		return nil
+	})
+}
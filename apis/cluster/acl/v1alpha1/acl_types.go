@@ -24,7 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
-	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
 )
 
 // An AccessControlListSpec defines the desired state of an AccessControlList
@@ -42,6 +42,11 @@ type AccessControlListStatus struct {
 // +kubebuilder:object:root=true
 
 // A AccessControlList is an example API type.
+//
+// Deprecated: use v1alpha2. The spec and status of v1alpha1 have the schema of
+// v1alpha2, so that the API server converts between the versions without
+// losing the lists of v1alpha2.
+// +kubebuilder:deprecatedversion:warning="acl.kafka.crossplane.io/v1alpha1 AccessControlList is deprecated; use v1alpha2"
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
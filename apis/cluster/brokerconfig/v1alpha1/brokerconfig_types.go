@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// A BrokerConfigSpec defines the desired state of a BrokerConfig.
+type BrokerConfigSpec struct {
+	xpv2.ClusterManagedResourceSpec `json:",inline"`
+	ForProvider                     common.BrokerConfigParameters `json:"forProvider"`
+}
+
+// A BrokerConfigStatus represents the observed state of a BrokerConfig.
+type BrokerConfigStatus struct {
+	xpv2.ManagedResourceStatus `json:",inline"`
+	AtProvider                 common.BrokerConfigObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BrokerConfig manages the dynamic configs of a Kafka broker, or the cluster-wide dynamic defaults of all brokers.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BROKER",type="integer",JSONPath=".spec.forProvider.brokerId"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type BrokerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BrokerConfigSpec   `json:"spec"`
+	Status BrokerConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BrokerConfigList contains a list of BrokerConfig
+type BrokerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BrokerConfig `json:"items"`
+}
+
+// BrokerConfig type metadata.
+var (
+	BrokerConfigKind             = reflect.TypeOf(BrokerConfig{}).Name()
+	BrokerConfigGroupKind        = schema.GroupKind{Group: Group, Kind: BrokerConfigKind}.String()
+	BrokerConfigKindAPIVersion   = BrokerConfigKind + "." + SchemeGroupVersion.String()
+	BrokerConfigGroupVersionKind = SchemeGroupVersion.WithKind(BrokerConfigKind)
+)
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &BrokerConfig{}, &BrokerConfigList{})
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// A DelegationTokenSpec defines the desired state of a DelegationToken.
+type DelegationTokenSpec struct {
+	xpv2.ClusterManagedResourceSpec `json:",inline"`
+	ForProvider                     common.DelegationTokenParameters `json:"forProvider"`
+}
+
+// A DelegationTokenStatus represents the observed state of a DelegationToken.
+type DelegationTokenStatus struct {
+	xpv2.ManagedResourceStatus `json:",inline"`
+	AtProvider                 common.DelegationTokenObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DelegationToken is a Kafka delegation token that is renewed before it expires.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="OWNER",type="string",JSONPath=".status.atProvider.owner"
+// +kubebuilder:printcolumn:name="EXPIRES",type="date",JSONPath=".status.atProvider.expiryTimestamp"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type DelegationToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DelegationTokenSpec   `json:"spec"`
+	Status DelegationTokenStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DelegationTokenList contains a list of DelegationToken
+type DelegationTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DelegationToken `json:"items"`
+}
+
+// DelegationToken type metadata.
+var (
+	DelegationTokenKind             = reflect.TypeOf(DelegationToken{}).Name()
+	DelegationTokenGroupKind        = schema.GroupKind{Group: Group, Kind: DelegationTokenKind}.String()
+	DelegationTokenKindAPIVersion   = DelegationTokenKind + "." + SchemeGroupVersion.String()
+	DelegationTokenGroupVersionKind = SchemeGroupVersion.WithKind(DelegationTokenKind)
+)
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &DelegationToken{}, &DelegationTokenList{})
+		return nil
+	})
+}
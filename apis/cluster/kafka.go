@@ -20,8 +20,15 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	accessv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/access/v1alpha1"
 	aclv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha1"
+	aclv1alpha2 "github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha2"
+	brokerconfigv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/brokerconfig/v1alpha1"
+	consumergroupv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/consumergroup/v1alpha1"
+	delegationtokenv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/delegationtoken/v1alpha1"
+	quotav1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/quota/v1alpha1"
 	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/topic/v1alpha1"
+	userv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/user/v1alpha1"
 	kafkav1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
 )
 
@@ -31,6 +38,13 @@ func init() {
 		kafkav1alpha1.SchemeBuilder.AddToScheme,
 		topicv1alpha1.SchemeBuilder.AddToScheme,
 		aclv1alpha1.SchemeBuilder.AddToScheme,
+		aclv1alpha2.SchemeBuilder.AddToScheme,
+		userv1alpha1.SchemeBuilder.AddToScheme,
+		consumergroupv1alpha1.SchemeBuilder.AddToScheme,
+		brokerconfigv1alpha1.SchemeBuilder.AddToScheme,
+		quotav1alpha1.SchemeBuilder.AddToScheme,
+		accessv1alpha1.SchemeBuilder.AddToScheme,
+		delegationtokenv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"reflect"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
+)
+
+// An AccessControlListSpec defines the desired state of an AccessControlList
+type AccessControlListSpec struct {
+	xpv2.ManagedResourceSpec `json:",inline"`
+	ForProvider              common.AccessControlListParameters `json:"forProvider"`
+}
+
+// A AccessControlListStatus represents the observed state of a AccessControlList.
+type AccessControlListStatus struct {
+	xpv2.ManagedResourceStatus `json:",inline"`
+	AtProvider                 common.AccessControlListObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A AccessControlList binds a principal to a set of Kafka ACLs, one for
+// every combination of its hosts, operations and resources.
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,kafka}
+type AccessControlList struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccessControlListSpec   `json:"spec"`
+	Status AccessControlListStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessControlListList contains a list of AccessControlList
+type AccessControlListList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessControlList `json:"items"`
+}
+
+// AccessControlList type metadata.
+var (
+	AccessControlListKind             = reflect.TypeOf(AccessControlList{}).Name()
+	AccessControlListGroupKind        = schema.GroupKind{Group: Group, Kind: AccessControlListKind}.String()
+	AccessControlListKindAPIVersion   = AccessControlListKind + "." + SchemeGroupVersion.String()
+	AccessControlListGroupVersionKind = SchemeGroupVersion.WithKind(AccessControlListKind)
+)
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &AccessControlList{}, &AccessControlListList{})
+		return nil
+	})
+}
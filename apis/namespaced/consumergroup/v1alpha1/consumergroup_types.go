@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// A ConsumerGroupSpec defines the desired state of a ConsumerGroup.
+type ConsumerGroupSpec struct {
+	xpv2.ManagedResourceSpec `json:",inline"`
+	ForProvider              common.ConsumerGroupParameters `json:"forProvider"`
+}
+
+// A ConsumerGroupStatus represents the observed state of a ConsumerGroup.
+type ConsumerGroupStatus struct {
+	xpv2.ManagedResourceStatus `json:",inline"`
+	AtProvider                 common.ConsumerGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ConsumerGroup observes and manages the committed offsets of a Kafka consumer group.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="LAG",type="integer",JSONPath=".status.atProvider.totalLag"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,kafka}
+type ConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsumerGroupSpec   `json:"spec"`
+	Status ConsumerGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConsumerGroupList contains a list of ConsumerGroup
+type ConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsumerGroup `json:"items"`
+}
+
+// ConsumerGroup type metadata.
+var (
+	ConsumerGroupKind             = reflect.TypeOf(ConsumerGroup{}).Name()
+	ConsumerGroupGroupKind        = schema.GroupKind{Group: Group, Kind: ConsumerGroupKind}.String()
+	ConsumerGroupKindAPIVersion   = ConsumerGroupKind + "." + SchemeGroupVersion.String()
+	ConsumerGroupGroupVersionKind = SchemeGroupVersion.WithKind(ConsumerGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &ConsumerGroup{}, &ConsumerGroupList{})
+		return nil
+	})
+}
@@ -3,11 +3,18 @@ package v1alpha1
 import (
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
 )
 
 // A ProviderConfigStatus defines the status of a Provider.
 type ProviderConfigStatus struct {
 	xpv2.ProviderConfigStatus `json:",inline"`
+
+	// Cluster is the observed state of the Kafka cluster, as of the last
+	// successful health check.
+	// +optional
+	Cluster *common.ClusterObservation `json:"cluster,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -19,15 +26,27 @@ type ProviderCredentials struct {
 	xpv2.CommonCredentialSelectors `json:",inline"`
 }
 
+// A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
-	// Credentials required to authenticate to this provider.
+	// Credentials required to authenticate to this provider. The credentials
+	// are a JSON Kafka client configuration, see
+	// internal/clients/kafka/config.go. Settings that are also set in
+	// Connection are ignored. Use source None to configure the connection
+	// with Connection only.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// Connection configures the connection to the Kafka cluster with typed
+	// fields, taking precedence over the JSON credentials.
+	// +optional
+	Connection *common.ConnectionConfig `json:"connection,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="KAFKA-VERSION",type="string",JSONPath=".status.cluster.kafkaVersion"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Namespaced,categories={crossplane,provider,kafka}
@@ -77,6 +96,8 @@ type ProviderConfigUsageList struct {
 // +kubebuilder:object:root=true
 
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="KAFKA-VERSION",type="string",JSONPath=".status.cluster.kafkaVersion"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kafka}
@@ -0,0 +1,157 @@
+package v1alpha1
+
+// Roles of a KafkaAccessIntent.
+const (
+	KafkaAccessRoleProducer              = "Producer"
+	KafkaAccessRoleConsumer              = "Consumer"
+	KafkaAccessRoleTransactionalProducer = "TransactionalProducer"
+)
+
+// ACL ownership modes of a KafkaAccess.
+const (
+	ACLOwnershipShared        = "Shared"
+	ACLOwnershipDryRun        = "DryRun"
+	ACLOwnershipAuthoritative = "Authoritative"
+)
+
+// KafkaAccessParameters are the configurable fields of a KafkaAccess.
+type KafkaAccessParameters struct {
+	// Principal is the principal that is granted access, for example
+	// User:alice.
+	// +kubebuilder:validation:MinLength=1
+	Principal string `json:"principal"`
+	// Hosts are the hosts from which the principal has access. All hosts
+	// (*) are allowed if it is empty.
+	// +optional
+	// +listType=set
+	Hosts []string `json:"hosts,omitempty"`
+	// Intents are the client patterns the principal is granted the ACLs of.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=atomic
+	Intents []KafkaAccessIntent `json:"intents"`
+	// ACLOwnership is whether the KafkaAccess owns every ACL binding of the
+	// principal. Shared leaves the bindings that no AccessControlList or
+	// KafkaAccess of the same ProviderConfig declares alone. DryRun lists them
+	// in status.atProvider.undeclaredBindings. Authoritative also deletes
+	// them.
+	// +optional
+	// +kubebuilder:validation:Enum=Shared;DryRun;Authoritative
+	// +kubebuilder:default=Shared
+	ACLOwnership string `json:"aclOwnership,omitempty"`
+}
+
+// A KafkaAccessIntent is a client pattern of a principal.
+// +kubebuilder:validation:XValidation:rule="self.role == 'Consumer' ? has(self.group) : !has(self.group)",message="group is required for the Consumer role and not allowed otherwise"
+// +kubebuilder:validation:XValidation:rule="self.role == 'TransactionalProducer' ? has(self.transactionalId) : !has(self.transactionalId)",message="transactionalId is required for the TransactionalProducer role and not allowed otherwise"
+type KafkaAccessIntent struct {
+	// Role is the client pattern. A Producer writes to the topic with an
+	// idempotent producer, a Consumer reads the topic as a member of the
+	// group, and a TransactionalProducer writes to the topic in transactions
+	// of the transactional ID.
+	// +kubebuilder:validation:Enum=Producer;Consumer;TransactionalProducer
+	Role string `json:"role"`
+	// Topic is the topic name, or prefix if TopicPatternType is Prefixed.
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+	// TopicPatternType is the pattern type of Topic.
+	// +optional
+	// +kubebuilder:validation:Enum=Literal;Prefixed
+	// +kubebuilder:default=Literal
+	TopicPatternType string `json:"topicPatternType,omitempty"`
+	// Group is the consumer group ID, or prefix if GroupPatternType is
+	// Prefixed, of a Consumer.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group,omitempty"`
+	// GroupPatternType is the pattern type of Group.
+	// +optional
+	// +kubebuilder:validation:Enum=Literal;Prefixed
+	// +kubebuilder:default=Literal
+	GroupPatternType string `json:"groupPatternType,omitempty"`
+	// TransactionalID is the transactional ID, or prefix if
+	// TransactionalIDPatternType is Prefixed, of a TransactionalProducer.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	TransactionalID string `json:"transactionalId,omitempty"`
+	// TransactionalIDPatternType is the pattern type of TransactionalID.
+	// +optional
+	// +kubebuilder:validation:Enum=Literal;Prefixed
+	// +kubebuilder:default=Literal
+	TransactionalIDPatternType string `json:"transactionalIdPatternType,omitempty"`
+}
+
+// KafkaAccessObservation are the observable fields of a KafkaAccess.
+type KafkaAccessObservation struct {
+	// Bindings is the number of ACL bindings the intents expand to.
+	Bindings int `json:"bindings,omitempty"`
+	// MissingBindings are the ACL bindings of the KafkaAccess that Kafka
+	// does not hold.
+	MissingBindings []string `json:"missingBindings,omitempty"`
+	// UndeclaredBindings are the ACL bindings of the principal that no
+	// AccessControlList or KafkaAccess declares. They are only observed if
+	// ACLOwnership is DryRun or Authoritative, and deleted if it is
+	// Authoritative.
+	UndeclaredBindings []string `json:"undeclaredBindings,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAccessParameters) DeepCopyInto(out *KafkaAccessParameters) {
+	*out = *in
+	if in.Hosts != nil {
+		out.Hosts = make([]string, len(in.Hosts))
+		copy(out.Hosts, in.Hosts)
+	}
+	if in.Intents != nil {
+		out.Intents = make([]KafkaAccessIntent, len(in.Intents))
+		copy(out.Intents, in.Intents)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new KafkaAccessParameters.
+func (in *KafkaAccessParameters) DeepCopy() *KafkaAccessParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaAccessParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAccessIntent) DeepCopyInto(out *KafkaAccessIntent) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new KafkaAccessIntent.
+func (in *KafkaAccessIntent) DeepCopy() *KafkaAccessIntent {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaAccessIntent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAccessObservation) DeepCopyInto(out *KafkaAccessObservation) {
+	*out = *in
+	if in.MissingBindings != nil {
+		out.MissingBindings = make([]string, len(in.MissingBindings))
+		copy(out.MissingBindings, in.MissingBindings)
+	}
+	if in.UndeclaredBindings != nil {
+		out.UndeclaredBindings = make([]string, len(in.UndeclaredBindings))
+		copy(out.UndeclaredBindings, in.UndeclaredBindings)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new KafkaAccessObservation.
+func (in *KafkaAccessObservation) DeepCopy() *KafkaAccessObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaAccessObservation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,5 +1,48 @@
 package v1alpha1
 
+import (
+	"strings"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypeReplaced is the condition type that reports whether the Kafka ACL
+// binding of an AccessControlList was replaced after its parameters changed.
+const TypeReplaced xpv2.ConditionType = "Replaced"
+
+// Reasons an AccessControlList's binding is or is not replaced.
+const (
+	ReasonReplaced      xpv2.ConditionReason = "Replaced"
+	ReasonReplaceFailed xpv2.ConditionReason = "ReplaceFailed"
+)
+
+// Replaced returns a condition that indicates the Kafka ACL binding of the
+// AccessControlList was replaced by a binding with the changed fields.
+func Replaced(changed []string) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeReplaced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReplaced,
+		Message:            "replaced binding to change " + strings.Join(changed, ", "),
+	}
+}
+
+// ReplaceFailed returns a condition that indicates the Kafka ACL binding of
+// the AccessControlList could not be replaced. The previous binding is only
+// deleted once its replacement exists, so it may still be in place.
+func ReplaceFailed(err error) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeReplaced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReplaceFailed,
+		Message:            err.Error(),
+	}
+}
+
 // AccessControlListObservation are the observable fields of an AccessControlList.
 type AccessControlListObservation struct {
 	ID                        string `json:"id,omitempty"`
@@ -28,20 +71,26 @@ func (in *AccessControlListObservation) DeepCopy() *AccessControlListObservation
 }
 
 // AccessControlListParameters are the configurable fields of a AccessControlList.
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'Topic' || self.resourceOperation in ['Read', 'Write', 'Create', 'Delete', 'Alter', 'Describe', 'DescribeConfigs', 'AlterConfigs', 'All', 'Any']",message="Topic resources only accept the Read, Write, Create, Delete, Alter, Describe, DescribeConfigs, AlterConfigs and All operations"
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'Group' || self.resourceOperation in ['Read', 'Describe', 'Delete', 'All', 'Any']",message="Group resources only accept the Read, Describe, Delete and All operations"
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'Cluster' || self.resourceOperation in ['Create', 'Alter', 'Describe', 'ClusterAction', 'DescribeConfigs', 'AlterConfigs', 'IdempotentWrite', 'All', 'Any']",message="Cluster resources only accept the Create, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite and All operations"
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'TransactionalID' || self.resourceOperation in ['Write', 'Describe', 'All', 'Any']",message="TransactionalID resources only accept the Write, Describe and All operations"
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'DelegationToken' || self.resourceOperation in ['Describe', 'All', 'Any']",message="DelegationToken resources only accept the Describe and All operations"
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'User' || self.resourceOperation in ['CreateTokens', 'DescribeTokens', 'All', 'Any']",message="User resources only accept the CreateTokens, DescribeTokens and All operations"
 type AccessControlListParameters struct {
 	// ResourceName is the name of the resource.
 	ResourceName string `json:"resourceName"`
 	// ResourceType is the type of resource.
-	// Valid values are Unknown, Any, Topic, Group, Cluster, TransactionalID
-	// +kubebuilder:validation:Enum=Unknown;Any;Topic;Group;Cluster;TransactionalID
+	// Valid values are Unknown, Any, Topic, Group, Cluster, TransactionalID, DelegationToken, User
+	// +kubebuilder:validation:Enum=Unknown;Any;Topic;Group;Cluster;TransactionalID;DelegationToken;User
 	ResourceType string `json:"resourceType"`
 	// ResourcePrincipal is the Principal that is being allowed or denied.
 	ResourcePrincipal string `json:"resourcePrincipal"`
 	// ResourceHost is the Host from which principal listed in ResourcePrinciple will have access.
 	ResourceHost string `json:"resourceHost"`
 	// ResourceOperation is the Operation that is being allowed or denied.
-	// Valid values are Unknown, Any, All, Read, Write, Create, Delete, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite.
-	// +kubebuilder:validation:Enum=Unknown;Any;All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite
+	// Valid values are Unknown, Any, All, Read, Write, Create, Delete, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite, CreateTokens, DescribeTokens.
+	// +kubebuilder:validation:Enum=Unknown;Any;All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite;CreateTokens;DescribeTokens
 	ResourceOperation string `json:"resourceOperation"`
 	// ResourcePermissionType is the Type of permission.
 	// Valid values are Unknown, Any, Allow, Deny.
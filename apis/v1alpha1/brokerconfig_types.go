@@ -0,0 +1,117 @@
+package v1alpha1
+
+// BrokerConfigParameters are the configurable fields of a BrokerConfig.
+// +kubebuilder:validation:XValidation:rule="has(self.brokerId) == has(oldSelf.brokerId)",message="brokerId is immutable"
+type BrokerConfigParameters struct {
+	// BrokerID is the ID of the broker whose dynamic configs are managed.
+	// When unset, the cluster-wide dynamic defaults that apply to all
+	// brokers are managed instead.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="brokerId is immutable"
+	BrokerID *int32 `json:"brokerId,omitempty"`
+	// Config are the dynamic broker configs to set, for example
+	// log.retention.ms. A key with a null value is removed from the
+	// dynamic config so that it falls back to the next config source.
+	// +optional
+	Config map[string]*string `json:"config,omitempty"`
+}
+
+// BrokerConfigValue is the observed value of a single broker config.
+type BrokerConfigValue struct {
+	// Name is the name of the config.
+	Name string `json:"name"`
+	// Value is the effective value of the config. It is unset for sensitive
+	// configs.
+	// +optional
+	Value *string `json:"value,omitempty"`
+	// Source is where the effective value is defined, for example
+	// DYNAMIC_BROKER_CONFIG, DYNAMIC_DEFAULT_BROKER_CONFIG,
+	// STATIC_BROKER_CONFIG or DEFAULT_CONFIG.
+	Source string `json:"source"`
+	// Sensitive is true if Kafka does not return the value of the config.
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// BrokerConfigObservation are the observable fields of a BrokerConfig.
+type BrokerConfigObservation struct {
+	// Configs are the observed values of the configs in the spec.
+	// +optional
+	Configs []BrokerConfigValue `json:"configs,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigParameters) DeepCopyInto(out *BrokerConfigParameters) {
+	*out = *in
+	if in.BrokerID != nil {
+		in, out := &in.BrokerID, &out.BrokerID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val != nil {
+				in, out := &val, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new BrokerConfigParameters.
+func (in *BrokerConfigParameters) DeepCopy() *BrokerConfigParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigValue) DeepCopyInto(out *BrokerConfigValue) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new BrokerConfigValue.
+func (in *BrokerConfigValue) DeepCopy() *BrokerConfigValue {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigObservation) DeepCopyInto(out *BrokerConfigObservation) {
+	*out = *in
+	if in.Configs != nil {
+		in, out := &in.Configs, &out.Configs
+		*out = make([]BrokerConfigValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new BrokerConfigObservation.
+func (in *BrokerConfigObservation) DeepCopy() *BrokerConfigObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigObservation)
+	in.DeepCopyInto(out)
+	return out
+}
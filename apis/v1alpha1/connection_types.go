@@ -0,0 +1,489 @@
+package v1alpha1
+
+import (
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+)
+
+// ConnectionConfig configures the connection to a Kafka cluster with typed
+// fields. Each of brokers, sasl, tls and client is taken from here when set,
+// and from the JSON credentials otherwise. The sasl, tls and client blocks are
+// not merged field by field: when set here, the block of the JSON credentials
+// is ignored.
+type ConnectionConfig struct {
+	// Brokers are the bootstrap broker addresses, for example
+	// kafka-0.kafka-headless:9092.
+	// +optional
+	// +kubebuilder:validation:items:MinLength=1
+	Brokers []string `json:"brokers,omitempty"`
+
+	// SASL configures SASL authentication.
+	// +optional
+	SASL *SASLConfig `json:"sasl,omitempty"`
+
+	// TLS configures encryption in transit.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Client tunes the connections and requests of the Kafka client,
+	// overriding the provider flags.
+	// +optional
+	Client *ClientConfig `json:"client,omitempty"`
+}
+
+// SASLConfig configures SASL authentication.
+// +kubebuilder:validation:XValidation:rule="!(self.mechanism in ['PLAIN', 'SCRAM-SHA-256', 'SCRAM-SHA-512']) || (has(self.username) && has(self.passwordSecretRef))",message="username and passwordSecretRef are required for PLAIN and SCRAM mechanisms"
+// +kubebuilder:validation:XValidation:rule="self.mechanism != 'OAUTHBEARER' || has(self.oauth)",message="oauth is required for the OAUTHBEARER mechanism"
+// +kubebuilder:validation:XValidation:rule="self.mechanism != 'GSSAPI' || has(self.kerberos)",message="kerberos is required for the GSSAPI mechanism"
+type SASLConfig struct {
+	// Mechanism is the SASL mechanism.
+	// +kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512;AWS-MSK-IAM;OAUTHBEARER;GSSAPI
+	Mechanism string `json:"mechanism"`
+
+	// Username of the PLAIN and SCRAM mechanisms.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef selects the password of the PLAIN and SCRAM
+	// mechanisms.
+	// +optional
+	PasswordSecretRef *xpv2.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// RoleArn is an IAM role assumed by the AWS-MSK-IAM mechanism.
+	// +optional
+	RoleArn string `json:"roleArn,omitempty"`
+
+	// IAMCredentialsExpiryWindow controls how early cached STS credentials
+	// of the AWS-MSK-IAM mechanism are refreshed before expiry, for example
+	// "5m". Defaults to "5m", maximum "15m".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	IAMCredentialsExpiryWindow string `json:"iamCredentialsExpiryWindow,omitempty"`
+
+	// OAuth configures the OAUTHBEARER mechanism.
+	// +optional
+	OAuth *OAuthConfig `json:"oauth,omitempty"`
+
+	// Kerberos configures the GSSAPI mechanism.
+	// +optional
+	Kerberos *KerberosConfig `json:"kerberos,omitempty"`
+}
+
+// OAuthConfig configures the OAuth 2.0 client credentials flow of the
+// OAUTHBEARER mechanism.
+type OAuthConfig struct {
+	// TokenEndpoint is the URL of the token endpoint of the identity provider.
+	// +kubebuilder:validation:Pattern=`^https?://.+`
+	TokenEndpoint string `json:"tokenEndpoint"`
+
+	// ClientID is the OAuth client ID.
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientId"`
+
+	// ClientSecretSecretRef selects the OAuth client secret.
+	ClientSecretSecretRef xpv2.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// Scopes are the scopes requested for the token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Audience is sent as the audience parameter of the token request.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// Extensions are SASL extensions sent with the token, for example the
+	// logicalCluster and identityPoolId required by Confluent Cloud.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+
+	// TokenExpiryWindow controls how early cached tokens are refreshed before
+	// expiry, for example "1m". Defaults to "1m".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	TokenExpiryWindow string `json:"tokenExpiryWindow,omitempty"`
+}
+
+// KerberosConfig configures the keytab login of the GSSAPI mechanism.
+// +kubebuilder:validation:XValidation:rule="has(self.keytabSecretRef) != has(self.keytabPath)",message="exactly one of keytabSecretRef and keytabPath is required"
+type KerberosConfig struct {
+	// Principal is the client principal without realm, for example
+	// kafka-admin.
+	// +kubebuilder:validation:MinLength=1
+	Principal string `json:"principal"`
+
+	// Realm is the Kerberos realm of the principal.
+	// +kubebuilder:validation:MinLength=1
+	Realm string `json:"realm"`
+
+	// ServiceName is the primary of the broker service principal. Defaults to
+	// kafka.
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// Krb5Conf is the content of the krb5.conf file describing the realm.
+	// +kubebuilder:validation:MinLength=1
+	Krb5Conf string `json:"krb5Conf"`
+
+	// KeytabSecretRef selects the keytab.
+	// +optional
+	KeytabSecretRef *xpv2.SecretKeySelector `json:"keytabSecretRef,omitempty"`
+
+	// KeytabPath is the path of a keytab file mounted into the provider.
+	// +optional
+	KeytabPath string `json:"keytabPath,omitempty"`
+
+	// DisablePAFXFAST disables the PA-FX-FAST pre-authentication, which is
+	// required for Active Directory KDCs.
+	// +optional
+	DisablePAFXFAST bool `json:"disablePAFXFAST,omitempty"`
+}
+
+// TLSConfig configures encryption in transit.
+// +kubebuilder:validation:XValidation:rule="!(has(self.clientCertificateSecretRef) && has(self.clientCertificatePath))",message="at most one of clientCertificateSecretRef and clientCertificatePath may be set"
+type TLSConfig struct {
+	// InsecureSkipVerify skips the verification of the broker certificates.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CACertificateSecretRef selects a CA certificate used to verify the
+	// brokers.
+	// +optional
+	CACertificateSecretRef *xpv2.SecretKeySelector `json:"caCertificateSecretRef,omitempty"`
+
+	// CACertificateFile is the path of a CA certificate file mounted into the
+	// provider.
+	// +optional
+	CACertificateFile string `json:"caCertificateFile,omitempty"`
+
+	// ClientCertificateSecretRef references a Secret containing the client
+	// certificate and key for mutual TLS.
+	// +optional
+	ClientCertificateSecretRef *ClientCertificateSecretReference `json:"clientCertificateSecretRef,omitempty"`
+
+	// ClientCertificatePath reads the client certificate and key for mutual
+	// TLS from files mounted into the provider, reloading them on every
+	// handshake.
+	// +optional
+	ClientCertificatePath *ClientCertificatePath `json:"clientCertificatePath,omitempty"`
+
+	// MinVersion is the minimum TLS version.
+	// +optional
+	// +kubebuilder:validation:Enum=TLS12;TLS13
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// MaxVersion is the maximum TLS version.
+	// +optional
+	// +kubebuilder:validation:Enum=TLS12;TLS13
+	MaxVersion string `json:"maxVersion,omitempty"`
+
+	// CipherSuites lists cipher suite names for TLS 1.2 negotiation, as
+	// returned by Go's tls.CipherSuites().
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// CurvePreferences lists the elliptic curves in preference order.
+	// +optional
+	// +kubebuilder:validation:items:Enum=P256;P384;P521;X25519
+	CurvePreferences []string `json:"curvePreferences,omitempty"`
+
+	// ServerName overrides the server name used to verify the brokers.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// NextProtos lists the ALPN protocols.
+	// +optional
+	NextProtos []string `json:"nextProtos,omitempty"`
+
+	// SessionTicketsDisabled disables session resumption with tickets.
+	// +optional
+	SessionTicketsDisabled bool `json:"sessionTicketsDisabled,omitempty"`
+
+	// DynamicRecordSizingDisabled disables adaptive TLS record sizing.
+	// +optional
+	DynamicRecordSizingDisabled bool `json:"dynamicRecordSizingDisabled,omitempty"`
+
+	// ClientSessionCacheCapacity is the size of the TLS session cache. 0
+	// disables session caching.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ClientSessionCacheCapacity int `json:"clientSessionCacheCapacity,omitempty"`
+
+	// DialTimeoutSeconds is the timeout for establishing TLS connections.
+	// Defaults to 10 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DialTimeoutSeconds int `json:"dialTimeoutSeconds,omitempty"`
+}
+
+// ClientCertificateSecretReference references a Secret containing a client
+// certificate and key.
+type ClientCertificateSecretReference struct {
+	xpv2.SecretReference `json:",inline"`
+
+	// CertField is the Secret key of the certificate. Defaults to tls.crt.
+	// +optional
+	CertField string `json:"certField,omitempty"`
+
+	// KeyField is the Secret key of the private key. Defaults to tls.key.
+	// +optional
+	KeyField string `json:"keyField,omitempty"`
+}
+
+// ClientCertificatePath locates a client certificate and key on disk.
+type ClientCertificatePath struct {
+	// CertFile is the path of the certificate.
+	CertFile string `json:"certFile"`
+
+	// KeyFile is the path of the private key.
+	KeyFile string `json:"keyFile"`
+}
+
+// ClientConfig tunes the connections and requests of the Kafka client.
+// Unset fields default to the provider flags.
+type ClientConfig struct {
+	// DialTimeout is the timeout for opening a connection to a broker, for
+	// example "10s". It takes precedence over tls.dialTimeoutSeconds.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	DialTimeout string `json:"dialTimeout,omitempty"`
+
+	// RequestTimeout is the time a broker has to answer a request, on top of
+	// any timeout carried by the request itself.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+
+	// RequestRetries is the number of times a failed request is retried.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RequestRetries *int `json:"requestRetries,omitempty"`
+
+	// RetryBackoff is the fixed time to wait between retries.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	RetryBackoff string `json:"retryBackoff,omitempty"`
+
+	// MetadataMaxAge is how often the cluster metadata is refreshed.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	MetadataMaxAge string `json:"metadataMaxAge,omitempty"`
+
+	// ConnIdleTimeout is how long an unused connection is kept open.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	ConnIdleTimeout string `json:"connIdleTimeout,omitempty"`
+
+	// ClientID is the client ID sent to the brokers.
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+
+	// Rack is the rack of the client.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionConfig) DeepCopyInto(out *ConnectionConfig) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(SASLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Client != nil {
+		in, out := &in.Client, &out.Client
+		*out = new(ClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ConnectionConfig.
+func (in *ConnectionConfig) DeepCopy() *ConnectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SASLConfig) DeepCopyInto(out *SASLConfig) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(xpv2.SecretKeySelector)
+		**out = **in
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(OAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new SASLConfig.
+func (in *SASLConfig) DeepCopy() *SASLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SASLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthConfig) DeepCopyInto(out *OAuthConfig) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new OAuthConfig.
+func (in *OAuthConfig) DeepCopy() *OAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KerberosConfig) DeepCopyInto(out *KerberosConfig) {
+	*out = *in
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(xpv2.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new KerberosConfig.
+func (in *KerberosConfig) DeepCopy() *KerberosConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KerberosConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.CACertificateSecretRef != nil {
+		in, out := &in.CACertificateSecretRef, &out.CACertificateSecretRef
+		*out = new(xpv2.SecretKeySelector)
+		**out = **in
+	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(ClientCertificateSecretReference)
+		**out = **in
+	}
+	if in.ClientCertificatePath != nil {
+		in, out := &in.ClientCertificatePath, &out.ClientCertificatePath
+		*out = new(ClientCertificatePath)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurvePreferences != nil {
+		in, out := &in.CurvePreferences, &out.CurvePreferences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextProtos != nil {
+		in, out := &in.NextProtos, &out.NextProtos
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateSecretReference) DeepCopyInto(out *ClientCertificateSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClientCertificateSecretReference.
+func (in *ClientCertificateSecretReference) DeepCopy() *ClientCertificateSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificatePath) DeepCopyInto(out *ClientCertificatePath) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClientCertificatePath.
+func (in *ClientCertificatePath) DeepCopy() *ClientCertificatePath {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificatePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientConfig) DeepCopyInto(out *ClientConfig) {
+	*out = *in
+	if in.RequestRetries != nil {
+		in, out := &in.RequestRetries, &out.RequestRetries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClientConfig.
+func (in *ClientConfig) DeepCopy() *ClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,169 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConsumerGroupParameters are the configurable fields of a ConsumerGroup.
+type ConsumerGroupParameters struct {
+	// OffsetReset resets the committed offsets of the group. Every distinct
+	// reset is applied once, and only while the group has no active members.
+	// +optional
+	OffsetReset *ConsumerGroupOffsetReset `json:"offsetReset,omitempty"`
+	// DeleteGroup deletes the consumer group from Kafka when the resource is
+	// deleted. By default the group is left in place.
+	// +optional
+	DeleteGroup bool `json:"deleteGroup,omitempty"`
+}
+
+// ConsumerGroupOffsetReset describes how the committed offsets of a group
+// are reset.
+// +kubebuilder:validation:XValidation:rule="self.strategy != 'Timestamp' || has(self.timestamp)",message="timestamp is required for the Timestamp strategy"
+// +kubebuilder:validation:XValidation:rule="self.strategy != 'Explicit' || has(self.offsets)",message="offsets are required for the Explicit strategy"
+type ConsumerGroupOffsetReset struct {
+	// Strategy selects the offsets the group is reset to.
+	// Valid values are Earliest, Latest, Timestamp, Explicit.
+	// +kubebuilder:validation:Enum=Earliest;Latest;Timestamp;Explicit
+	Strategy string `json:"strategy"`
+	// Topics limits the reset to the listed topics. Defaults to all topics
+	// the group has committed offsets for. Ignored by the Explicit strategy.
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+	// Timestamp is used by the Timestamp strategy. Each partition is reset to
+	// its first offset at or after this time, or to its end offset if there
+	// is none.
+	// +optional
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+	// Offsets are the per-partition offsets used by the Explicit strategy.
+	// +optional
+	Offsets []ConsumerGroupPartitionOffset `json:"offsets,omitempty"`
+	// Revision is an arbitrary value that can be changed to repeat a reset
+	// whose other fields are unchanged.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+}
+
+// ConsumerGroupPartitionOffset is an offset for a single partition.
+type ConsumerGroupPartitionOffset struct {
+	// Topic is the name of the topic.
+	Topic string `json:"topic"`
+	// Partition is the partition number.
+	// +kubebuilder:validation:Minimum:=0
+	Partition int32 `json:"partition"`
+	// Offset is the offset to commit.
+	// +kubebuilder:validation:Minimum:=0
+	Offset int64 `json:"offset"`
+}
+
+// ConsumerGroupPartitionLag is the committed offset and lag of a group for a
+// single partition.
+type ConsumerGroupPartitionLag struct {
+	// Topic is the name of the topic.
+	Topic string `json:"topic"`
+	// Partition is the partition number.
+	Partition int32 `json:"partition"`
+	// CommittedOffset is the offset committed by the group, or -1 if the
+	// group has not committed an offset for the partition.
+	CommittedOffset int64 `json:"committedOffset"`
+	// EndOffset is the log end offset of the partition.
+	EndOffset int64 `json:"endOffset"`
+	// Lag is the number of records the group is behind, or -1 if it could
+	// not be calculated.
+	Lag int64 `json:"lag"`
+	// MemberID is the group member the partition is assigned to, if any.
+	// +optional
+	MemberID string `json:"memberId,omitempty"`
+}
+
+// ConsumerGroupObservation are the observable fields of a ConsumerGroup.
+type ConsumerGroupObservation struct {
+	// State is the state of the group, for example Empty, Stable or Dead.
+	State string `json:"state,omitempty"`
+	// ProtocolType is the protocol type of the group, "consumer" for
+	// regular consumer groups.
+	ProtocolType string `json:"protocolType,omitempty"`
+	// Members is the number of active members in the group.
+	Members int `json:"members,omitempty"`
+	// TotalLag is the sum of the lag of all partitions.
+	TotalLag int64 `json:"totalLag,omitempty"`
+	// Partitions are the committed offsets and lag per partition.
+	// +optional
+	Partitions []ConsumerGroupPartitionLag `json:"partitions,omitempty"`
+	// AppliedOffsetReset is the offset reset that was last applied.
+	// +optional
+	AppliedOffsetReset *ConsumerGroupOffsetReset `json:"appliedOffsetReset,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupParameters) DeepCopyInto(out *ConsumerGroupParameters) {
+	*out = *in
+	if in.OffsetReset != nil {
+		in, out := &in.OffsetReset, &out.OffsetReset
+		*out = new(ConsumerGroupOffsetReset)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ConsumerGroupParameters.
+func (in *ConsumerGroupParameters) DeepCopy() *ConsumerGroupParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupOffsetReset) DeepCopyInto(out *ConsumerGroupOffsetReset) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Offsets != nil {
+		in, out := &in.Offsets, &out.Offsets
+		*out = make([]ConsumerGroupPartitionOffset, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ConsumerGroupOffsetReset.
+func (in *ConsumerGroupOffsetReset) DeepCopy() *ConsumerGroupOffsetReset {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupOffsetReset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupObservation) DeepCopyInto(out *ConsumerGroupObservation) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]ConsumerGroupPartitionLag, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedOffsetReset != nil {
+		in, out := &in.AppliedOffsetReset, &out.AppliedOffsetReset
+		*out = new(ConsumerGroupOffsetReset)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ConsumerGroupObservation.
+func (in *ConsumerGroupObservation) DeepCopy() *ConsumerGroupObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupObservation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// DelegationTokenParameters are the configurable fields of a DelegationToken.
+// +kubebuilder:validation:XValidation:rule="has(self.owner) == has(oldSelf.owner) && has(self.renewers) == has(oldSelf.renewers) && has(self.maxLifetime) == has(oldSelf.maxLifetime)",message="owner, renewers and maxLifetime are immutable"
+type DelegationTokenParameters struct {
+	// Owner is the principal that owns the token, for example User:alice.
+	// The token has the ACLs of its owner. Defaults to the principal of the
+	// ProviderConfig. Creating tokens for other owners requires Kafka 3.3 or
+	// later.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[^:]+:.+$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="owner is immutable"
+	Owner string `json:"owner,omitempty"`
+	// Renewers are the principals that may renew the token besides its
+	// owner.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:Pattern=`^[^:]+:.+$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="renewers are immutable"
+	Renewers []string `json:"renewers,omitempty"`
+	// MaxLifetime is how long the token can be renewed for. Defaults to the
+	// delegation.token.max.lifetime.ms of the brokers, which is 7 days unless
+	// configured otherwise.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="maxLifetime is immutable"
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
+	// RenewBefore is how long before it expires the token is renewed. A
+	// token that is this close to its max lifetime is replaced by a new token
+	// instead. It should be well above the poll interval of the provider.
+	// +optional
+	// +kubebuilder:default="1h"
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// DelegationTokenObservation are the observable fields of a DelegationToken.
+type DelegationTokenObservation struct {
+	// TokenID is the ID of the token, which clients use as SCRAM username.
+	TokenID string `json:"tokenId,omitempty"`
+	// Owner is the principal that owns the token.
+	Owner string `json:"owner,omitempty"`
+	// Renewers are the principals that may renew the token besides its
+	// owner.
+	Renewers []string `json:"renewers,omitempty"`
+	// IssueTimestamp is when the token was created.
+	IssueTimestamp *metav1.Time `json:"issueTimestamp,omitempty"`
+	// ExpiryTimestamp is when the token expires unless it is renewed.
+	ExpiryTimestamp *metav1.Time `json:"expiryTimestamp,omitempty"`
+	// MaxTimestamp is when the token expires at the latest.
+	MaxTimestamp *metav1.Time `json:"maxTimestamp,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegationTokenParameters) DeepCopyInto(out *DelegationTokenParameters) {
+	*out = *in
+	if in.Renewers != nil {
+		out.Renewers = make([]string, len(in.Renewers))
+		copy(out.Renewers, in.Renewers)
+	}
+	if in.MaxLifetime != nil {
+		out.MaxLifetime = new(metav1.Duration)
+		*out.MaxLifetime = *in.MaxLifetime
+	}
+	if in.RenewBefore != nil {
+		out.RenewBefore = new(metav1.Duration)
+		*out.RenewBefore = *in.RenewBefore
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new DelegationTokenParameters.
+func (in *DelegationTokenParameters) DeepCopy() *DelegationTokenParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegationTokenParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegationTokenObservation) DeepCopyInto(out *DelegationTokenObservation) {
+	*out = *in
+	if in.Renewers != nil {
+		out.Renewers = make([]string, len(in.Renewers))
+		copy(out.Renewers, in.Renewers)
+	}
+	if in.IssueTimestamp != nil {
+		out.IssueTimestamp = in.IssueTimestamp.DeepCopy()
+	}
+	if in.ExpiryTimestamp != nil {
+		out.ExpiryTimestamp = in.ExpiryTimestamp.DeepCopy()
+	}
+	if in.MaxTimestamp != nil {
+		out.MaxTimestamp = in.MaxTimestamp.DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new DelegationTokenObservation.
+func (in *DelegationTokenObservation) DeepCopy() *DelegationTokenObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegationTokenObservation)
+	in.DeepCopyInto(out)
+	return out
+}
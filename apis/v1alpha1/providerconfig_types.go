@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reasons a ProviderConfig's Kafka cluster is or is not available.
+const (
+	ReasonClusterAvailable     xpv2.ConditionReason = "Available"
+	ReasonAuthenticationFailed xpv2.ConditionReason = "AuthenticationFailed"
+	ReasonTLSHandshakeFailed   xpv2.ConditionReason = "TLSHandshakeFailed"
+	ReasonBrokersUnreachable   xpv2.ConditionReason = "BrokersUnreachable"
+	ReasonInvalidConfig        xpv2.ConditionReason = "InvalidConfig"
+)
+
+// ClusterAvailable returns a condition that indicates the Kafka cluster of a
+// ProviderConfig answered the last health check.
+func ClusterAvailable() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               xpv2.TypeReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClusterAvailable,
+	}
+}
+
+// ClusterUnavailable returns a condition that indicates the last health check
+// of the Kafka cluster of a ProviderConfig failed for the supplied reason.
+func ClusterUnavailable(reason xpv2.ConditionReason, err error) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               xpv2.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            err.Error(),
+	}
+}
+
+// ClusterObservation is the observed state of the Kafka cluster of a
+// ProviderConfig, as of the last successful health check.
+type ClusterObservation struct {
+	// ClusterID is the ID of the Kafka cluster.
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// ControllerID is the node ID of the controller broker, if known.
+	// +optional
+	ControllerID *int32 `json:"controllerID,omitempty"`
+
+	// BrokerCount is the number of brokers in the cluster.
+	// +optional
+	BrokerCount int `json:"brokerCount,omitempty"`
+
+	// KafkaVersion is the Kafka version of the controller broker, as guessed
+	// from the API versions it supports.
+	// +optional
+	KafkaVersion string `json:"kafkaVersion,omitempty"`
+
+	// LastCheckTime is the time of the last successful health check.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterObservation) DeepCopyInto(out *ClusterObservation) {
+	*out = *in
+	if in.ControllerID != nil {
+		in, out := &in.ControllerID, &out.ControllerID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new ClusterObservation.
+func (in *ClusterObservation) DeepCopy() *ClusterObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterObservation)
+	in.DeepCopyInto(out)
+	return out
+}
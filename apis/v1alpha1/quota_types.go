@@ -0,0 +1,157 @@
+package v1alpha1
+
+// QuotaParameters are the configurable fields of a Quota.
+// +kubebuilder:validation:XValidation:rule="has(self.user) || has(self.clientId)",message="at least one of user and clientId is required"
+// +kubebuilder:validation:XValidation:rule="has(self.user) == has(oldSelf.user) && has(self.clientId) == has(oldSelf.clientId)",message="the quota entity is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.producerByteRate) || has(self.consumerByteRate) || has(self.requestPercentage) || has(self.controllerMutationRate)",message="at least one quota is required"
+type QuotaParameters struct {
+	// User is the user principal the quota applies to. When set together
+	// with ClientID, the quota applies to that client of that user.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="user is immutable"
+	User *QuotaEntityName `json:"user,omitempty"`
+	// ClientID is the client ID the quota applies to.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="clientId is immutable"
+	ClientID *QuotaEntityName `json:"clientId,omitempty"`
+
+	QuotaValues `json:",inline"`
+}
+
+// QuotaEntityName names a quota entity, or selects the default entity that
+// applies to every user or client ID without a more specific quota.
+// +kubebuilder:validation:XValidation:rule="(has(self.name) && size(self.name) > 0) != (has(self.default) && self.default)",message="exactly one of name and default is required"
+type QuotaEntityName struct {
+	// Name is the name of the user or client ID.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Default selects the default user or client ID entity.
+	// +optional
+	Default bool `json:"default,omitempty"`
+}
+
+// QuotaValues are the client quotas of an entity. Quotas that are not set
+// are removed from the entity.
+type QuotaValues struct {
+	// ProducerByteRate is the produce throughput limit in bytes per second
+	// per broker.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	ProducerByteRate *int64 `json:"producerByteRate,omitempty"`
+	// ConsumerByteRate is the fetch throughput limit in bytes per second per
+	// broker.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	ConsumerByteRate *int64 `json:"consumerByteRate,omitempty"`
+	// RequestPercentage is the percentage of broker request handler and
+	// network thread time the entity may use, for example "200" or "12.5".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	RequestPercentage *string `json:"requestPercentage,omitempty"`
+	// ControllerMutationRate is the rate at which partitions may be created
+	// or deleted per second, for example "10" or "2.5".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	ControllerMutationRate *string `json:"controllerMutationRate,omitempty"`
+}
+
+// QuotaObservation are the observable fields of a Quota.
+type QuotaObservation struct {
+	// Entity is the Kafka quota entity, for example
+	// {user=alice, client-id=<default>}.
+	Entity string `json:"entity,omitempty"`
+
+	QuotaValues `json:",inline"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaParameters) DeepCopyInto(out *QuotaParameters) {
+	*out = *in
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(QuotaEntityName)
+		**out = **in
+	}
+	if in.ClientID != nil {
+		in, out := &in.ClientID, &out.ClientID
+		*out = new(QuotaEntityName)
+		**out = **in
+	}
+	in.QuotaValues.DeepCopyInto(&out.QuotaValues)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new QuotaParameters.
+func (in *QuotaParameters) DeepCopy() *QuotaParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaEntityName) DeepCopyInto(out *QuotaEntityName) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new QuotaEntityName.
+func (in *QuotaEntityName) DeepCopy() *QuotaEntityName {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaEntityName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaValues) DeepCopyInto(out *QuotaValues) {
+	*out = *in
+	if in.ProducerByteRate != nil {
+		in, out := &in.ProducerByteRate, &out.ProducerByteRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConsumerByteRate != nil {
+		in, out := &in.ConsumerByteRate, &out.ConsumerByteRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RequestPercentage != nil {
+		in, out := &in.RequestPercentage, &out.RequestPercentage
+		*out = new(string)
+		**out = **in
+	}
+	if in.ControllerMutationRate != nil {
+		in, out := &in.ControllerMutationRate, &out.ControllerMutationRate
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new QuotaValues.
+func (in *QuotaValues) DeepCopy() *QuotaValues {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaObservation) DeepCopyInto(out *QuotaObservation) {
+	*out = *in
+	in.QuotaValues.DeepCopyInto(&out.QuotaValues)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new QuotaObservation.
+func (in *QuotaObservation) DeepCopy() *QuotaObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaObservation)
+	in.DeepCopyInto(out)
+	return out
+}
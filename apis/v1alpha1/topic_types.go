@@ -1,5 +1,55 @@
 package v1alpha1
 
+import (
+	"strings"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypeChangesApplied is the condition type that reports whether the last
+// update of a Topic applied every change it planned.
+const TypeChangesApplied xpv2.ConditionType = "ChangesApplied"
+
+// Reasons a Topic's changes are or are not applied.
+const (
+	ReasonChangesApplied xpv2.ConditionReason = "ChangesApplied"
+	ReasonChangesFailed  xpv2.ConditionReason = "ChangesFailed"
+)
+
+// ChangesApplied returns a condition that indicates the last update of the
+// Topic applied every planned change to the listed parts.
+func ChangesApplied(applied []string) xpv2.Condition {
+	c := xpv2.Condition{
+		Type:               TypeChangesApplied,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChangesApplied,
+	}
+	if len(applied) > 0 {
+		c.Message = "applied: " + strings.Join(applied, ", ")
+	}
+	return c
+}
+
+// ChangesFailed returns a condition that indicates the last update of the
+// Topic failed to change the listed failed parts, while the applied parts
+// were changed.
+func ChangesFailed(applied, failed []string, err error) xpv2.Condition {
+	msg := "failed: " + strings.Join(failed, ", ")
+	if len(applied) > 0 {
+		msg = "applied: " + strings.Join(applied, ", ") + "; " + msg
+	}
+	return xpv2.Condition{
+		Type:               TypeChangesApplied,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChangesFailed,
+		Message:            msg + ": " + err.Error(),
+	}
+}
+
 // TopicObservation are the observable fields of a Topic.
 type TopicObservation struct {
 	ID string `json:"id,omitempty"`
@@ -10,19 +60,67 @@ type TopicObservation struct {
 	// Config is the observed topic configuration from Kafka.
 	// +optional
 	Config map[string]*string `json:"config,omitempty"`
+	// ReassignmentInProgress is true while a partition reassignment started
+	// to change the replication factor has not completed yet.
+	// +optional
+	ReassignmentInProgress bool `json:"reassignmentInProgress,omitempty"`
+	// UnderReplicatedPartitions is the number of partitions with fewer
+	// in-sync replicas than replicas.
+	UnderReplicatedPartitions int `json:"underReplicatedPartitions,omitempty"`
+	// OfflinePartitions is the number of partitions without a leader.
+	OfflinePartitions int `json:"offlinePartitions,omitempty"`
+	// PartitionLayout is the observed leader and replicas of every partition.
+	// +optional
+	PartitionLayout []TopicPartition `json:"partitionLayout,omitempty"`
+}
+
+// TopicPartition is the observed layout of a single topic partition.
+type TopicPartition struct {
+	// Partition is the partition number.
+	Partition int32 `json:"partition"`
+	// Leader is the broker ID of the partition leader, or -1 if the partition
+	// has no leader.
+	Leader int32 `json:"leader"`
+	// Replicas are the broker IDs holding replicas of the partition, with
+	// the preferred leader first.
+	// +optional
+	Replicas []int32 `json:"replicas,omitempty"`
+	// ISR are the broker IDs of the in-sync replicas.
+	// +optional
+	ISR []int32 `json:"isr,omitempty"`
+	// OfflineReplicas are the broker IDs of replicas that are offline.
+	// +optional
+	OfflineReplicas []int32 `json:"offlineReplicas,omitempty"`
+	// UnderReplicated is true if the partition has fewer in-sync replicas
+	// than replicas.
+	// +optional
+	UnderReplicated bool `json:"underReplicated,omitempty"`
 }
 
 // TopicParameters are the configurable fields of a Topic.
 type TopicParameters struct {
 	// ReplicationFactor defines the number of replicas the topic should have.
+	// If unset, it is late-initialized from the existing topic, and new topics
+	// are created with the broker default.
 	// +kubebuilder:validation:Minimum:=1
-	ReplicationFactor int `json:"replicationFactor"`
+	// +optional
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
 	// Partitions defines the number of partitions the topic should have.
+	// If unset, it is late-initialized from the existing topic, and new topics
+	// are created with the broker default.
 	// +kubebuilder:validation:Minimum:=1
-	Partitions int `json:"partitions"`
-	// Config is an optional map of string key/ value pairs.
+	// +optional
+	Partitions int `json:"partitions,omitempty"`
+	// Config is an optional map of string key/ value pairs. If unset, it is
+	// late-initialized with the configs that are set on the existing topic
+	// rather than inherited from the broker.
 	// +optional
 	Config map[string]*string `json:"config,omitempty"`
+	// AllowUnknownConfig accepts config keys that are neither known to the
+	// provider nor reported by the broker, such as vendor-specific configs.
+	// Their values are only validated by the broker.
+	// +optional
+	AllowUnknownConfig bool `json:"allowUnknownConfig,omitempty"`
 }
 
 // DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -44,6 +142,13 @@ func (in *TopicObservation) DeepCopyInto(out *TopicObservation) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PartitionLayout != nil {
+		in, out := &in.PartitionLayout, &out.PartitionLayout
+		*out = make([]TopicPartition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is a deepcopy function, copying the receiver, creating a new TopicObservation.
@@ -56,6 +161,36 @@ func (in *TopicObservation) DeepCopy() *TopicObservation {
 	return out
 }
 
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicPartition) DeepCopyInto(out *TopicPartition) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.ISR != nil {
+		in, out := &in.ISR, &out.ISR
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.OfflineReplicas != nil {
+		in, out := &in.OfflineReplicas, &out.OfflineReplicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new TopicPartition.
+func (in *TopicPartition) DeepCopy() *TopicPartition {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicPartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicParameters) DeepCopyInto(out *TopicParameters) {
 	*out = *in
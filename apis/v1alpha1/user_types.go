@@ -0,0 +1,68 @@
+package v1alpha1
+
+// UserParameters are the configurable fields of a User that do not depend on
+// the scope of the managed resource.
+type UserParameters struct {
+	// Mechanism is the SCRAM mechanism the user's credential is stored with.
+	// Valid values are SCRAM-SHA-256, SCRAM-SHA-512.
+	// +kubebuilder:validation:Enum=SCRAM-SHA-256;SCRAM-SHA-512
+	// +kubebuilder:default=SCRAM-SHA-512
+	// +optional
+	Mechanism string `json:"mechanism,omitempty"`
+	// Iterations is the number of SCRAM iterations used to salt the password.
+	// +kubebuilder:validation:Minimum:=4096
+	// +kubebuilder:validation:Maximum:=16384
+	// +kubebuilder:default=4096
+	// +optional
+	Iterations int32 `json:"iterations,omitempty"`
+}
+
+// UserCredential is a SCRAM credential that Kafka reports for a user.
+type UserCredential struct {
+	// Mechanism is the SCRAM mechanism of the credential.
+	Mechanism string `json:"mechanism"`
+	// Iterations is the number of SCRAM iterations of the credential.
+	Iterations int32 `json:"iterations"`
+}
+
+// UserObservation are the observable fields of a User.
+type UserObservation struct {
+	// Credentials are the SCRAM credentials Kafka reports for the user.
+	// +optional
+	Credentials []UserCredential `json:"credentials,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserParameters) DeepCopyInto(out *UserParameters) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new UserParameters.
+func (in *UserParameters) DeepCopy() *UserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(UserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserObservation) DeepCopyInto(out *UserObservation) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]UserCredential, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new UserObservation.
+func (in *UserObservation) DeepCopy() *UserObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(UserObservation)
+	in.DeepCopyInto(out)
+	return out
+}
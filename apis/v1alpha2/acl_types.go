@@ -0,0 +1,149 @@
+package v1alpha2
+
+// AccessControlListObservation are the observable fields of an AccessControlList.
+type AccessControlListObservation struct {
+	ID string `json:"id,omitempty"`
+	// The following fields are only set if the AccessControlList has a
+	// single binding. They are the binding held by Kafka.
+	ResourceName              string `json:"resourceName,omitempty"`
+	ResourceType              string `json:"resourceType,omitempty"`
+	ResourcePrincipal         string `json:"resourcePrincipal,omitempty"`
+	ResourceHost              string `json:"resourceHost,omitempty"`
+	ResourceOperation         string `json:"resourceOperation,omitempty"`
+	ResourcePermissionType    string `json:"resourcePermissionType,omitempty"`
+	ResourcePatternTypeFilter string `json:"resourcePatternTypeFilter,omitempty"`
+	// Bindings is the number of ACL bindings the AccessControlList expands to.
+	Bindings int `json:"bindings,omitempty"`
+	// MissingBindings are the ACL bindings of the AccessControlList that
+	// Kafka does not hold.
+	MissingBindings []string `json:"missingBindings,omitempty"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListObservation) DeepCopyInto(out *AccessControlListObservation) {
+	*out = *in
+	if in.MissingBindings != nil {
+		out.MissingBindings = make([]string, len(in.MissingBindings))
+		copy(out.MissingBindings, in.MissingBindings)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new AccessControlListObservation.
+func (in *AccessControlListObservation) DeepCopy() *AccessControlListObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// AccessControlListParameters are the configurable fields of a
+// AccessControlList. The AccessControlList binds the principal to every
+// combination of its hosts, operations and resources. The singular fields of
+// v1alpha1 are kept, and are combined with the lists.
+// +kubebuilder:validation:XValidation:rule="has(self.resourceType) == has(self.resourceName)",message="resourceName and resourceType must be set together"
+// +kubebuilder:validation:XValidation:rule="has(self.resourceType) || (has(self.resources) && size(self.resources) > 0)",message="at least one of resourceType and resources is required"
+// +kubebuilder:validation:XValidation:rule="has(self.resourceOperation) || (has(self.resourceOperations) && size(self.resourceOperations) > 0)",message="at least one of resourceOperation and resourceOperations is required"
+// +kubebuilder:validation:XValidation:rule="!((has(self.resourceType) && self.resourceType == 'Topic') || (has(self.resources) && self.resources.exists(r, r.type == 'Topic'))) || ((!has(self.resourceOperation) || self.resourceOperation in ['Read', 'Write', 'Create', 'Delete', 'Alter', 'Describe', 'DescribeConfigs', 'AlterConfigs', 'All', 'Any']) && (!has(self.resourceOperations) || self.resourceOperations.all(o, o in ['Read', 'Write', 'Create', 'Delete', 'Alter', 'Describe', 'DescribeConfigs', 'AlterConfigs', 'All', 'Any'])))",message="Topic resources only accept the Read, Write, Create, Delete, Alter, Describe, DescribeConfigs, AlterConfigs and All operations"
+// +kubebuilder:validation:XValidation:rule="!((has(self.resourceType) && self.resourceType == 'Group') || (has(self.resources) && self.resources.exists(r, r.type == 'Group'))) || ((!has(self.resourceOperation) || self.resourceOperation in ['Read', 'Describe', 'Delete', 'All', 'Any']) && (!has(self.resourceOperations) || self.resourceOperations.all(o, o in ['Read', 'Describe', 'Delete', 'All', 'Any'])))",message="Group resources only accept the Read, Describe, Delete and All operations"
+// +kubebuilder:validation:XValidation:rule="!((has(self.resourceType) && self.resourceType == 'Cluster') || (has(self.resources) && self.resources.exists(r, r.type == 'Cluster'))) || ((!has(self.resourceOperation) || self.resourceOperation in ['Create', 'Alter', 'Describe', 'ClusterAction', 'DescribeConfigs', 'AlterConfigs', 'IdempotentWrite', 'All', 'Any']) && (!has(self.resourceOperations) || self.resourceOperations.all(o, o in ['Create', 'Alter', 'Describe', 'ClusterAction', 'DescribeConfigs', 'AlterConfigs', 'IdempotentWrite', 'All', 'Any'])))",message="Cluster resources only accept the Create, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite and All operations"
+// +kubebuilder:validation:XValidation:rule="!((has(self.resourceType) && self.resourceType == 'TransactionalID') || (has(self.resources) && self.resources.exists(r, r.type == 'TransactionalID'))) || ((!has(self.resourceOperation) || self.resourceOperation in ['Write', 'Describe', 'All', 'Any']) && (!has(self.resourceOperations) || self.resourceOperations.all(o, o in ['Write', 'Describe', 'All', 'Any'])))",message="TransactionalID resources only accept the Write, Describe and All operations"
+// +kubebuilder:validation:XValidation:rule="!((has(self.resourceType) && self.resourceType == 'DelegationToken') || (has(self.resources) && self.resources.exists(r, r.type == 'DelegationToken'))) || ((!has(self.resourceOperation) || self.resourceOperation in ['Describe', 'All', 'Any']) && (!has(self.resourceOperations) || self.resourceOperations.all(o, o in ['Describe', 'All', 'Any'])))",message="DelegationToken resources only accept the Describe and All operations"
+// +kubebuilder:validation:XValidation:rule="!((has(self.resourceType) && self.resourceType == 'User') || (has(self.resources) && self.resources.exists(r, r.type == 'User'))) || ((!has(self.resourceOperation) || self.resourceOperation in ['CreateTokens', 'DescribeTokens', 'All', 'Any']) && (!has(self.resourceOperations) || self.resourceOperations.all(o, o in ['CreateTokens', 'DescribeTokens', 'All', 'Any'])))",message="User resources only accept the CreateTokens, DescribeTokens and All operations"
+type AccessControlListParameters struct {
+	// ResourceName is the name of the resource.
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
+	// ResourceType is the type of resource.
+	// Valid values are Unknown, Any, Topic, Group, Cluster, TransactionalID, DelegationToken, User
+	// +optional
+	// +kubebuilder:validation:Enum=Unknown;Any;Topic;Group;Cluster;TransactionalID;DelegationToken;User
+	ResourceType string `json:"resourceType,omitempty"`
+	// Resources are further resources the principal is bound to.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=100
+	Resources []AccessControlListResource `json:"resources,omitempty"`
+	// ResourcePrincipal is the Principal that is being allowed or denied.
+	ResourcePrincipal string `json:"resourcePrincipal"`
+	// ResourceHost is the Host from which principal listed in ResourcePrinciple will have access.
+	// All hosts (*) are bound if neither ResourceHost nor ResourceHosts is set.
+	// +optional
+	ResourceHost string `json:"resourceHost,omitempty"`
+	// ResourceHosts are further hosts from which the principal will have access.
+	// +optional
+	// +listType=atomic
+	ResourceHosts []string `json:"resourceHosts,omitempty"`
+	// ResourceOperation is the Operation that is being allowed or denied.
+	// Valid values are Unknown, Any, All, Read, Write, Create, Delete, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite, CreateTokens, DescribeTokens.
+	// +optional
+	// +kubebuilder:validation:Enum=Unknown;Any;All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite;CreateTokens;DescribeTokens
+	ResourceOperation string `json:"resourceOperation,omitempty"`
+	// ResourceOperations are further operations that are being allowed or denied.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:Enum=Any;All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite;CreateTokens;DescribeTokens
+	ResourceOperations []string `json:"resourceOperations,omitempty"`
+	// ResourcePermissionType is the Type of permission.
+	// Valid values are Unknown, Any, Allow, Deny.
+	// +kubebuilder:validation:Enum=Unknown;Any;Allow;Deny
+	ResourcePermissionType string `json:"resourcePermissionType"`
+	// ResourcePatternTypeFilter is the pattern filter of all resources.
+	// Valid values are Prefixed, Any, Match, Literal.
+	// +kubebuilder:validation:Enum=Prefixed;Any;Match;Literal
+	ResourcePatternTypeFilter string `json:"resourcePatternTypeFilter"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListParameters) DeepCopyInto(out *AccessControlListParameters) {
+	*out = *in
+	if in.Resources != nil {
+		out.Resources = make([]AccessControlListResource, len(in.Resources))
+		copy(out.Resources, in.Resources)
+	}
+	if in.ResourceHosts != nil {
+		out.ResourceHosts = make([]string, len(in.ResourceHosts))
+		copy(out.ResourceHosts, in.ResourceHosts)
+	}
+	if in.ResourceOperations != nil {
+		out.ResourceOperations = make([]string, len(in.ResourceOperations))
+		copy(out.ResourceOperations, in.ResourceOperations)
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new AccessControlListParameters.
+func (in *AccessControlListParameters) DeepCopy() *AccessControlListParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// AccessControlListResource is a resource of an AccessControlList.
+type AccessControlListResource struct {
+	// Type is the type of resource.
+	// Valid values are Any, Topic, Group, Cluster, TransactionalID, DelegationToken, User
+	// +kubebuilder:validation:Enum=Any;Topic;Group;Cluster;TransactionalID;DelegationToken;User
+	Type string `json:"type"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListResource) DeepCopyInto(out *AccessControlListResource) {
+	*out = *in
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new AccessControlListResource.
+func (in *AccessControlListResource) DeepCopy() *AccessControlListResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListResource)
+	in.DeepCopyInto(out)
+	return out
+}
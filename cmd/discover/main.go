@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// discover generates Topic and AccessControlList manifests for the topics
+// and ACLs of an existing Kafka cluster, so that the cluster can be imported
+// into Crossplane.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/twmb/franz-go/pkg/kadm"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
+	"github.com/crossplane-contrib/provider-kafka/internal/discovery"
+)
+
+// scopeNamespaced generates namespaced managed resources.
+const scopeNamespaced = "namespaced"
+
+var cli struct {
+	Credentials kong.FileContentFlag `help:"File with the Kafka credentials JSON of a ProviderConfig. Certificates, keytabs and OAuth client secrets must be given as files or inline rather than Secret references." short:"c" required:""`
+	Output      string               `help:"File to write the manifests to instead of stdout." short:"o" type:"path"`
+	Timeout     time.Duration        `help:"Time the discovery may take." default:"2m"`
+
+	Scope              string `help:"Scope of the generated managed resources, namespaced or cluster." enum:"namespaced,cluster" default:"namespaced"`
+	Namespace          string `help:"Namespace of namespaced managed resources." short:"n" default:"default"`
+	ProviderConfig     string `help:"Name of the ProviderConfig the managed resources reference." default:"default"`
+	ProviderConfigKind string `help:"Kind of the ProviderConfig namespaced managed resources reference, ClusterProviderConfig or ProviderConfig." enum:"ClusterProviderConfig,ProviderConfig" default:"ClusterProviderConfig"`
+	ObserveOnly        bool   `help:"Generate managed resources with the Observe management policy, so that the cluster is never changed."`
+
+	SkipTopics bool `help:"Do not generate Topics."`
+	SkipACLs   bool `help:"Do not generate AccessControlLists, for clusters without an authorizer." name:"skip-acls"`
+}
+
+func main() {
+	kctx := kong.Parse(&cli, kong.Name("discover"), kong.Description("Generate Crossplane manifests for the topics and ACLs of a Kafka cluster"))
+	kctx.FatalIfErrorf(run())
+}
+
+func run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), cli.Timeout)
+	defer cancel()
+
+	cl, err := kafka.NewAdminClient(ctx, cli.Credentials, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create Kafka client: %w", err)
+	}
+	defer cl.Close()
+
+	var (
+		topics  []*topic.Topic
+		acls    []*acl.AccessControlList
+		skipped []kadm.DescribedACL
+	)
+	if !cli.SkipTopics {
+		if topics, err = discovery.Topics(ctx, cl); err != nil {
+			return err
+		}
+	}
+	if !cli.SkipACLs {
+		if acls, skipped, err = discovery.ACLs(ctx, cl); err != nil {
+			return err
+		}
+	}
+	for _, d := range skipped {
+		fmt.Fprintf(os.Stderr, "skipping ACL that cannot be managed by an AccessControlList: %s %s on %s %q (%s) for %s from host %s\n",
+			d.Permission, d.Operation, d.Type, d.Name, d.Pattern, d.Principal, d.Host)
+	}
+
+	o := discovery.Options{
+		ProviderConfigName: cli.ProviderConfig,
+		ObserveOnly:        cli.ObserveOnly,
+	}
+	if cli.Scope == scopeNamespaced {
+		o.Namespace = cli.Namespace
+		o.ProviderConfigKind = cli.ProviderConfigKind
+	}
+	objs, err := discovery.Resources(topics, acls, o)
+	if err != nil {
+		return err
+	}
+
+	if cli.Output == "" {
+		return discovery.Write(os.Stdout, objs)
+	}
+	f, err := os.Create(cli.Output)
+	if err != nil {
+		return fmt.Errorf("cannot create output file: %w", err)
+	}
+	if err := discovery.Write(f, objs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -68,6 +68,16 @@ var cli struct {
 	ChangelogsSocketPath     string `help:"Path for changelogs socket (if enabled)" default:"/var/run/changelogs/changelogs.sock" env:"CHANGELOGS_SOCKET_PATH"`
 
 	BrokerConnectionTimeout time.Duration `help:"Timeout for establishing connection to Kafka brokers" default:"30s"`
+	BrokerRequestTimeout    time.Duration `help:"Time a Kafka broker has to answer a request, on top of the timeout of the request itself." default:"10s"`
+	BrokerRequestRetries    int           `help:"Number of times a failed Kafka request is retried." default:"20"`
+	BrokerRetryBackoff      time.Duration `help:"Fixed time to wait between retries of Kafka requests. Zero uses an exponential backoff." default:"0s"`
+	MetadataMaxAge          time.Duration `help:"How often the Kafka cluster metadata is refreshed." default:"5m"`
+	ConnectionIdleTimeout   time.Duration `help:"How long an unused Kafka broker connection is kept open." default:"20s"`
+	ClientID                string        `help:"Client ID sent to the Kafka brokers." default:"kgo"`
+	Rack                    string        `help:"Rack of the Kafka client."`
+
+	ClientCacheSize        int           `help:"The maximum number of Kafka clients, one per ProviderConfig, kept open at a time." default:"32"`
+	ClientCacheIdleTimeout time.Duration `help:"How long a Kafka client may go unused before it is closed." default:"10m"`
 }
 
 func main() {
@@ -88,6 +98,18 @@ func main() {
 		ctrl.SetLogger(zap.New(zap.WriteTo(io.Discard)))
 	}
 	ctx.Bind(log)
+	ctx.FatalIfErrorf(validateClientFlags(), "Invalid Kafka client flags")
+
+	kafka.ClientDefaults = kafka.ClientTuning{
+		DialTimeout:     cli.BrokerConnectionTimeout,
+		RequestTimeout:  cli.BrokerRequestTimeout,
+		RequestRetries:  &cli.BrokerRequestRetries,
+		RetryBackoff:    cli.BrokerRetryBackoff,
+		MetadataMaxAge:  cli.MetadataMaxAge,
+		ConnIdleTimeout: cli.ConnectionIdleTimeout,
+		ClientID:        cli.ClientID,
+		Rack:            cli.Rack,
+	}
 
 	cfg, err := ctrl.GetConfig()
 	ctx.FatalIfErrorf(err, "Cannot get API server rest config")
@@ -113,6 +135,13 @@ func main() {
 	metrics.Registry.MustRegister(metricRecorder)
 	metrics.Registry.MustRegister(stateMetrics)
 
+	clientCacheMetrics := kafka.NewClientCacheMetrics()
+	metrics.Registry.MustRegister(clientCacheMetrics)
+	clients := kafka.NewClientCache(
+		kafka.WithMaxClients(cli.ClientCacheSize),
+		kafka.WithIdleTimeout(cli.ClientCacheIdleTimeout),
+		kafka.WithCacheMetrics(clientCacheMetrics))
+
 	ctx.FatalIfErrorf(err, "Cannot get provider")
 	o := controller.Options{
 		Logger:                  log,
@@ -153,13 +182,13 @@ func main() {
 
 	if canSafeStart {
 		o.Gate = new(gate.Gate[schema.GroupVersionKind])
-		ctx.FatalIfErrorf(clustercontroller.SetupGated(mgr, o), "Cannot setup Cluster Kafka controllers")
-		ctx.FatalIfErrorf(namespacedcontroller.SetupGated(mgr, o), "Cannot setup Namespaced Kafka controllers")
+		ctx.FatalIfErrorf(clustercontroller.SetupGated(mgr, o, clients), "Cannot setup Cluster Kafka controllers")
+		ctx.FatalIfErrorf(namespacedcontroller.SetupGated(mgr, o, clients), "Cannot setup Namespaced Kafka controllers")
 		ctx.FatalIfErrorf(customresourcesgate.Setup(mgr, o), "Cannot setup CRD gate controller")
 	} else {
 		log.Info("Provider has missing RBAC permissions for watching CRDs, controller SafeStart capability will be disabled")
-		ctx.FatalIfErrorf(clustercontroller.Setup(mgr, o), "Cannot setup Cluster Kafka controllers")
-		ctx.FatalIfErrorf(namespacedcontroller.Setup(mgr, o), "Cannot setup Namespaced Kafka controllers")
+		ctx.FatalIfErrorf(clustercontroller.Setup(mgr, o, clients), "Cannot setup Cluster Kafka controllers")
+		ctx.FatalIfErrorf(namespacedcontroller.Setup(mgr, o, clients), "Cannot setup Namespaced Kafka controllers")
 	}
 
 	ctx.FatalIfErrorf(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
@@ -189,3 +218,35 @@ func canWatchCRD(ctx context.Context, mgr manager.Manager) (bool, error) {
 	}
 	return true, nil
 }
+
+// validateClientFlags returns an error if a flag that tunes the Kafka clients
+// is out of range, as the clients would otherwise fail or misbehave on every
+// reconcile.
+func validateClientFlags() error {
+	for _, f := range []struct {
+		name      string
+		value     time.Duration
+		allowZero bool
+	}{
+		{name: "broker-connection-timeout", value: cli.BrokerConnectionTimeout},
+		{name: "broker-request-timeout", value: cli.BrokerRequestTimeout},
+		{name: "broker-retry-backoff", value: cli.BrokerRetryBackoff, allowZero: true},
+		{name: "metadata-max-age", value: cli.MetadataMaxAge},
+		{name: "connection-idle-timeout", value: cli.ConnectionIdleTimeout},
+		{name: "client-cache-idle-timeout", value: cli.ClientCacheIdleTimeout, allowZero: true},
+	} {
+		switch {
+		case f.allowZero && f.value < 0:
+			return fmt.Errorf("--%s %s: must be >= 0", f.name, f.value)
+		case !f.allowZero && f.value <= 0:
+			return fmt.Errorf("--%s %s: must be a positive duration", f.name, f.value)
+		}
+	}
+	if cli.BrokerRequestRetries < 0 {
+		return fmt.Errorf("--broker-request-retries %d: must be >= 0", cli.BrokerRequestRetries)
+	}
+	if cli.ClientCacheSize < 1 {
+		return fmt.Errorf("--client-cache-size %d: must be >= 1", cli.ClientCacheSize)
+	}
+	return nil
+}
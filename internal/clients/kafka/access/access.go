@@ -0,0 +1,134 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+)
+
+// adminClient is the subset of kafka.Client methods used by this package.
+type adminClient interface {
+	CreateACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.CreateACLsResults, error)
+	DeleteACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DeleteACLsResults, error)
+	DescribeACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DescribeACLsResults, error)
+	kmsg.Requestor
+}
+
+// resource is a Kafka ACL resource an intent grants operations on.
+type resource struct {
+	Type        string
+	Name        string
+	PatternType string
+}
+
+// grant is an operation on a resource.
+type grant struct {
+	resource
+	Operation string
+}
+
+// grants returns the operations on resources that the intent needs, as
+// granted by kafka-acls.sh for its producer and consumer options.
+func grants(in v1alpha1.KafkaAccessIntent) []grant {
+	topic := resource{Type: kafka.ACLResourceTypeTopic, Name: in.Topic, PatternType: patternType(in.TopicPatternType)}
+	cluster := resource{Type: kafka.ACLResourceTypeCluster, Name: kafka.ACLClusterName, PatternType: kafka.ACLPatternTypeLiteral}
+
+	switch in.Role {
+	case v1alpha1.KafkaAccessRoleProducer:
+		return []grant{
+			{resource: topic, Operation: kafka.ACLOperationWrite},
+			{resource: topic, Operation: kafka.ACLOperationDescribe},
+			{resource: cluster, Operation: kafka.ACLOperationIdempotentWrite},
+		}
+	case v1alpha1.KafkaAccessRoleConsumer:
+		group := resource{Type: kafka.ACLResourceTypeGroup, Name: in.Group, PatternType: patternType(in.GroupPatternType)}
+		return []grant{
+			{resource: topic, Operation: kafka.ACLOperationRead},
+			{resource: topic, Operation: kafka.ACLOperationDescribe},
+			{resource: group, Operation: kafka.ACLOperationRead},
+			{resource: group, Operation: kafka.ACLOperationDescribe},
+		}
+	case v1alpha1.KafkaAccessRoleTransactionalProducer:
+		txn := resource{Type: kafka.ACLResourceTypeTransactionalID, Name: in.TransactionalID, PatternType: patternType(in.TransactionalIDPatternType)}
+		return []grant{
+			{resource: topic, Operation: kafka.ACLOperationWrite},
+			{resource: topic, Operation: kafka.ACLOperationDescribe},
+			{resource: cluster, Operation: kafka.ACLOperationIdempotentWrite},
+			{resource: txn, Operation: kafka.ACLOperationWrite},
+			{resource: txn, Operation: kafka.ACLOperationDescribe},
+		}
+	}
+	return nil
+}
+
+// patternType returns the pattern type, which defaults to Literal.
+func patternType(p string) string {
+	if p == "" {
+		return kafka.ACLPatternTypeLiteral
+	}
+	return p
+}
+
+// Expand returns the ACL bindings that grant the principal of the
+// KafkaAccessParameters the access of their intents from their hosts, sorted
+// as by acl.Sort and without duplicates. All hosts are bound if the
+// parameters have none.
+func Expand(params *v1alpha1.KafkaAccessParameters) []*acl.AccessControlList {
+	hosts := params.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{"*"}
+	}
+
+	var gs []grant
+	for _, in := range params.Intents {
+		for _, g := range grants(in) {
+			if !slices.Contains(gs, g) {
+				gs = append(gs, g)
+			}
+		}
+	}
+
+	bindings := make([]*acl.AccessControlList, 0, len(hosts)*len(gs))
+	for _, h := range hosts {
+		for _, g := range gs {
+			bindings = append(bindings, &acl.AccessControlList{
+				ResourceName:              g.Name,
+				ResourceType:              g.Type,
+				ResourcePrincipal:         params.Principal,
+				ResourceHost:              h,
+				ResourceOperation:         g.Operation,
+				ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+				ResourcePatternTypeFilter: g.PatternType,
+			})
+		}
+	}
+	acl.Sort(bindings)
+	return bindings
+}
+
+// Create creates the ACL bindings with a request per resource, as the
+// operations granted on each resource differ.
+func Create(ctx context.Context, cl adminClient, bindings []*acl.AccessControlList) error {
+	var order []resource
+	byResource := map[resource][]*acl.AccessControlList{}
+	for _, b := range bindings {
+		r := resource{Type: b.ResourceType, Name: b.ResourceName, PatternType: b.ResourcePatternTypeFilter}
+		if _, ok := byResource[r]; !ok {
+			order = append(order, r)
+		}
+		byResource[r] = append(byResource[r], b)
+	}
+	for _, r := range order {
+		if err := acl.Create(ctx, cl, byResource[r]...); err != nil {
+			return fmt.Errorf("%s %q: %w", r.Type, r.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+)
+
+// fakeAdmin is an in-process implementation of adminClient for unit tests.
+type fakeAdmin struct {
+	// created are the ACLBuilders passed to CreateACLs.
+	created []*kadm.ACLBuilder
+}
+
+func (f *fakeAdmin) CreateACLs(_ context.Context, b *kadm.ACLBuilder) (kadm.CreateACLsResults, error) {
+	f.created = append(f.created, b)
+	return kadm.CreateACLsResults{{Principal: kafka.TestACLPrincipal, Permission: kmsg.ACLPermissionTypeAllow}}, nil
+}
+
+func (f *fakeAdmin) DeleteACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.DeleteACLsResults, error) {
+	return nil, nil
+}
+
+func (f *fakeAdmin) DescribeACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.DescribeACLsResults, error) {
+	return nil, nil
+}
+
+func (f *fakeAdmin) Request(_ context.Context, _ kmsg.Request) (kmsg.Response, error) {
+	return nil, errors.New("unexpected raw request")
+}
+
+func binding(resourceType, name, patternType, host, operation string) *acl.AccessControlList {
+	return &acl.AccessControlList{
+		ResourceName:              name,
+		ResourceType:              resourceType,
+		ResourcePrincipal:         kafka.TestACLPrincipal,
+		ResourceHost:              host,
+		ResourceOperation:         operation,
+		ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+		ResourcePatternTypeFilter: patternType,
+	}
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	const (
+		literal  = kafka.ACLPatternTypeLiteral
+		prefixed = "Prefixed"
+		cluster  = kafka.ACLResourceTypeCluster
+		group    = kafka.ACLResourceTypeGroup
+		topic    = kafka.ACLResourceTypeTopic
+		txn      = kafka.ACLResourceTypeTransactionalID
+	)
+
+	cases := map[string]struct {
+		params v1alpha1.KafkaAccessParameters
+		want   []*acl.AccessControlList
+	}{
+		"Producer": {
+			params: v1alpha1.KafkaAccessParameters{
+				Principal: kafka.TestACLPrincipal,
+				Intents:   []v1alpha1.KafkaAccessIntent{{Role: v1alpha1.KafkaAccessRoleProducer, Topic: "orders"}},
+			},
+			want: []*acl.AccessControlList{
+				binding(cluster, kafka.ACLClusterName, literal, "*", kafka.ACLOperationIdempotentWrite),
+				binding(topic, "orders", literal, "*", kafka.ACLOperationDescribe),
+				binding(topic, "orders", literal, "*", kafka.ACLOperationWrite),
+			},
+		},
+		"ConsumerWithGroupPrefix": {
+			params: v1alpha1.KafkaAccessParameters{
+				Principal: kafka.TestACLPrincipal,
+				Intents: []v1alpha1.KafkaAccessIntent{{
+					Role:             v1alpha1.KafkaAccessRoleConsumer,
+					Topic:            "orders",
+					Group:            "billing-",
+					GroupPatternType: prefixed,
+				}},
+			},
+			want: []*acl.AccessControlList{
+				binding(group, "billing-", prefixed, "*", kafka.ACLOperationDescribe),
+				binding(group, "billing-", prefixed, "*", kafka.ACLOperationRead),
+				binding(topic, "orders", literal, "*", kafka.ACLOperationDescribe),
+				binding(topic, "orders", literal, "*", kafka.ACLOperationRead),
+			},
+		},
+		"TransactionalProducer": {
+			params: v1alpha1.KafkaAccessParameters{
+				Principal: kafka.TestACLPrincipal,
+				Intents: []v1alpha1.KafkaAccessIntent{{
+					Role:            v1alpha1.KafkaAccessRoleTransactionalProducer,
+					Topic:           "orders",
+					TransactionalID: "orders-tx",
+				}},
+			},
+			want: []*acl.AccessControlList{
+				binding(cluster, kafka.ACLClusterName, literal, "*", kafka.ACLOperationIdempotentWrite),
+				binding(topic, "orders", literal, "*", kafka.ACLOperationDescribe),
+				binding(topic, "orders", literal, "*", kafka.ACLOperationWrite),
+				binding(txn, "orders-tx", literal, "*", kafka.ACLOperationDescribe),
+				binding(txn, "orders-tx", literal, "*", kafka.ACLOperationWrite),
+			},
+		},
+		"OverlappingIntentsAndHosts": {
+			params: v1alpha1.KafkaAccessParameters{
+				Principal: kafka.TestACLPrincipal,
+				Hosts:     []string{"10.0.0.1", "10.0.0.2"},
+				Intents: []v1alpha1.KafkaAccessIntent{
+					{Role: v1alpha1.KafkaAccessRoleProducer, Topic: "orders"},
+					{Role: v1alpha1.KafkaAccessRoleProducer, Topic: "orders"},
+					{Role: v1alpha1.KafkaAccessRoleConsumer, Topic: "orders", Group: "billing"},
+				},
+			},
+			want: []*acl.AccessControlList{
+				binding(cluster, kafka.ACLClusterName, literal, "10.0.0.1", kafka.ACLOperationIdempotentWrite),
+				binding(cluster, kafka.ACLClusterName, literal, "10.0.0.2", kafka.ACLOperationIdempotentWrite),
+				binding(group, "billing", literal, "10.0.0.1", kafka.ACLOperationDescribe),
+				binding(group, "billing", literal, "10.0.0.1", kafka.ACLOperationRead),
+				binding(group, "billing", literal, "10.0.0.2", kafka.ACLOperationDescribe),
+				binding(group, "billing", literal, "10.0.0.2", kafka.ACLOperationRead),
+				binding(topic, "orders", literal, "10.0.0.1", kafka.ACLOperationDescribe),
+				binding(topic, "orders", literal, "10.0.0.1", kafka.ACLOperationRead),
+				binding(topic, "orders", literal, "10.0.0.1", kafka.ACLOperationWrite),
+				binding(topic, "orders", literal, "10.0.0.2", kafka.ACLOperationDescribe),
+				binding(topic, "orders", literal, "10.0.0.2", kafka.ACLOperationRead),
+				binding(topic, "orders", literal, "10.0.0.2", kafka.ACLOperationWrite),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.want, Expand(&tc.params)); diff != "" {
+				t.Errorf("Expand(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	cl := &fakeAdmin{}
+	bindings := Expand(&v1alpha1.KafkaAccessParameters{
+		Principal: kafka.TestACLPrincipal,
+		Intents: []v1alpha1.KafkaAccessIntent{
+			{Role: v1alpha1.KafkaAccessRoleProducer, Topic: "orders"},
+			{Role: v1alpha1.KafkaAccessRoleConsumer, Topic: "orders", Group: "billing"},
+		},
+	})
+	require.NoError(t, Create(context.Background(), cl, bindings))
+
+	want := []*kadm.ACLBuilder{
+		new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("*").
+			Operations(kadm.OpIdempotentWrite).ResourcePatternType(kadm.ACLPatternLiteral).Clusters(),
+		new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("*").
+			Operations(kadm.OpDescribe, kadm.OpRead).ResourcePatternType(kadm.ACLPatternLiteral).Groups("billing"),
+		new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("*").
+			Operations(kadm.OpDescribe, kadm.OpRead, kadm.OpWrite).ResourcePatternType(kadm.ACLPatternLiteral).Topics("orders"),
+	}
+	assert.True(t, reflect.DeepEqual(want, cl.created), "Create(...): expected a request per resource")
+}
@@ -0,0 +1,195 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusteraccess "github.com/crossplane-contrib/provider-kafka/apis/cluster/access/v1alpha1"
+	clusteracl "github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha2"
+	clusterv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	namespacedaccess "github.com/crossplane-contrib/provider-kafka/apis/namespaced/access/v1alpha1"
+	namespacedacl "github.com/crossplane-contrib/provider-kafka/apis/namespaced/acl/v1alpha2"
+	namespacedv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+)
+
+const (
+	errListDeclared          = "cannot list the declared ACL bindings"
+	errUnknownCluster        = "cannot determine the Kafka cluster of the ProviderConfig, as its health check has not recorded a cluster ID yet"
+	errUnsupportedConfigKind = "unsupported provider config kind"
+)
+
+// declarations collects the ACL bindings declared on a Kafka cluster.
+type declarations struct {
+	kube      client.Client
+	clusterID string
+	// except is the UID of the resource whose bindings are not collected.
+	except types.UID
+	// onCluster memoizes whether a ProviderConfig connects to the cluster.
+	onCluster map[kafka.ClientKey]bool
+	bindings  []*acl.AccessControlList
+}
+
+// Declared returns the ACL bindings that the AccessControlLists and
+// KafkaAccesses of both scopes declare on the Kafka cluster of the
+// ProviderConfig of the key. A resource declares bindings on the cluster if
+// the health check recorded the same cluster ID for its ProviderConfig,
+// whatever its scope or the name of its ProviderConfig. Resources whose
+// ProviderConfig cannot be read or has no recorded cluster ID are assumed to
+// use the cluster, so that their bindings are kept. Declared fails if no
+// cluster ID is recorded for the ProviderConfig of the key, as the clusters of
+// the other resources cannot be told apart then. Both the bindings of their
+// specs and of their external names are declared, so that bindings that are
+// being replaced are kept. The bindings of the except resource are left out,
+// so that it can tell which of its bindings other resources declare.
+func Declared(ctx context.Context, kube client.Client, key kafka.ClientKey, except metav1.Object) ([]*acl.AccessControlList, error) {
+	id, err := clusterID(ctx, kube, key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errUnknownCluster, err)
+	}
+	if id == "" {
+		return nil, errors.New(errUnknownCluster)
+	}
+	d := &declarations{kube: kube, clusterID: id, except: except.GetUID(), onCluster: map[kafka.ClientKey]bool{}}
+
+	cacls := &clusteracl.AccessControlListList{}
+	if err := kube.List(ctx, cacls); err != nil {
+		return nil, fmt.Errorf("%s: %w", errListDeclared, err)
+	}
+	for i := range cacls.Items {
+		a := &cacls.Items[i]
+		if d.declares(ctx, a, clusterKey(a)) {
+			d.add(meta.GetExternalName(a), acl.Expand(&a.Spec.ForProvider))
+		}
+	}
+
+	nacls := &namespacedacl.AccessControlListList{}
+	if err := kube.List(ctx, nacls); err != nil {
+		return nil, fmt.Errorf("%s: %w", errListDeclared, err)
+	}
+	for i := range nacls.Items {
+		a := &nacls.Items[i]
+		if d.declares(ctx, a, namespacedKey(a)) {
+			d.add(meta.GetExternalName(a), acl.Expand(&a.Spec.ForProvider))
+		}
+	}
+
+	ckas := &clusteraccess.KafkaAccessList{}
+	if err := kube.List(ctx, ckas); err != nil {
+		return nil, fmt.Errorf("%s: %w", errListDeclared, err)
+	}
+	for i := range ckas.Items {
+		ka := &ckas.Items[i]
+		if d.declares(ctx, ka, clusterKey(ka)) {
+			d.add(meta.GetExternalName(ka), Expand(&ka.Spec.ForProvider))
+		}
+	}
+
+	nkas := &namespacedaccess.KafkaAccessList{}
+	if err := kube.List(ctx, nkas); err != nil {
+		return nil, fmt.Errorf("%s: %w", errListDeclared, err)
+	}
+	for i := range nkas.Items {
+		ka := &nkas.Items[i]
+		if d.declares(ctx, ka, namespacedKey(ka)) {
+			d.add(meta.GetExternalName(ka), Expand(&ka.Spec.ForProvider))
+		}
+	}
+	return d.bindings, nil
+}
+
+// add declares the bindings and the bindings of the external name.
+func (d *declarations) add(extName string, bindings []*acl.AccessControlList) {
+	d.bindings = append(d.bindings, bindings...)
+	if a, err := acl.ConvertAllFromJSON(extName); err == nil {
+		d.bindings = append(d.bindings, a...)
+	}
+}
+
+// declares returns true if the resource is not the except resource and its
+// ProviderConfig connects to the cluster.
+func (d *declarations) declares(ctx context.Context, o metav1.Object, key kafka.ClientKey) bool {
+	if d.except != "" && o.GetUID() == d.except {
+		return false
+	}
+	return d.targets(ctx, key)
+}
+
+// targets returns true if the ProviderConfig of the key connects to the
+// cluster, or if its cluster is not known.
+func (d *declarations) targets(ctx context.Context, key kafka.ClientKey) bool {
+	if on, ok := d.onCluster[key]; ok {
+		return on
+	}
+	id, err := clusterID(ctx, d.kube, key)
+	on := err != nil || id == "" || id == d.clusterID
+	d.onCluster[key] = on
+	return on
+}
+
+// clusterID returns the ID of the Kafka cluster that the health check last
+// recorded in the status of the ProviderConfig of the key, or an empty string
+// if it has not recorded one yet.
+func clusterID(ctx context.Context, kube client.Client, key kafka.ClientKey) (string, error) {
+	var cluster *v1alpha1.ClusterObservation
+
+	switch key.Kind {
+	case clusterv1alpha1.ProviderConfigGroupKind:
+		pc := &clusterv1alpha1.ProviderConfig{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: key.Name}, pc); err != nil {
+			return "", err
+		}
+		cluster = pc.Status.Cluster
+	case namespacedv1alpha1.ProviderConfigGroupKind:
+		pc := &namespacedv1alpha1.ProviderConfig{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: key.Name, Namespace: key.Namespace}, pc); err != nil {
+			return "", err
+		}
+		cluster = pc.Status.Cluster
+	case namespacedv1alpha1.ClusterProviderConfigGroupKind:
+		cpc := &namespacedv1alpha1.ClusterProviderConfig{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: key.Name}, cpc); err != nil {
+			return "", err
+		}
+		cluster = cpc.Status.Cluster
+	default:
+		return "", fmt.Errorf("%s: %s", errUnsupportedConfigKind, key.Kind)
+	}
+
+	if cluster == nil {
+		return "", nil
+	}
+	return cluster.ClusterID, nil
+}
+
+// clusterKey returns the key of the ProviderConfig of a cluster scoped
+// managed resource.
+func clusterKey(mg xpresource.LegacyManaged) kafka.ClientKey { //nolint:staticcheck // cluster scoped managed resources are legacy managed
+	key := kafka.ClientKey{Kind: clusterv1alpha1.ProviderConfigGroupKind}
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		key.Name = ref.Name
+	}
+	return key
+}
+
+// namespacedKey returns the key of the ProviderConfig or
+// ClusterProviderConfig of a namespaced managed resource.
+func namespacedKey(mg xpresource.ModernManaged) kafka.ClientKey {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return kafka.ClientKey{Kind: namespacedv1alpha1.ProviderConfigGroupKind, Namespace: mg.GetNamespace()}
+	}
+	if ref.Kind == namespacedv1alpha1.ClusterProviderConfigKind {
+		return kafka.ClientKey{Kind: namespacedv1alpha1.ClusterProviderConfigGroupKind, Name: ref.Name}
+	}
+	return kafka.ClientKey{Kind: namespacedv1alpha1.ProviderConfigGroupKind, Namespace: mg.GetNamespace(), Name: ref.Name}
+}
@@ -0,0 +1,179 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusteraccess "github.com/crossplane-contrib/provider-kafka/apis/cluster/access/v1alpha1"
+	clusteracl "github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha2"
+	clusterv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	namespacedaccess "github.com/crossplane-contrib/provider-kafka/apis/namespaced/access/v1alpha1"
+	namespacedacl "github.com/crossplane-contrib/provider-kafka/apis/namespaced/acl/v1alpha2"
+	namespacedv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+)
+
+func TestDeclared(t *testing.T) {
+	t.Parallel()
+
+	aclParams := func(topic string) v1alpha2.AccessControlListParameters {
+		return v1alpha2.AccessControlListParameters{
+			ResourceName:              topic,
+			ResourceType:              "Topic",
+			ResourcePrincipal:         "User:alice",
+			ResourceOperation:         "Read",
+			ResourcePermissionType:    "Allow",
+			ResourcePatternTypeFilter: "Literal",
+		}
+	}
+	accessParams := func(topic string) v1alpha1.KafkaAccessParameters {
+		return v1alpha1.KafkaAccessParameters{
+			Principal: "User:alice",
+			Intents:   []v1alpha1.KafkaAccessIntent{{Role: v1alpha1.KafkaAccessRoleProducer, Topic: topic}},
+		}
+	}
+	clusterRef := func(name string) xpv2.ClusterManagedResourceSpec {
+		return xpv2.ClusterManagedResourceSpec{ProviderConfigReference: &xpv2.Reference{Name: name}}
+	}
+	namespacedRef := func(kind, name string) xpv2.ManagedResourceSpec {
+		return xpv2.ManagedResourceSpec{ProviderConfigReference: &xpv2.ProviderConfigReference{Kind: kind, Name: name}}
+	}
+
+	// The health check recorded the cluster of the cluster ProviderConfig
+	// "same", the ClusterProviderConfig "shared" and the ProviderConfig
+	// "team/unchanged" as the cluster, and the cluster of "other" and
+	// "team/default" as another one. It did not record the cluster of
+	// "team/pending" yet.
+	clusters := map[string]string{
+		"same":           "abc",
+		"shared":         "abc",
+		"other":          "xyz",
+		"team/default":   "xyz",
+		"team/unchanged": "abc",
+		"team/pending":   "",
+	}
+
+	replaced := clusteracl.AccessControlList{Spec: clusteracl.AccessControlListSpec{ClusterManagedResourceSpec: clusterRef("same"), ForProvider: aclParams("payments")}}
+	meta.SetExternalName(&replaced, `{"ResourceName":"refunds","ResourceType":"Topic","ResourcePrincipal":"User:alice","ResourceHost":"*","ResourceOperation":"Read","ResourcePermissionType":"Allow","ResourcePatternTypeFilter":"Literal"}`)
+	cacls := []clusteracl.AccessControlList{
+		replaced,
+		{Spec: clusteracl.AccessControlListSpec{ClusterManagedResourceSpec: clusterRef("other"), ForProvider: aclParams("invoices")}},
+	}
+	nacls := []namespacedacl.AccessControlList{
+		{Spec: namespacedacl.AccessControlListSpec{ManagedResourceSpec: namespacedRef("ClusterProviderConfig", "shared"), ForProvider: aclParams("ledger")}},
+		{Spec: namespacedacl.AccessControlListSpec{ManagedResourceSpec: namespacedRef("ProviderConfig", "default"), ForProvider: aclParams("audit")}},
+	}
+	nacls[1].SetNamespace("team")
+	ckas := []clusteraccess.KafkaAccess{
+		{Spec: clusteraccess.KafkaAccessSpec{ClusterManagedResourceSpec: clusterRef("missing"), ForProvider: accessParams("orders")}},
+		{Spec: clusteraccess.KafkaAccessSpec{ClusterManagedResourceSpec: clusterRef("same"), ForProvider: accessParams("self")}},
+	}
+	ckas[1].SetUID("self")
+	nkas := []namespacedaccess.KafkaAccess{
+		{Spec: namespacedaccess.KafkaAccessSpec{ManagedResourceSpec: namespacedRef("ProviderConfig", "unchanged"), ForProvider: accessParams("shipments")}},
+		{Spec: namespacedaccess.KafkaAccessSpec{ManagedResourceSpec: namespacedRef("ProviderConfig", "pending"), ForProvider: accessParams("returns")}},
+	}
+	nkas[0].SetNamespace("team")
+	nkas[1].SetNamespace("team")
+
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch l := list.(type) {
+			case *clusteracl.AccessControlListList:
+				l.Items = cacls
+			case *namespacedacl.AccessControlListList:
+				l.Items = nacls
+			case *clusteraccess.KafkaAccessList:
+				l.Items = ckas
+			case *namespacedaccess.KafkaAccessList:
+				l.Items = nkas
+			}
+			return nil
+		},
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			name := key.Name
+			if key.Namespace != "" {
+				name = key.Namespace + "/" + name
+			}
+			id, ok := clusters[name]
+			if !ok {
+				return kerrors.NewNotFound(schema.GroupResource{}, name)
+			}
+			var cluster *v1alpha1.ClusterObservation
+			if id != "" {
+				cluster = &v1alpha1.ClusterObservation{ClusterID: id}
+			}
+			switch pc := obj.(type) {
+			case *clusterv1alpha1.ProviderConfig:
+				pc.Status.Cluster = cluster
+			case *namespacedv1alpha1.ProviderConfig:
+				pc.Status.Cluster = cluster
+			case *namespacedv1alpha1.ClusterProviderConfig:
+				pc.Status.Cluster = cluster
+			}
+			return nil
+		},
+	}
+
+	declared, err := Declared(context.Background(), kube, kafka.ClientKey{Kind: clusterv1alpha1.ProviderConfigGroupKind, Name: "same"}, &ckas[1])
+	require.NoError(t, err)
+	topics := map[string]bool{}
+	for _, d := range declared {
+		if d.ResourceType == "Topic" {
+			topics[d.ResourceName] = true
+		}
+	}
+	// invoices and audit are declared on another cluster and self by the
+	// except resource; the ProviderConfig of orders cannot be read and the
+	// cluster of returns is not known yet, so that their bindings are kept.
+	want := map[string]bool{"ledger": true, "orders": true, "payments": true, "refunds": true, "returns": true, "shipments": true}
+	if diff := cmp.Diff(want, topics); diff != "" {
+		t.Errorf("Declared(...): topics: -want, +got:\n%s", diff)
+	}
+}
+
+func TestDeclaredUnknownCluster(t *testing.T) {
+	t.Parallel()
+
+	key := kafka.ClientKey{Kind: clusterv1alpha1.ProviderConfigGroupKind, Name: "default"}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		want   error
+	}{
+		"NotChecked": {
+			reason: "Declared should fail if the health check did not record the cluster of the ProviderConfig yet",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			want:   errors.New(errUnknownCluster),
+		},
+		"ProviderConfigNotFound": {
+			reason: "Declared should fail if the ProviderConfig cannot be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			want:   fmt.Errorf("%s: %w", errUnknownCluster, errors.New("boom")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Declared(context.Background(), tc.kube, key, &clusteraccess.KafkaAccess{})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDeclared(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
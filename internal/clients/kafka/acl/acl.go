@@ -5,21 +5,35 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kmsg"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
 	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
 )
 
-// adminClient is the subset of kadm.Client methods used by this package.
-// *kadm.Client satisfies this interface without any changes to callers.
+const (
+	errNoBindings       = "no ACL bindings"
+	errMixedBindings    = "ACL bindings differ in permission or pattern type"
+	errNotCombinations  = "ACL bindings are not all combinations of their principals, hosts, operations and resources"
+	errInvalidOperation = "operation is not valid for the resource type"
+	errUnsupportedType  = "resource type is not supported by the Kafka admin client"
+)
+
+// adminClient is the subset of kafka.Client methods used by this package.
+// The ACLs of User resources, which kadm builds no requests for, are managed
+// with raw requests.
 type adminClient interface {
 	CreateACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.CreateACLsResults, error)
 	DeleteACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DeleteACLsResults, error)
 	DescribeACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DescribeACLsResults, error)
+	kmsg.Requestor
 }
 
 // AccessControlList is a holistic representation of a Kafka ACL with configurable
@@ -34,39 +48,163 @@ type AccessControlList struct {
 	ResourcePatternTypeFilter string `json:"ResourcePatternTypeFilter"`
 }
 
-// buildACLBuilder constructs an ACLBuilder from an AccessControlList.
-func buildACLBuilder(accessControlList *AccessControlList) (*kadm.ACLBuilder, error) {
-	o, err := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation))
-	if err != nil {
-		return nil, fmt.Errorf("did not return ACL Operation: %w", err)
+// buildACLBuilder constructs an ACLBuilder from AccessControlLists. The
+// builder combines every principal, host, operation and resource, so the
+// AccessControlLists must be all of their combinations, with the same
+// permission and pattern type, as returned by Expand.
+func buildACLBuilder(accessControlLists ...*AccessControlList) (*kadm.ACLBuilder, error) {
+	if len(accessControlLists) == 0 {
+		return nil, errors.New(errNoBindings)
 	}
+	first := accessControlLists[0]
 
-	rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter))
+	rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(first.ResourcePatternTypeFilter))
 	if err != nil {
 		return nil, fmt.Errorf("did not return parsing of ACL pattern: %w", err)
 	}
 
+	var principals, hosts, opNames, types []string
+	resources := map[string][]string{}
+	resourceCount := 0
+	bindings := map[AccessControlList]bool{}
+	for _, a := range accessControlLists {
+		if a.ResourcePermissionType != first.ResourcePermissionType || a.ResourcePatternTypeFilter != first.ResourcePatternTypeFilter {
+			return nil, errors.New(errMixedBindings)
+		}
+		if err := validateOperation(a.ResourceType, a.ResourceOperation); err != nil {
+			return nil, err
+		}
+		bindings[*a] = true
+		principals = appendUnique(principals, a.ResourcePrincipal)
+		hosts = appendUnique(hosts, a.ResourceHost)
+		opNames = appendUnique(opNames, a.ResourceOperation)
+		types = appendUnique(types, a.ResourceType)
+		names := resources[a.ResourceType]
+		if resources[a.ResourceType] = appendUnique(names, a.ResourceName); len(resources[a.ResourceType]) > len(names) {
+			resourceCount++
+		}
+	}
+	if len(principals)*len(hosts)*len(opNames)*resourceCount != len(bindings) {
+		return nil, errors.New(errNotCombinations)
+	}
+
+	ops := make([]kmsg.ACLOperation, 0, len(opNames))
+	for _, name := range opNames {
+		o, err := kmsg.ParseACLOperation(strings.ToLower(name))
+		if err != nil {
+			return nil, fmt.Errorf("did not return ACL Operation: %w", err)
+		}
+		ops = append(ops, o)
+	}
+
 	b := kadm.ACLBuilder{}
-	ab := b.Allow(accessControlList.ResourcePrincipal).AllowHosts(accessControlList.ResourceHost).Operations(o).ResourcePatternType(rpt)
+	ab := &b
+	if first.ResourcePermissionType == kafka.ACLPermissionTypeDeny {
+		ab = ab.Deny(principals...).DenyHosts(hosts...)
+	} else {
+		ab = ab.Allow(principals...).AllowHosts(hosts...)
+	}
+	ab = ab.Operations(ops...).ResourcePatternType(rpt)
 
-	switch accessControlList.ResourceType {
-	case kafka.ACLResourceTypeTopic:
-		ab = ab.Topics(accessControlList.ResourceName)
-	case kafka.ACLResourceTypeGroup:
-		ab = ab.Groups(accessControlList.ResourceName)
-	case kafka.ACLResourceTypeTransactionalID:
-		ab = ab.TransactionalIDs(accessControlList.ResourceName)
-	case kafka.ACLResourceTypeCluster:
-		ab = ab.Clusters()
-	case kafka.ACLResourceTypeAny:
-		ab = ab.AnyResource(accessControlList.ResourceName)
+	for _, resourceType := range types {
+		names := resources[resourceType]
+		switch resourceType {
+		case kafka.ACLResourceTypeTopic:
+			ab = ab.Topics(names...)
+		case kafka.ACLResourceTypeGroup:
+			ab = ab.Groups(names...)
+		case kafka.ACLResourceTypeTransactionalID:
+			ab = ab.TransactionalIDs(names...)
+		case kafka.ACLResourceTypeCluster:
+			ab = ab.Clusters()
+		case kafka.ACLResourceTypeDelegationToken:
+			ab = ab.DelegationTokens(names...)
+		case kafka.ACLResourceTypeAny:
+			ab = ab.AnyResource(names...)
+		default:
+			// kadm builds no requests for User resources, which are
+			// managed with raw requests, and Unknown resources are
+			// rejected by Kafka.
+			return nil, fmt.Errorf("%s: %s", errUnsupportedType, resourceType)
+		}
 	}
 
 	return ab, nil
 }
 
-// List lists all the ACLs in Kafka
+// validOperations are the operations Kafka accepts on each resource type,
+// besides All.
+var validOperations = map[string][]string{
+	kafka.ACLResourceTypeTopic:           {kafka.ACLOperationRead, kafka.ACLOperationWrite, "Create", "Delete", "Alter", kafka.ACLOperationDescribe, "DescribeConfigs", kafka.ACLOperationAlterConfigs},
+	kafka.ACLResourceTypeGroup:           {kafka.ACLOperationRead, kafka.ACLOperationDescribe, "Delete"},
+	kafka.ACLResourceTypeCluster:         {"Create", "Alter", kafka.ACLOperationDescribe, "ClusterAction", "DescribeConfigs", kafka.ACLOperationAlterConfigs, kafka.ACLOperationIdempotentWrite},
+	kafka.ACLResourceTypeTransactionalID: {kafka.ACLOperationWrite, kafka.ACLOperationDescribe},
+	kafka.ACLResourceTypeDelegationToken: {kafka.ACLOperationDescribe},
+	kafka.ACLResourceTypeUser:            {kafka.ACLOperationCreateTokens, kafka.ACLOperationDescribeTokens},
+}
+
+// validateOperation returns an error if Kafka does not accept the operation
+// on resources of the type. The Any resource type and operation only filter
+// bindings, so they are valid with everything.
+func validateOperation(resourceType, operation string) error {
+	ops, ok := validOperations[resourceType]
+	if !ok || operation == kafka.ACLOperationAll || operation == kafka.ACLOperationAny || slices.Contains(ops, operation) {
+		return nil
+	}
+	return fmt.Errorf("%s: %s on %s", errInvalidOperation, operation, resourceType)
+}
+
+// appendUnique appends v to s unless s already contains it.
+func appendUnique(s []string, v string) []string {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// ErrAmbiguousACL indicates that several ACLs in Kafka match an
+// AccessControlList, none of them exactly.
+var ErrAmbiguousACL = errors.New("ACL matches several bindings in Kafka")
+
+// List returns the ACL binding held by Kafka that matches the
+// AccessControlList. The binding that equals the AccessControlList is
+// returned if there is one, otherwise the only matching binding, which then
+// differs from the AccessControlList as reported by Drift. It returns nil if
+// no binding matches and ErrAmbiguousACL if several bindings match.
 func List(ctx context.Context, cl adminClient, accessControlList *AccessControlList) (*AccessControlList, error) {
+	described, err := describe(ctx, cl, accessControlList)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []AccessControlList
+	for _, d := range described {
+		if permissionTypeString(d.Permission) != accessControlList.ResourcePermissionType {
+			continue
+		}
+		matches = append(matches, observed(d))
+	}
+
+	for i := range matches {
+		if matches[i] == *accessControlList {
+			return &matches[i], nil
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, fmt.Errorf("%w: %d bindings match", ErrAmbiguousACL, len(matches))
+}
+
+// describe returns the ACLs held by Kafka that match the AccessControlList.
+func describe(ctx context.Context, cl adminClient, accessControlList *AccessControlList) ([]kadm.DescribedACL, error) {
+	if isUser(accessControlList) {
+		return describeRaw(ctx, cl, accessControlList)
+	}
+
 	ab, err := buildACLBuilder(accessControlList)
 	if err != nil {
 		return nil, err
@@ -76,30 +214,168 @@ func List(ctx context.Context, cl adminClient, accessControlList *AccessControlL
 	if err != nil {
 		return nil, fmt.Errorf("describe ACLs failed: %w", err)
 	}
-	if len(resp) == 0 {
-		return nil, nil
+
+	var described []kadm.DescribedACL
+	for _, r := range resp {
+		if r.Err != nil {
+			return nil, fmt.Errorf("describe ACLs failed: %w", r.Err)
+		}
+		described = append(described, r.Described...)
 	}
-	if resp[0].Err != nil {
-		return nil, fmt.Errorf("describe ACLs failed: %w", resp[0].Err)
+	return described, nil
+}
+
+// Drift returns a description of every field of the observed ACL binding
+// that differs from the desired AccessControlList.
+func Drift(desired AccessControlList, observed AccessControlList) []string {
+	var drift []string
+	for _, f := range diffFields(desired, observed) {
+		drift = append(drift, fmt.Sprintf("%s is %q instead of %q", f.name, f.b, f.a))
 	}
-	if len(resp[0].Described) == 0 {
-		return nil, nil
+	return drift
+}
+
+// ChangedFields returns the names of the fields that differ between two
+// AccessControlLists.
+func ChangedFields(a, b AccessControlList) []string {
+	var changed []string
+	for _, f := range diffFields(a, b) {
+		changed = append(changed, f.name)
 	}
+	return changed
+}
 
-	acl := AccessControlList{}
-	acl.ResourceType = accessControlList.ResourceType
-	acl.ResourcePrincipal = accessControlList.ResourcePrincipal
-	acl.ResourceHost = accessControlList.ResourceHost
-	acl.ResourceOperation = accessControlList.ResourceOperation
-	acl.ResourcePermissionType = accessControlList.ResourcePermissionType
-	acl.ResourcePatternTypeFilter = accessControlList.ResourcePatternTypeFilter
+type fieldDiff struct {
+	name string
+	a, b string
+}
 
-	return &acl, nil
+// diffFields returns the fields that differ between two AccessControlLists,
+// named as in AccessControlListParameters.
+func diffFields(a, b AccessControlList) []fieldDiff {
+	var diffs []fieldDiff
+	for _, f := range []fieldDiff{
+		{name: "resourceName", a: a.ResourceName, b: b.ResourceName},
+		{name: "resourceType", a: a.ResourceType, b: b.ResourceType},
+		{name: "resourcePrincipal", a: a.ResourcePrincipal, b: b.ResourcePrincipal},
+		{name: "resourceHost", a: a.ResourceHost, b: b.ResourceHost},
+		{name: "resourceOperation", a: a.ResourceOperation, b: b.ResourceOperation},
+		{name: "resourcePermissionType", a: a.ResourcePermissionType, b: b.ResourcePermissionType},
+		{name: "resourcePatternTypeFilter", a: a.ResourcePatternTypeFilter, b: b.ResourcePatternTypeFilter},
+	} {
+		if f.a != f.b {
+			diffs = append(diffs, f)
+		}
+	}
+	return diffs
 }
 
-// Create creates an ACL from the Kafka side
-func Create(ctx context.Context, cl adminClient, accessControlList *AccessControlList) error {
-	ab, err := buildACLBuilder(accessControlList)
+// permissionTypeString converts a Kafka permission type to the value used in
+// AccessControlList.ResourcePermissionType.
+func permissionTypeString(p kmsg.ACLPermissionType) string {
+	switch p {
+	case kmsg.ACLPermissionTypeAllow:
+		return kafka.ACLPermissionTypeAllow
+	case kmsg.ACLPermissionTypeDeny:
+		return kafka.ACLPermissionTypeDeny
+	case kmsg.ACLPermissionTypeAny:
+		return kafka.ACLPermissionTypeAny
+	default:
+		return kafka.ACLPermissionTypeUnknown
+	}
+}
+
+// resourceTypes are the AccessControlList resource types of the Kafka ACL
+// resource types that an AccessControlList can manage.
+var resourceTypes = map[kmsg.ACLResourceType]string{
+	kmsg.ACLResourceTypeTopic:           kafka.ACLResourceTypeTopic,
+	kmsg.ACLResourceTypeGroup:           kafka.ACLResourceTypeGroup,
+	kmsg.ACLResourceTypeCluster:         kafka.ACLResourceTypeCluster,
+	kmsg.ACLResourceTypeTransactionalId: kafka.ACLResourceTypeTransactionalID,
+	kmsg.ACLResourceTypeDelegationToken: kafka.ACLResourceTypeDelegationToken,
+	kmsg.ACLResourceTypeUser:            kafka.ACLResourceTypeUser,
+}
+
+// operations are the AccessControlList operations of the Kafka ACL
+// operations that an AccessControlList can manage.
+var operations = map[kmsg.ACLOperation]string{
+	kmsg.ACLOperationAll:             kafka.ACLOperationAll,
+	kmsg.ACLOperationRead:            kafka.ACLOperationRead,
+	kmsg.ACLOperationWrite:           kafka.ACLOperationWrite,
+	kmsg.ACLOperationCreate:          "Create",
+	kmsg.ACLOperationDelete:          "Delete",
+	kmsg.ACLOperationAlter:           "Alter",
+	kmsg.ACLOperationDescribe:        kafka.ACLOperationDescribe,
+	kmsg.ACLOperationClusterAction:   "ClusterAction",
+	kmsg.ACLOperationDescribeConfigs: "DescribeConfigs",
+	kmsg.ACLOperationAlterConfigs:    kafka.ACLOperationAlterConfigs,
+	kmsg.ACLOperationIdempotentWrite: kafka.ACLOperationIdempotentWrite,
+	kmsg.ACLOperationCreateTokens:    kafka.ACLOperationCreateTokens,
+	kmsg.ACLOperationDescribeTokens:  kafka.ACLOperationDescribeTokens,
+}
+
+// patternTypes are the AccessControlList pattern types of the Kafka ACL
+// pattern types that an AccessControlList can manage.
+var patternTypes = map[kmsg.ACLResourcePatternType]string{
+	kmsg.ACLResourcePatternTypeLiteral:  kafka.ACLPatternTypeLiteral,
+	kmsg.ACLResourcePatternTypePrefixed: "Prefixed",
+}
+
+// FromDescribed converts an ACL described by Kafka to an AccessControlList.
+// It returns false if the ACL has a resource type, operation or pattern type
+// that an AccessControlList cannot manage.
+func FromDescribed(d kadm.DescribedACL) (*AccessControlList, bool) {
+	_, rtOK := resourceTypes[d.Type]
+	_, opOK := operations[d.Operation]
+	_, ptOK := patternTypes[d.Pattern]
+	if !rtOK || !opOK || !ptOK {
+		return nil, false
+	}
+	a := observed(d)
+	return &a, true
+}
+
+// observed converts an ACL described by Kafka to an AccessControlList. Values
+// that an AccessControlList cannot manage are kept in the notation of Kafka.
+func observed(d kadm.DescribedACL) AccessControlList {
+	return AccessControlList{
+		ResourceName:              d.Name,
+		ResourceType:              valueOr(resourceTypes, d.Type),
+		ResourcePrincipal:         d.Principal,
+		ResourceHost:              d.Host,
+		ResourceOperation:         valueOr(operations, d.Operation),
+		ResourcePermissionType:    permissionTypeString(d.Permission),
+		ResourcePatternTypeFilter: valueOr(patternTypes, d.Pattern),
+	}
+}
+
+// valueOr returns the value of the key, or the key itself if the map has no
+// value for it.
+func valueOr[K interface {
+	comparable
+	String() string
+}](m map[K]string, k K) string {
+	if v, ok := m[k]; ok {
+		return v
+	}
+	return k.String()
+}
+
+// Create creates ACLs from the Kafka side. The AccessControlLists of User
+// resources are created by a single raw request and the others by a single
+// request of kadm, so they must be valid for buildACLBuilder.
+func Create(ctx context.Context, cl adminClient, accessControlLists ...*AccessControlList) error {
+	users, others := splitUsers(accessControlLists)
+	if len(users) > 0 {
+		if err := createRaw(ctx, cl, users); err != nil {
+			return err
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	ab, err := buildACLBuilder(others...)
 	if err != nil {
 		return err
 	}
@@ -111,8 +387,10 @@ func Create(ctx context.Context, cl adminClient, accessControlList *AccessContro
 	if len(resp) == 0 {
 		return errors.New("no create response for acl")
 	}
-	if resp[0].Err != nil {
-		return fmt.Errorf("create ACL failed: %w", resp[0].Err)
+	for _, r := range resp {
+		if r.Err != nil {
+			return fmt.Errorf("create ACL failed: %w", r.Err)
+		}
 	}
 	if len(resp[0].Principal) == 0 {
 		return errors.New("no create response for acl")
@@ -121,20 +399,195 @@ func Create(ctx context.Context, cl adminClient, accessControlList *AccessContro
 	return nil
 }
 
-// Delete deletes an ACL from the Kafka side
-func Delete(ctx context.Context, cl adminClient, accessControlList *AccessControlList) error {
-	ab, err := buildACLBuilder(accessControlList)
+// Delete deletes ACLs from the Kafka side. Each AccessControlList is deleted
+// by its own request, so they may be any set of bindings.
+func Delete(ctx context.Context, cl adminClient, accessControlLists ...*AccessControlList) error {
+	for _, a := range accessControlLists {
+		if isUser(a) {
+			if err := deleteRaw(ctx, cl, a); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ab, err := buildACLBuilder(a)
+		if err != nil {
+			return err
+		}
+
+		resp, err := cl.DeleteACLs(ctx, ab)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp {
+			if r.Err != nil {
+				return fmt.Errorf("delete ACL failed: %w", r.Err)
+			}
+		}
+	}
+	return nil
+}
+
+// isUser returns true if the AccessControlList binds a User resource.
+func isUser(a *AccessControlList) bool {
+	return a.ResourceType == kafka.ACLResourceTypeUser
+}
+
+// splitUsers splits AccessControlLists into those of User resources and the
+// others.
+func splitUsers(acls []*AccessControlList) (users, others []*AccessControlList) {
+	for _, a := range acls {
+		if isUser(a) {
+			users = append(users, a)
+		} else {
+			others = append(others, a)
+		}
+	}
+	return users, others
+}
+
+// rawBinding is an AccessControlList as encoded in raw ACL requests.
+type rawBinding struct {
+	resourceType kmsg.ACLResourceType
+	name         string
+	pattern      kmsg.ACLResourcePatternType
+	principal    string
+	host         string
+	operation    kmsg.ACLOperation
+	permission   kmsg.ACLPermissionType
+}
+
+// parseRaw encodes an AccessControlList for raw ACL requests.
+func parseRaw(a *AccessControlList) (rawBinding, error) {
+	if err := validateOperation(a.ResourceType, a.ResourceOperation); err != nil {
+		return rawBinding{}, err
+	}
+	rt, err := kmsg.ParseACLResourceType(strings.ToLower(a.ResourceType))
+	if err != nil {
+		return rawBinding{}, fmt.Errorf("did not return ACL resource type: %w", err)
+	}
+	rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(a.ResourcePatternTypeFilter))
+	if err != nil {
+		return rawBinding{}, fmt.Errorf("did not return parsing of ACL pattern: %w", err)
+	}
+	op, err := kmsg.ParseACLOperation(strings.ToLower(a.ResourceOperation))
+	if err != nil {
+		return rawBinding{}, fmt.Errorf("did not return ACL Operation: %w", err)
+	}
+	perm, err := kmsg.ParseACLPermissionType(strings.ToLower(a.ResourcePermissionType))
+	if err != nil {
+		return rawBinding{}, fmt.Errorf("did not return ACL permission type: %w", err)
+	}
+	return rawBinding{
+		resourceType: rt,
+		name:         a.ResourceName,
+		pattern:      rpt,
+		principal:    a.ResourcePrincipal,
+		host:         a.ResourceHost,
+		operation:    op,
+		permission:   perm,
+	}, nil
+}
+
+// createRaw creates ACLs by a single raw request.
+func createRaw(ctx context.Context, cl adminClient, acls []*AccessControlList) error {
+	req := kmsg.NewPtrCreateACLsRequest()
+	for _, a := range acls {
+		b, err := parseRaw(a)
+		if err != nil {
+			return err
+		}
+		c := kmsg.NewCreateACLsRequestCreation()
+		c.ResourceType = b.resourceType
+		c.ResourceName = b.name
+		c.ResourcePatternType = b.pattern
+		c.Principal = b.principal
+		c.Host = b.host
+		c.Operation = b.operation
+		c.PermissionType = b.permission
+		req.Creations = append(req.Creations, c)
+	}
+
+	resp, err := req.RequestWith(ctx, cl)
+	if err != nil {
+		return err
+	}
+	if len(resp.Results) != len(req.Creations) {
+		return errors.New("no create response for acl")
+	}
+	for _, r := range resp.Results {
+		if err := kerr.ErrorForCode(r.ErrorCode); err != nil {
+			return fmt.Errorf("create ACL failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// describeRaw describes the ACLs that match an AccessControlList by a raw
+// request.
+func describeRaw(ctx context.Context, cl adminClient, a *AccessControlList) ([]kadm.DescribedACL, error) {
+	b, err := parseRaw(a)
+	if err != nil {
+		return nil, err
+	}
+	req := kmsg.NewPtrDescribeACLsRequest()
+	req.ResourceType = b.resourceType
+	req.ResourceName = &b.name
+	req.ResourcePatternType = b.pattern
+	req.Principal = &b.principal
+	req.Host = &b.host
+	req.Operation = b.operation
+	req.PermissionType = b.permission
+
+	resp, err := req.RequestWith(ctx, cl)
+	if err != nil {
+		return nil, fmt.Errorf("describe ACLs failed: %w", err)
+	}
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		return nil, fmt.Errorf("describe ACLs failed: %w", err)
+	}
+
+	var described []kadm.DescribedACL
+	for _, r := range resp.Resources {
+		for _, d := range r.ACLs {
+			described = append(described, kadm.DescribedACL{
+				Principal:  d.Principal,
+				Host:       d.Host,
+				Type:       r.ResourceType,
+				Name:       r.ResourceName,
+				Pattern:    r.ResourcePatternType,
+				Operation:  d.Operation,
+				Permission: d.PermissionType,
+			})
+		}
+	}
+	return described, nil
+}
+
+// deleteRaw deletes the ACL of an AccessControlList by a raw request.
+func deleteRaw(ctx context.Context, cl adminClient, a *AccessControlList) error {
+	b, err := parseRaw(a)
 	if err != nil {
 		return err
 	}
+	f := kmsg.NewDeleteACLsRequestFilter()
+	f.ResourceType = b.resourceType
+	f.ResourceName = &b.name
+	f.ResourcePatternType = b.pattern
+	f.Principal = &b.principal
+	f.Host = &b.host
+	f.Operation = b.operation
+	f.PermissionType = b.permission
+	req := kmsg.NewPtrDeleteACLsRequest()
+	req.Filters = append(req.Filters, f)
 
-	resp, err := cl.DeleteACLs(ctx, ab)
+	resp, err := req.RequestWith(ctx, cl)
 	if err != nil {
 		return err
 	}
-	for _, r := range resp {
-		if r.Err != nil {
-			return fmt.Errorf("delete ACL failed: %w", r.Err)
+	for _, r := range resp.Results {
+		if err := kerr.ErrorForCode(r.ErrorCode); err != nil {
+			return fmt.Errorf("delete ACL failed: %w", err)
 		}
 	}
 	return nil
@@ -160,35 +613,39 @@ func ConvertFromJSON(extname string) (*AccessControlList, error) {
 	return &acl, nil
 }
 
-// Diff performs a Diff of and existing and observed ACL and provides
-// the User with an error string and the difference if one is found
-func Diff(existing AccessControlList, observed AccessControlList) []string {
-	diff := make([]string, 0)
-	if existing.ResourceType != observed.ResourceType {
-		str := "Resource Type has been updated, which is not allowed."
-		diff = append(diff, str)
+// ConvertAllToJSON performs a json marshalling for a set of ACLs. A single
+// ACL is marshalled as by ConvertToJSON, so that the external names of
+// AccessControlLists with one binding are unchanged.
+func ConvertAllToJSON(acls []*AccessControlList) (string, error) {
+	if len(acls) == 1 {
+		return ConvertToJSON(acls[0])
 	}
-	if existing.ResourcePrincipal != observed.ResourcePrincipal {
-		str := "Resource Principal has been updated, which is not allowed."
-		diff = append(diff, str)
-	}
-	if existing.ResourceHost != observed.ResourceHost {
-		str := "Resource Host has been updated, which is not allowed."
-		diff = append(diff, str)
+	j, err := json.Marshal(acls)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal ACLs to JSON: %w", err)
 	}
-	if existing.ResourceOperation != observed.ResourceOperation {
-		str := "Resource Operation has been updated, which is not allowed."
-		diff = append(diff, str)
+
+	return string(j), nil
+}
+
+// ConvertAllFromJSON performs a json unmarshalling for a set of ACLs, as
+// marshalled by ConvertAllToJSON or ConvertToJSON.
+func ConvertAllFromJSON(extname string) ([]*AccessControlList, error) {
+	if !strings.HasPrefix(strings.TrimSpace(extname), "[") {
+		acl, err := ConvertFromJSON(extname)
+		if err != nil {
+			return nil, err
+		}
+		return []*AccessControlList{acl}, nil
 	}
-	if existing.ResourcePermissionType != observed.ResourcePermissionType {
-		str := "Resource Permission Type has been updated, which is not allowed."
-		diff = append(diff, str)
+	var acls []*AccessControlList
+	if err := json.Unmarshal([]byte(extname), &acls); err != nil {
+		return nil, fmt.Errorf("could not unmarshal ACLs from JSON: %w", err)
 	}
-	if existing.ResourcePatternTypeFilter != observed.ResourcePatternTypeFilter {
-		str := "Resource Pattern Type Filter has been updated, which is not allowed."
-		diff = append(diff, str)
+	if len(acls) == 0 || slices.Contains(acls, nil) {
+		return nil, errors.New("could not unmarshal ACLs from JSON: empty ACL")
 	}
-	return diff
+	return acls, nil
 }
 
 // CompareAcls performs an observed to incoming ACL comparison
@@ -211,6 +668,216 @@ func Generate(params *v1alpha1.AccessControlListParameters) *AccessControlList {
 	return acl
 }
 
+// Expand returns the ACL bindings of AccessControlListParameters, one for
+// every combination of their hosts, operations and resources, sorted as by
+// Sort. All hosts are bound if the parameters have none.
+func Expand(params *v1alpha2.AccessControlListParameters) []*AccessControlList {
+	var hosts, operations []string
+	if params.ResourceHost != "" {
+		hosts = append(hosts, params.ResourceHost)
+	}
+	for _, h := range params.ResourceHosts {
+		hosts = appendUnique(hosts, h)
+	}
+	if len(hosts) == 0 {
+		hosts = []string{"*"}
+	}
+	if params.ResourceOperation != "" {
+		operations = append(operations, params.ResourceOperation)
+	}
+	for _, o := range params.ResourceOperations {
+		operations = appendUnique(operations, o)
+	}
+	var resources []v1alpha2.AccessControlListResource
+	if params.ResourceType != "" {
+		resources = append(resources, v1alpha2.AccessControlListResource{Type: params.ResourceType, Name: params.ResourceName})
+	}
+	for _, r := range params.Resources {
+		if !slices.Contains(resources, r) {
+			resources = append(resources, r)
+		}
+	}
+
+	acls := make([]*AccessControlList, 0, len(hosts)*len(operations)*len(resources))
+	for _, r := range resources {
+		for _, h := range hosts {
+			for _, o := range operations {
+				acls = append(acls, &AccessControlList{
+					ResourceName:              r.Name,
+					ResourceType:              r.Type,
+					ResourcePrincipal:         params.ResourcePrincipal,
+					ResourceHost:              h,
+					ResourceOperation:         o,
+					ResourcePermissionType:    params.ResourcePermissionType,
+					ResourcePatternTypeFilter: params.ResourcePatternTypeFilter,
+				})
+			}
+		}
+	}
+	Sort(acls)
+	return acls
+}
+
+// Sort sorts ACLs by resource, principal, host, operation and permission.
+func Sort(acls []*AccessControlList) {
+	sort.Slice(acls, func(i, j int) bool {
+		return sortKey(acls[i]) < sortKey(acls[j])
+	})
+}
+
+func sortKey(a *AccessControlList) string {
+	return a.ResourceType + "\x00" + a.ResourceName + "\x00" + a.ResourcePatternTypeFilter + "\x00" +
+		a.ResourcePrincipal + "\x00" + a.ResourceHost + "\x00" + a.ResourceOperation + "\x00" + a.ResourcePermissionType
+}
+
+// SameBindings returns true if two sets of ACLs have the same bindings, in
+// any order.
+func SameBindings(a, b []*AccessControlList) bool {
+	return len(Subtract(a, b)) == 0 && len(Subtract(b, a)) == 0
+}
+
+// Subtract returns the ACLs of a that are not in b.
+func Subtract(a, b []*AccessControlList) []*AccessControlList {
+	in := make(map[AccessControlList]bool, len(b))
+	for _, acl := range b {
+		in[*acl] = true
+	}
+	var out []*AccessControlList
+	for _, acl := range a {
+		if !in[*acl] {
+			out = append(out, acl)
+		}
+	}
+	return out
+}
+
+// ListAll returns the ACL binding held by Kafka for each of the ACLs, as
+// returned by List, or nil for each ACL that Kafka does not hold.
+func ListAll(ctx context.Context, cl adminClient, acls []*AccessControlList) ([]*AccessControlList, error) {
+	observed := make([]*AccessControlList, len(acls))
+	for i, a := range acls {
+		ae, err := List(ctx, cl, a)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", Describe(a), err)
+		}
+		observed[i] = ae
+	}
+	return observed, nil
+}
+
+// ListPrincipal returns the ACL bindings of the principal that an
+// AccessControlList can manage, sorted as by Sort.
+func ListPrincipal(ctx context.Context, cl adminClient, principal string) ([]*AccessControlList, error) {
+	b := kadm.NewACLs().
+		AnyResource().
+		Allow(principal).AllowHosts().
+		Deny(principal).DenyHosts().
+		Operations(kadm.OpAny).
+		ResourcePatternType(kadm.ACLPatternAny)
+
+	resp, err := cl.DescribeACLs(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("describe ACLs failed: %w", err)
+	}
+
+	var acls []*AccessControlList
+	for _, r := range resp {
+		if r.Err != nil {
+			return nil, fmt.Errorf("describe ACLs failed: %w", r.Err)
+		}
+		for _, d := range r.Described {
+			if a, ok := FromDescribed(d); ok {
+				acls = append(acls, a)
+			}
+		}
+	}
+	Sort(acls)
+	return acls, nil
+}
+
+// Matches returns true if the filter matches the binding. The Any resource
+// type, operation, permission and pattern type of a filter match every value,
+// and the Match pattern type matches every resource name and pattern type.
+func Matches(filter, binding *AccessControlList) bool {
+	anyOr := func(f, b string) bool {
+		return f == b || f == "Any"
+	}
+	match := filter.ResourcePatternTypeFilter == "Match"
+	return anyOr(filter.ResourceType, binding.ResourceType) &&
+		(match || filter.ResourceName == binding.ResourceName) &&
+		(match || anyOr(filter.ResourcePatternTypeFilter, binding.ResourcePatternTypeFilter)) &&
+		filter.ResourcePrincipal == binding.ResourcePrincipal &&
+		filter.ResourceHost == binding.ResourceHost &&
+		anyOr(filter.ResourceOperation, binding.ResourceOperation) &&
+		anyOr(filter.ResourcePermissionType, binding.ResourcePermissionType)
+}
+
+// Undeclared returns the bindings that none of the declared ACLs match.
+func Undeclared(bindings, declared []*AccessControlList) []*AccessControlList {
+	var out []*AccessControlList
+	for _, b := range bindings {
+		if !slices.ContainsFunc(declared, func(d *AccessControlList) bool { return Matches(d, b) }) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// Deletable returns the bindings that can be deleted without deleting a
+// declared binding: the bindings that no declared ACL matches and that do not
+// match a declared ACL themselves, as deleting an ACL deletes every binding it
+// matches.
+func Deletable(bindings, declared []*AccessControlList) []*AccessControlList {
+	var out []*AccessControlList
+	for _, b := range bindings {
+		if !slices.ContainsFunc(declared, func(d *AccessControlList) bool { return Matches(d, b) || Matches(b, d) }) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// Describe returns a human readable description of an ACL binding.
+func Describe(a *AccessControlList) string {
+	return fmt.Sprintf("%s %s on %s %q (%s) for %s from host %s",
+		a.ResourcePermissionType, a.ResourceOperation, a.ResourceType, a.ResourceName, a.ResourcePatternTypeFilter, a.ResourcePrincipal, a.ResourceHost)
+}
+
+// ChangedBindingFields returns the names of the fields that differ between
+// two sets of ACLs. The fields of single ACLs are named as by ChangedFields.
+func ChangedBindingFields(a, b []*AccessControlList) []string {
+	if len(a) == 1 && len(b) == 1 {
+		return ChangedFields(*a[0], *b[0])
+	}
+	var changed []string
+	for _, f := range []struct {
+		name  string
+		value func(*AccessControlList) string
+	}{
+		{name: "resources", value: func(a *AccessControlList) string { return a.ResourceType + "/" + a.ResourceName }},
+		{name: "resourcePrincipal", value: func(a *AccessControlList) string { return a.ResourcePrincipal }},
+		{name: "resourceHosts", value: func(a *AccessControlList) string { return a.ResourceHost }},
+		{name: "resourceOperations", value: func(a *AccessControlList) string { return a.ResourceOperation }},
+		{name: "resourcePermissionType", value: func(a *AccessControlList) string { return a.ResourcePermissionType }},
+		{name: "resourcePatternTypeFilter", value: func(a *AccessControlList) string { return a.ResourcePatternTypeFilter }},
+	} {
+		if !slices.Equal(values(a, f.value), values(b, f.value)) {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed
+}
+
+// values returns the sorted distinct values of a field of the ACLs.
+func values(acls []*AccessControlList, value func(*AccessControlList) string) []string {
+	var vs []string
+	for _, a := range acls {
+		vs = appendUnique(vs, value(a))
+	}
+	sort.Strings(vs)
+	return vs
+}
+
 // IsUpToDate returns true if the supplied Kubernetes resource differs from the
 // supplied Kafka ACLs.
 func IsUpToDate(in *v1alpha1.AccessControlListParameters, observed *AccessControlList) bool {
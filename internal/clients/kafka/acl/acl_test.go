@@ -2,19 +2,24 @@ package acl
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
 	"k8s.io/apimachinery/pkg/util/json"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
 	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
 )
 
@@ -26,13 +31,22 @@ type fakeACLAdmin struct {
 	describeErr     error
 	deleteResults   kadm.DeleteACLsResults
 	deleteErr       error
+	response        kmsg.Response
+	requestErr      error
+
+	// builder is the last ACLBuilder passed to any method.
+	builder *kadm.ACLBuilder
+	// request is the last raw request.
+	request kmsg.Request
 }
 
-func (f *fakeACLAdmin) CreateACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.CreateACLsResults, error) {
+func (f *fakeACLAdmin) CreateACLs(_ context.Context, b *kadm.ACLBuilder) (kadm.CreateACLsResults, error) {
+	f.builder = b
 	return f.createResults, f.createErr
 }
 
-func (f *fakeACLAdmin) DescribeACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.DescribeACLsResults, error) {
+func (f *fakeACLAdmin) DescribeACLs(_ context.Context, b *kadm.ACLBuilder) (kadm.DescribeACLsResults, error) {
+	f.builder = b
 	return f.describeResults, f.describeErr
 }
 
@@ -40,6 +54,11 @@ func (f *fakeACLAdmin) DeleteACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.D
 	return f.deleteResults, f.deleteErr
 }
 
+func (f *fakeACLAdmin) Request(_ context.Context, r kmsg.Request) (kmsg.Response, error) {
+	f.request = r
+	return f.response, f.requestErr
+}
+
 var dataTesting = []byte(os.Getenv("KAFKA_CONFIG"))
 
 var baseACL = AccessControlList{
@@ -483,6 +502,9 @@ func TestList(t *testing.T) {
 	}
 
 	// Verify all fields that feed into status.atProvider
+	if got.ResourceName != testACL.ResourceName {
+		t.Errorf("ResourceName = %q, want %q", got.ResourceName, testACL.ResourceName)
+	}
 	if got.ResourceType != testACL.ResourceType {
 		t.Errorf("ResourceType = %q, want %q", got.ResourceType, testACL.ResourceType)
 	}
@@ -555,6 +577,283 @@ func TestListEmptyResponse(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+func TestBuildACLBuilderPermissionType(t *testing.T) {
+	cases := map[string]struct {
+		permission string
+		want       *kadm.ACLBuilder
+	}{
+		"Allow": {
+			permission: kafka.ACLPermissionTypeAllow,
+			want: new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("*").
+				Operations(kadm.OpAlterConfigs).ResourcePatternType(kadm.ACLPatternLiteral).Topics(kafka.TestACLName),
+		},
+		"Deny": {
+			permission: kafka.ACLPermissionTypeDeny,
+			want: new(kadm.ACLBuilder).Deny(kafka.TestACLPrincipal).DenyHosts("*").
+				Operations(kadm.OpAlterConfigs).ResourcePatternType(kadm.ACLPatternLiteral).Topics(kafka.TestACLName),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in := baseACL
+			in.ResourcePermissionType = tc.permission
+			got, err := buildACLBuilder(&in)
+			require.NoError(t, err)
+			assert.True(t, reflect.DeepEqual(tc.want, got), "buildACLBuilder(...): unexpected builder for %s", tc.permission)
+		})
+	}
+}
+
+func TestCreateDeny(t *testing.T) {
+	t.Parallel()
+	cl := &fakeACLAdmin{
+		createResults: kadm.CreateACLsResults{
+			{Principal: kafka.TestACLPrincipal, Permission: kmsg.ACLPermissionTypeDeny},
+		},
+	}
+	in := baseACL
+	in.ResourcePermissionType = kafka.ACLPermissionTypeDeny
+	require.NoError(t, Create(context.Background(), cl, &in))
+	want := new(kadm.ACLBuilder).Deny(kafka.TestACLPrincipal).DenyHosts("*").
+		Operations(kadm.OpAlterConfigs).ResourcePatternType(kadm.ACLPatternLiteral).Topics(kafka.TestACLName)
+	assert.True(t, reflect.DeepEqual(want, cl.builder), "Create(...): expected a deny builder")
+}
+
+func TestListPermissionType(t *testing.T) {
+	described := func(p kmsg.ACLPermissionType) kadm.DescribedACL {
+		return kadm.DescribedACL{
+			Principal:  kafka.TestACLPrincipal,
+			Host:       "*",
+			Type:       kmsg.ACLResourceTypeTopic,
+			Name:       kafka.TestACLName,
+			Pattern:    kadm.ACLPatternLiteral,
+			Operation:  kadm.OpAlterConfigs,
+			Permission: p,
+		}
+	}
+	cases := map[string]struct {
+		permission string
+		described  kadm.DescribedACLs
+		want       string
+		wantNil    bool
+	}{
+		"DenyFound": {
+			permission: kafka.ACLPermissionTypeDeny,
+			described:  kadm.DescribedACLs{described(kmsg.ACLPermissionTypeDeny)},
+			want:       kafka.ACLPermissionTypeDeny,
+		},
+		"AllowFound": {
+			permission: kafka.ACLPermissionTypeAllow,
+			described:  kadm.DescribedACLs{described(kmsg.ACLPermissionTypeAllow)},
+			want:       kafka.ACLPermissionTypeAllow,
+		},
+		"DenyRequestedOnlyAllowReturned": {
+			permission: kafka.ACLPermissionTypeDeny,
+			described:  kadm.DescribedACLs{described(kmsg.ACLPermissionTypeAllow)},
+			wantNil:    true,
+		},
+		"DenyAmongMixed": {
+			permission: kafka.ACLPermissionTypeDeny,
+			described:  kadm.DescribedACLs{described(kmsg.ACLPermissionTypeAllow), described(kmsg.ACLPermissionTypeDeny)},
+			want:       kafka.ACLPermissionTypeDeny,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeACLAdmin{describeResults: kadm.DescribeACLsResults{{Described: tc.described}}}
+			in := baseACL
+			in.ResourcePermissionType = tc.permission
+			got, err := List(context.Background(), cl, &in)
+			require.NoError(t, err)
+			if tc.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tc.want, got.ResourcePermissionType)
+		})
+	}
+}
+
+func TestFromDescribed(t *testing.T) {
+	t.Parallel()
+
+	described := kadm.DescribedACL{
+		Principal:  kafka.TestACLPrincipal,
+		Host:       "*",
+		Type:       kmsg.ACLResourceTypeTransactionalId,
+		Name:       kafka.TestACLName,
+		Pattern:    kadm.ACLPatternPrefixed,
+		Operation:  kadm.OpIdempotentWrite,
+		Permission: kmsg.ACLPermissionTypeDeny,
+	}
+
+	cases := map[string]struct {
+		modify func(d *kadm.DescribedACL)
+		want   *AccessControlList
+	}{
+		"Managed": {
+			want: &AccessControlList{
+				ResourceName:              kafka.TestACLName,
+				ResourceType:              kafka.ACLResourceTypeTransactionalID,
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHost:              "*",
+				ResourceOperation:         "IdempotentWrite",
+				ResourcePermissionType:    kafka.ACLPermissionTypeDeny,
+				ResourcePatternTypeFilter: "Prefixed",
+			},
+		},
+		"DelegationToken": {
+			modify: func(d *kadm.DescribedACL) {
+				d.Type = kmsg.ACLResourceTypeDelegationToken
+				d.Operation = kmsg.ACLOperationDescribe
+			},
+			want: &AccessControlList{
+				ResourceName:              kafka.TestACLName,
+				ResourceType:              kafka.ACLResourceTypeDelegationToken,
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHost:              "*",
+				ResourceOperation:         kafka.ACLOperationDescribe,
+				ResourcePermissionType:    kafka.ACLPermissionTypeDeny,
+				ResourcePatternTypeFilter: "Prefixed",
+			},
+		},
+		"User": {
+			modify: func(d *kadm.DescribedACL) {
+				d.Type = kmsg.ACLResourceTypeUser
+				d.Operation = kmsg.ACLOperationDescribeTokens
+			},
+			want: &AccessControlList{
+				ResourceName:              kafka.TestACLName,
+				ResourceType:              kafka.ACLResourceTypeUser,
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHost:              "*",
+				ResourceOperation:         kafka.ACLOperationDescribeTokens,
+				ResourcePermissionType:    kafka.ACLPermissionTypeDeny,
+				ResourcePatternTypeFilter: "Prefixed",
+			},
+		},
+		"UnmanagedResourceType": {
+			modify: func(d *kadm.DescribedACL) { d.Type = kmsg.ACLResourceTypeUnknown },
+		},
+		"UnmanagedOperation": {
+			modify: func(d *kadm.DescribedACL) { d.Operation = kmsg.ACLOperationUnknown },
+		},
+		"UnmanagedPatternType": {
+			modify: func(d *kadm.DescribedACL) { d.Pattern = kadm.ACLPatternMatch },
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := described
+			if tc.modify != nil {
+				tc.modify(&d)
+			}
+			got, ok := FromDescribed(d)
+			assert.Equal(t, tc.want != nil, ok)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FromDescribed(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListObservedBinding(t *testing.T) {
+	t.Parallel()
+
+	binding := func(host string, op kadm.ACLOperation) kadm.DescribedACL {
+		return kadm.DescribedACL{
+			Principal:  kafka.TestACLPrincipal,
+			Host:       host,
+			Type:       kmsg.ACLResourceTypeTopic,
+			Name:       kafka.TestACLName,
+			Pattern:    kadm.ACLPatternLiteral,
+			Operation:  op,
+			Permission: kmsg.ACLPermissionTypeAllow,
+		}
+	}
+	anyOp := baseACL
+	anyOp.ResourceOperation = "Any"
+
+	cases := map[string]struct {
+		in        AccessControlList
+		described kadm.DescribedACLs
+		want      *AccessControlList
+		wantErr   error
+	}{
+		"ExactAmongSeveral": {
+			in:        baseACL,
+			described: kadm.DescribedACLs{binding("10.0.0.1", kadm.OpAlterConfigs), binding("*", kadm.OpAlterConfigs)},
+			want:      &baseACL,
+		},
+		"SingleDifferentBinding": {
+			in:        anyOp,
+			described: kadm.DescribedACLs{binding("*", kadm.OpRead)},
+			want: &AccessControlList{
+				ResourceName:              kafka.TestACLName,
+				ResourceType:              kafka.ACLResourceTypeTopic,
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHost:              "*",
+				ResourceOperation:         kafka.ACLOperationRead,
+				ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+				ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+			},
+		},
+		"UnmanagedOperationKeptInKafkaNotation": {
+			in:        anyOp,
+			described: kadm.DescribedACLs{binding("*", kmsg.ACLOperationUnknown)},
+			want: &AccessControlList{
+				ResourceName:              kafka.TestACLName,
+				ResourceType:              kafka.ACLResourceTypeTopic,
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHost:              "*",
+				ResourceOperation:         kmsg.ACLOperationUnknown.String(),
+				ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+				ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+			},
+		},
+		"Ambiguous": {
+			in:        anyOp,
+			described: kadm.DescribedACLs{binding("*", kadm.OpRead), binding("*", kadm.OpWrite)},
+			wantErr:   ErrAmbiguousACL,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeACLAdmin{describeResults: kadm.DescribeACLsResults{{Described: tc.described}}}
+			got, err := List(context.Background(), cl, &tc.in)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("List(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	t.Parallel()
+
+	observed := baseACL
+	observed.ResourceHost = "10.0.0.1"
+	observed.ResourceOperation = kafka.ACLOperationRead
+
+	assert.Empty(t, Drift(baseACL, baseACL))
+	want := []string{
+		`resourceHost is "10.0.0.1" instead of "*"`,
+		`resourceOperation is "Read" instead of "AlterConfigs"`,
+	}
+	if diff := cmp.Diff(want, Drift(baseACL, observed)); diff != "" {
+		t.Errorf("Drift(...): -want, +got:\n%s", diff)
+	}
+	assert.Equal(t, []string{"resourceHost", "resourceOperation"}, ChangedFields(baseACL, observed))
+}
+
 func TestDeleteBrokerError(t *testing.T) {
 	t.Parallel()
 	cl := &fakeACLAdmin{
@@ -596,3 +895,584 @@ func TestListAtProviderNotFound(t *testing.T) {
 		t.Errorf("List() = %v, expected nil for non-existent ACL", got)
 	}
 }
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	binding := func(resourceType, name, host, operation string) *AccessControlList {
+		return &AccessControlList{
+			ResourceName:              name,
+			ResourceType:              resourceType,
+			ResourcePrincipal:         kafka.TestACLPrincipal,
+			ResourceHost:              host,
+			ResourceOperation:         operation,
+			ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+			ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+		}
+	}
+
+	cases := map[string]struct {
+		params v1alpha2.AccessControlListParameters
+		want   []*AccessControlList
+	}{
+		"Single": {
+			params: v1alpha2.AccessControlListParameters{
+				ResourceName:              "orders",
+				ResourceType:              kafka.ACLResourceTypeTopic,
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHost:              "*",
+				ResourceOperation:         kafka.ACLOperationRead,
+				ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+				ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+			},
+			want: []*AccessControlList{binding(kafka.ACLResourceTypeTopic, "orders", "*", kafka.ACLOperationRead)},
+		},
+		"Combinations": {
+			params: v1alpha2.AccessControlListParameters{
+				ResourceName:              "orders",
+				ResourceType:              kafka.ACLResourceTypeTopic,
+				Resources:                 []v1alpha2.AccessControlListResource{{Type: kafka.ACLResourceTypeGroup, Name: "orders"}, {Type: kafka.ACLResourceTypeTopic, Name: "orders"}},
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceHosts:             []string{"10.0.0.1", "10.0.0.2"},
+				ResourceOperation:         kafka.ACLOperationRead,
+				ResourceOperations:        []string{kafka.ACLOperationDescribe, kafka.ACLOperationRead},
+				ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+				ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+			},
+			want: []*AccessControlList{
+				binding(kafka.ACLResourceTypeGroup, "orders", "10.0.0.1", kafka.ACLOperationDescribe),
+				binding(kafka.ACLResourceTypeGroup, "orders", "10.0.0.1", kafka.ACLOperationRead),
+				binding(kafka.ACLResourceTypeGroup, "orders", "10.0.0.2", kafka.ACLOperationDescribe),
+				binding(kafka.ACLResourceTypeGroup, "orders", "10.0.0.2", kafka.ACLOperationRead),
+				binding(kafka.ACLResourceTypeTopic, "orders", "10.0.0.1", kafka.ACLOperationDescribe),
+				binding(kafka.ACLResourceTypeTopic, "orders", "10.0.0.1", kafka.ACLOperationRead),
+				binding(kafka.ACLResourceTypeTopic, "orders", "10.0.0.2", kafka.ACLOperationDescribe),
+				binding(kafka.ACLResourceTypeTopic, "orders", "10.0.0.2", kafka.ACLOperationRead),
+			},
+		},
+		"DefaultHost": {
+			params: v1alpha2.AccessControlListParameters{
+				Resources:                 []v1alpha2.AccessControlListResource{{Type: kafka.ACLResourceTypeTopic, Name: "orders"}},
+				ResourcePrincipal:         kafka.TestACLPrincipal,
+				ResourceOperations:        []string{kafka.ACLOperationWrite},
+				ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+				ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+			},
+			want: []*AccessControlList{binding(kafka.ACLResourceTypeTopic, "orders", "*", kafka.ACLOperationWrite)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.want, Expand(&tc.params)); diff != "" {
+				t.Errorf("Expand(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateExpanded(t *testing.T) {
+	t.Parallel()
+
+	cl := &fakeACLAdmin{
+		createResults: kadm.CreateACLsResults{
+			{Principal: kafka.TestACLPrincipal, Permission: kmsg.ACLPermissionTypeAllow},
+		},
+	}
+	acls := Expand(&v1alpha2.AccessControlListParameters{
+		Resources: []v1alpha2.AccessControlListResource{
+			{Type: kafka.ACLResourceTypeTopic, Name: "orders"},
+			{Type: kafka.ACLResourceTypeGroup, Name: "orders"},
+		},
+		ResourcePrincipal:         kafka.TestACLPrincipal,
+		ResourceHosts:             []string{"10.0.0.1", "10.0.0.2"},
+		ResourceOperations:        []string{kafka.ACLOperationRead, kafka.ACLOperationDescribe},
+		ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+		ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+	})
+	require.NoError(t, Create(context.Background(), cl, acls...))
+	want := new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("10.0.0.1", "10.0.0.2").
+		Operations(kadm.OpDescribe, kadm.OpRead).ResourcePatternType(kadm.ACLPatternLiteral).
+		Groups("orders").Topics("orders")
+	assert.True(t, reflect.DeepEqual(want, cl.builder), "Create(...): expected a single builder of all combinations")
+}
+
+func TestCreateDelegationToken(t *testing.T) {
+	t.Parallel()
+
+	cl := &fakeACLAdmin{
+		createResults: kadm.CreateACLsResults{
+			{Principal: kafka.TestACLPrincipal, Permission: kmsg.ACLPermissionTypeAllow},
+		},
+	}
+	token := baseACL
+	token.ResourceType = kafka.ACLResourceTypeDelegationToken
+	token.ResourceOperation = kafka.ACLOperationDescribe
+	require.NoError(t, Create(context.Background(), cl, &token))
+	want := new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("*").
+		Operations(kadm.OpDescribe).ResourcePatternType(kadm.ACLPatternLiteral).
+		DelegationTokens(kafka.TestACLName)
+	assert.True(t, reflect.DeepEqual(want, cl.builder), "Create(...): expected a builder of the delegation token")
+}
+
+func userACL() AccessControlList {
+	user := baseACL
+	user.ResourceName = "User:bob"
+	user.ResourceType = kafka.ACLResourceTypeUser
+	user.ResourceOperation = kafka.ACLOperationCreateTokens
+	return user
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Parallel()
+
+	user := userACL()
+	cases := map[string]struct {
+		reason   string
+		response kmsg.Response
+		wantErr  error
+	}{
+		"Created": {
+			reason:   "Create should create the ACL of a User resource by a raw request",
+			response: &kmsg.CreateACLsResponse{Results: []kmsg.CreateACLsResponseResult{{}}},
+		},
+		"BrokerError": {
+			reason:   "Create should return the error of Kafka",
+			response: &kmsg.CreateACLsResponse{Results: []kmsg.CreateACLsResponseResult{{ErrorCode: kerr.ClusterAuthorizationFailed.Code}}},
+			wantErr:  fmt.Errorf("create ACL failed: %w", kerr.ClusterAuthorizationFailed),
+		},
+		"EmptyResponse": {
+			reason:   "Create should fail if Kafka returns no result for the ACL",
+			response: &kmsg.CreateACLsResponse{},
+			wantErr:  errors.New("no create response for acl"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeACLAdmin{response: tc.response}
+			err := Create(context.Background(), cl, &user)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			c := kmsg.NewCreateACLsRequestCreation()
+			c.ResourceType = kmsg.ACLResourceTypeUser
+			c.ResourceName = "User:bob"
+			c.ResourcePatternType = kmsg.ACLResourcePatternTypeLiteral
+			c.Principal = kafka.TestACLPrincipal
+			c.Host = "*"
+			c.Operation = kmsg.ACLOperationCreateTokens
+			c.PermissionType = kmsg.ACLPermissionTypeAllow
+			want := kmsg.NewPtrCreateACLsRequest()
+			want.Creations = []kmsg.CreateACLsRequestCreation{c}
+			assert.Equal(t, want, cl.request, "\n%s\nCreate(...): unexpected request", tc.reason)
+			assert.Nil(t, cl.builder, "Create(...): expected no builder for a User resource")
+		})
+	}
+}
+
+func TestCreateUserAndTopic(t *testing.T) {
+	t.Parallel()
+
+	cl := &fakeACLAdmin{
+		createResults: kadm.CreateACLsResults{
+			{Principal: kafka.TestACLPrincipal, Permission: kmsg.ACLPermissionTypeAllow},
+		},
+		response: &kmsg.CreateACLsResponse{Results: []kmsg.CreateACLsResponseResult{{}}},
+	}
+	user := userACL()
+	require.NoError(t, Create(context.Background(), cl, &user, &baseACL))
+	req, ok := cl.request.(*kmsg.CreateACLsRequest)
+	require.True(t, ok, "Create(...): expected a raw request for the User resource")
+	assert.Len(t, req.Creations, 1)
+	want := new(kadm.ACLBuilder).Allow(kafka.TestACLPrincipal).AllowHosts("*").
+		Operations(kadm.OpAlterConfigs).ResourcePatternType(kadm.ACLPatternLiteral).
+		Topics(kafka.TestACLName)
+	assert.True(t, reflect.DeepEqual(want, cl.builder), "Create(...): expected a builder of the topic")
+}
+
+func TestListUser(t *testing.T) {
+	t.Parallel()
+
+	user := userACL()
+	otherHost := user
+	otherHost.ResourceHost = "10.0.0.1"
+	resource := func(acls ...kmsg.DescribeACLsResponseResourceACL) []kmsg.DescribeACLsResponseResource {
+		return []kmsg.DescribeACLsResponseResource{{
+			ResourceType:        kmsg.ACLResourceTypeUser,
+			ResourceName:        "User:bob",
+			ResourcePatternType: kmsg.ACLResourcePatternTypeLiteral,
+			ACLs:                acls,
+		}}
+	}
+	binding := func(host string) kmsg.DescribeACLsResponseResourceACL {
+		return kmsg.DescribeACLsResponseResourceACL{
+			Principal:      kafka.TestACLPrincipal,
+			Host:           host,
+			Operation:      kmsg.ACLOperationCreateTokens,
+			PermissionType: kmsg.ACLPermissionTypeAllow,
+		}
+	}
+
+	cases := map[string]struct {
+		reason   string
+		response kmsg.Response
+		want     *AccessControlList
+		wantErr  error
+	}{
+		"Found": {
+			reason:   "List should return the ACL of a User resource described by a raw request",
+			response: &kmsg.DescribeACLsResponse{Resources: resource(binding("*"))},
+			want:     &user,
+		},
+		"FoundDrifted": {
+			reason:   "List should return the only ACL of a User resource that matches",
+			response: &kmsg.DescribeACLsResponse{Resources: resource(binding("10.0.0.1"))},
+			want:     &otherHost,
+		},
+		"NotFound": {
+			reason:   "List should return nil if Kafka holds no matching ACL",
+			response: &kmsg.DescribeACLsResponse{},
+		},
+		"BrokerError": {
+			reason:   "List should return the error of Kafka",
+			response: &kmsg.DescribeACLsResponse{ErrorCode: kerr.ClusterAuthorizationFailed.Code},
+			wantErr:  fmt.Errorf("describe ACLs failed: %w", kerr.ClusterAuthorizationFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeACLAdmin{response: tc.response}
+			got, err := List(context.Background(), cl, &user)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nList(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nList(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			req, ok := cl.request.(*kmsg.DescribeACLsRequest)
+			require.True(t, ok, "List(...): expected a raw describe request")
+			assert.Equal(t, kmsg.ACLResourceTypeUser, req.ResourceType)
+			assert.Equal(t, "User:bob", *req.ResourceName)
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	user := userACL()
+	cases := map[string]struct {
+		reason   string
+		response kmsg.Response
+		wantErr  error
+	}{
+		"Deleted": {
+			reason:   "Delete should delete the ACL of a User resource by a raw request",
+			response: &kmsg.DeleteACLsResponse{Results: []kmsg.DeleteACLsResponseResult{{}}},
+		},
+		"BrokerError": {
+			reason:   "Delete should return the error of Kafka",
+			response: &kmsg.DeleteACLsResponse{Results: []kmsg.DeleteACLsResponseResult{{ErrorCode: kerr.ClusterAuthorizationFailed.Code}}},
+			wantErr:  fmt.Errorf("delete ACL failed: %w", kerr.ClusterAuthorizationFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeACLAdmin{response: tc.response}
+			err := Delete(context.Background(), cl, &user)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			f := kmsg.NewDeleteACLsRequestFilter()
+			f.ResourceType = kmsg.ACLResourceTypeUser
+			f.ResourceName = &user.ResourceName
+			f.ResourcePatternType = kmsg.ACLResourcePatternTypeLiteral
+			f.Principal = &user.ResourcePrincipal
+			f.Host = &user.ResourceHost
+			f.Operation = kmsg.ACLOperationCreateTokens
+			f.PermissionType = kmsg.ACLPermissionTypeAllow
+			want := kmsg.NewPtrDeleteACLsRequest()
+			want.Filters = []kmsg.DeleteACLsRequestFilter{f}
+			assert.Equal(t, want, cl.request, "\n%s\nDelete(...): unexpected request", tc.reason)
+		})
+	}
+}
+
+func TestValidateOperation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		resourceType string
+		operation    string
+		wantErr      bool
+	}{
+		"TopicRead":            {resourceType: kafka.ACLResourceTypeTopic, operation: kafka.ACLOperationRead},
+		"TopicIdempotentWrite": {resourceType: kafka.ACLResourceTypeTopic, operation: kafka.ACLOperationIdempotentWrite, wantErr: true},
+		"GroupWrite":           {resourceType: kafka.ACLResourceTypeGroup, operation: kafka.ACLOperationWrite, wantErr: true},
+		"ClusterAll":           {resourceType: kafka.ACLResourceTypeCluster, operation: kafka.ACLOperationAll},
+		"TokenDescribe":        {resourceType: kafka.ACLResourceTypeDelegationToken, operation: kafka.ACLOperationDescribe},
+		"TokenRead":            {resourceType: kafka.ACLResourceTypeDelegationToken, operation: kafka.ACLOperationRead, wantErr: true},
+		"UserCreateTokens":     {resourceType: kafka.ACLResourceTypeUser, operation: kafka.ACLOperationCreateTokens},
+		"TopicCreateTokens":    {resourceType: kafka.ACLResourceTypeTopic, operation: kafka.ACLOperationCreateTokens, wantErr: true},
+		"UserAnyFilter":        {resourceType: kafka.ACLResourceTypeUser, operation: kafka.ACLOperationAny},
+		"AnyResourceFilter":    {resourceType: kafka.ACLResourceTypeAny, operation: kafka.ACLOperationDescribeTokens},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validateOperation(tc.resourceType, tc.operation)
+			assert.Equal(t, tc.wantErr, err != nil, "validateOperation(...): %v", err)
+		})
+	}
+}
+
+func TestBuildACLBuilderInvalid(t *testing.T) {
+	t.Parallel()
+
+	write := baseACL
+	write.ResourceOperation = kafka.ACLOperationWrite
+	otherHost := baseACL
+	otherHost.ResourceHost = "10.0.0.1"
+	deny := baseACL
+	deny.ResourcePermissionType = kafka.ACLPermissionTypeDeny
+	groupWrite := write
+	groupWrite.ResourceType = kafka.ACLResourceTypeGroup
+	user := baseACL
+	user.ResourceType = kafka.ACLResourceTypeUser
+	user.ResourceOperation = kafka.ACLOperationCreateTokens
+
+	cases := map[string]struct {
+		acls    []*AccessControlList
+		wantErr string
+	}{
+		"InvalidOperation": {
+			acls:    []*AccessControlList{&groupWrite},
+			wantErr: errInvalidOperation + ": Write on Group",
+		},
+		"UnsupportedResourceType": {
+			acls:    []*AccessControlList{&user},
+			wantErr: errUnsupportedType + ": User",
+		},
+		"NoBindings": {
+			wantErr: errNoBindings,
+		},
+		"MixedPermission": {
+			acls:    []*AccessControlList{&baseACL, &deny},
+			wantErr: errMixedBindings,
+		},
+		"NotAllCombinations": {
+			acls:    []*AccessControlList{&baseACL, &write, &otherHost},
+			wantErr: errNotCombinations,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := buildACLBuilder(tc.acls...)
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestConvertAllJSON(t *testing.T) {
+	t.Parallel()
+
+	write := baseACL
+	write.ResourceOperation = kafka.ACLOperationWrite
+
+	cases := map[string][]*AccessControlList{
+		"Single":   {&baseACL},
+		"Multiple": {&baseACL, &write},
+	}
+	for name, acls := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			j, err := ConvertAllToJSON(acls)
+			require.NoError(t, err)
+			if len(acls) == 1 {
+				single, err := ConvertToJSON(acls[0])
+				require.NoError(t, err)
+				assert.Equal(t, single, j, "a single ACL should keep the external name of ConvertToJSON")
+			}
+			got, err := ConvertAllFromJSON(j)
+			require.NoError(t, err)
+			if diff := cmp.Diff(acls, got); diff != "" {
+				t.Errorf("ConvertAllFromJSON(ConvertAllToJSON(...)): -want, +got:\n%s", diff)
+			}
+		})
+	}
+
+	_, err := ConvertAllFromJSON("[]")
+	require.Error(t, err)
+}
+
+func TestChangedBindingFields(t *testing.T) {
+	t.Parallel()
+
+	write := baseACL
+	write.ResourceOperation = kafka.ACLOperationWrite
+	otherHost := baseACL
+	otherHost.ResourceHost = "10.0.0.1"
+	otherHostWrite := write
+	otherHostWrite.ResourceHost = "10.0.0.1"
+
+	current := []*AccessControlList{&baseACL, &write}
+	desired := []*AccessControlList{&otherHost, &otherHostWrite}
+
+	assert.Equal(t, []string{"resourceHosts"}, ChangedBindingFields(current, desired))
+	assert.Equal(t, []string{"resourceHost"}, ChangedBindingFields(current[:1], desired[:1]))
+	assert.Equal(t, []string{"resourceOperations"}, ChangedBindingFields(current[:1], current))
+	assert.True(t, SameBindings(current, []*AccessControlList{&write, &baseACL}))
+	assert.Equal(t, []*AccessControlList{&write}, Subtract(current, []*AccessControlList{&baseACL}))
+}
+
+func TestListPrincipal(t *testing.T) {
+	t.Parallel()
+
+	read := kadm.DescribedACL{
+		Principal:  kafka.TestACLPrincipal,
+		Host:       "*",
+		Type:       kmsg.ACLResourceTypeTopic,
+		Name:       "orders",
+		Pattern:    kadm.ACLPatternLiteral,
+		Operation:  kadm.OpRead,
+		Permission: kmsg.ACLPermissionTypeAllow,
+	}
+	user := read
+	user.Type = kmsg.ACLResourceTypeUser
+	user.Operation = kmsg.ACLOperationCreateTokens
+	unknown := read
+	unknown.Operation = kmsg.ACLOperationUnknown
+
+	cl := &fakeACLAdmin{describeResults: kadm.DescribeACLsResults{
+		{Permission: kmsg.ACLPermissionTypeAllow, Described: kadm.DescribedACLs{user, unknown, read}},
+		{Permission: kmsg.ACLPermissionTypeDeny},
+	}}
+	got, err := ListPrincipal(context.Background(), cl, kafka.TestACLPrincipal)
+	require.NoError(t, err)
+	want := []*AccessControlList{
+		{
+			ResourceName:              "orders",
+			ResourceType:              kafka.ACLResourceTypeTopic,
+			ResourcePrincipal:         kafka.TestACLPrincipal,
+			ResourceHost:              "*",
+			ResourceOperation:         kafka.ACLOperationRead,
+			ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+			ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+		},
+		{
+			ResourceName:              "orders",
+			ResourceType:              kafka.ACLResourceTypeUser,
+			ResourcePrincipal:         kafka.TestACLPrincipal,
+			ResourceHost:              "*",
+			ResourceOperation:         kafka.ACLOperationCreateTokens,
+			ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+			ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListPrincipal(...): -want, +got:\n%s", diff)
+	}
+	require.NoError(t, cl.builder.ValidateDescribe())
+}
+
+func TestUndeclared(t *testing.T) {
+	t.Parallel()
+
+	write := baseACL
+	write.ResourceOperation = kafka.ACLOperationWrite
+	otherTopic := baseACL
+	otherTopic.ResourceName = "other"
+	anyOperation := baseACL
+	anyOperation.ResourceOperation = "Any"
+	match := otherTopic
+	match.ResourceName = "unrelated"
+	match.ResourcePatternTypeFilter = "Match"
+
+	bindings := []*AccessControlList{&baseACL, &write, &otherTopic}
+
+	cases := map[string]struct {
+		declared []*AccessControlList
+		want     []*AccessControlList
+	}{
+		"NoneDeclared": {
+			want: bindings,
+		},
+		"Exact": {
+			declared: []*AccessControlList{&baseACL},
+			want:     []*AccessControlList{&write, &otherTopic},
+		},
+		"AnyOperation": {
+			declared: []*AccessControlList{&anyOperation},
+			want:     []*AccessControlList{&otherTopic},
+		},
+		"MatchPattern": {
+			declared: []*AccessControlList{&match},
+			want:     []*AccessControlList{&write},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.want, Undeclared(bindings, tc.declared)); diff != "" {
+				t.Errorf("Undeclared(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeletable(t *testing.T) {
+	t.Parallel()
+
+	write := baseACL
+	write.ResourceOperation = kafka.ACLOperationWrite
+	otherTopic := baseACL
+	otherTopic.ResourceName = "other"
+	anyOperation := baseACL
+	anyOperation.ResourceOperation = "Any"
+
+	cases := map[string]struct {
+		bindings []*AccessControlList
+		declared []*AccessControlList
+		want     []*AccessControlList
+	}{
+		"NoneDeclared": {
+			bindings: []*AccessControlList{&baseACL, &write},
+			want:     []*AccessControlList{&baseACL, &write},
+		},
+		"Exact": {
+			bindings: []*AccessControlList{&baseACL, &write},
+			declared: []*AccessControlList{&baseACL},
+			want:     []*AccessControlList{&write},
+		},
+		"DeclaredFilter": {
+			bindings: []*AccessControlList{&baseACL, &write, &otherTopic},
+			declared: []*AccessControlList{&anyOperation},
+			want:     []*AccessControlList{&otherTopic},
+		},
+		"FilterMatchesDeclared": {
+			bindings: []*AccessControlList{&anyOperation, &otherTopic},
+			declared: []*AccessControlList{&write},
+			want:     []*AccessControlList{&otherTopic},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.want, Deletable(tc.bindings, tc.declared)); diff != "" {
+				t.Errorf("Deletable(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package brokerconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// AdminClient is the subset of kadm.Client methods used by this package.
+// *kadm.Client satisfies this interface without any changes to callers.
+type AdminClient interface {
+	DescribeBrokerConfigs(ctx context.Context, brokers ...int32) (kadm.ResourceConfigs, error)
+	AlterBrokerConfigs(ctx context.Context, configs []kadm.AlterConfig, brokers ...int32) (kadm.AlterConfigsResponses, error)
+}
+
+// BrokerConfig is the set of configs of a single broker, or of the
+// cluster-wide dynamic defaults if BrokerID is nil.
+type BrokerConfig struct {
+	BrokerID *int32
+	Configs  map[string]kadm.Config
+}
+
+const (
+	errCannotDescribeConfigs = "cannot describe broker configs"
+	errCannotAlterConfigs    = "cannot alter broker configs"
+	errNoDescribeResponse    = "no describe response for broker configs"
+	errNoAlterResponse       = "no alter response for broker configs"
+)
+
+// Get gets the configs of the broker from the Kafka side. Without a broker ID
+// only the cluster-wide dynamic defaults are returned.
+func Get(ctx context.Context, client AdminClient, brokerID *int32) (*BrokerConfig, error) {
+	rcs, err := client.DescribeBrokerConfigs(ctx, brokers(brokerID)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribeConfigs, err)
+	}
+	rc, err := rcs.On(resourceName(brokerID), nil)
+	if err != nil {
+		return nil, errors.New(errNoDescribeResponse)
+	}
+	if rc.Err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribeConfigs, rc.Err)
+	}
+
+	bc := &BrokerConfig{BrokerID: brokerID, Configs: make(map[string]kadm.Config, len(rc.Configs))}
+	for _, c := range rc.Configs {
+		bc.Configs[c.Key] = c
+	}
+	return bc, nil
+}
+
+// Alter incrementally alters the dynamic configs of the broker, or the
+// cluster-wide dynamic defaults, so that they match the desired configs.
+// Only keys whose dynamic value differs are changed, and keys with a nil
+// value are removed.
+func Alter(ctx context.Context, client AdminClient, desired map[string]*string, existing *BrokerConfig) error {
+	var changes []kadm.AlterConfig
+	for _, key := range sortedKeys(desired) {
+		value := desired[key]
+		current, set := existing.dynamicValue(key)
+		switch {
+		case value == nil && set:
+			changes = append(changes, kadm.AlterConfig{Op: kadm.DeleteConfig, Name: key})
+		case value != nil && (!set || current == nil || *current != *value):
+			changes = append(changes, kadm.AlterConfig{Op: kadm.SetConfig, Name: key, Value: value})
+		}
+	}
+	return alter(ctx, client, existing.BrokerID, changes)
+}
+
+// Delete removes the given keys from the dynamic configs of the broker, or
+// the cluster-wide dynamic defaults, so that they fall back to the next
+// config source.
+func Delete(ctx context.Context, client AdminClient, keys []string, existing *BrokerConfig) error {
+	var changes []kadm.AlterConfig
+	for _, key := range keys {
+		if _, set := existing.dynamicValue(key); set {
+			changes = append(changes, kadm.AlterConfig{Op: kadm.DeleteConfig, Name: key})
+		}
+	}
+	return alter(ctx, client, existing.BrokerID, changes)
+}
+
+func alter(ctx context.Context, client AdminClient, brokerID *int32, changes []kadm.AlterConfig) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	resp, err := client.AlterBrokerConfigs(ctx, changes, brokers(brokerID)...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotAlterConfigs, err)
+	}
+	r, err := resp.On(resourceName(brokerID), nil)
+	if err != nil {
+		return errors.New(errNoAlterResponse)
+	}
+	if r.Err != nil {
+		if r.ErrMessage != "" {
+			return fmt.Errorf("%s: %w: %s", errCannotAlterConfigs, r.Err, r.ErrMessage)
+		}
+		return fmt.Errorf("%s: %w", errCannotAlterConfigs, r.Err)
+	}
+	return nil
+}
+
+// ManagedKeys returns the keys of the desired configs that are currently set
+// at the dynamic config level that this BrokerConfig manages.
+func (bc *BrokerConfig) ManagedKeys(desired map[string]*string) []string {
+	var keys []string
+	for _, key := range sortedKeys(desired) {
+		if _, set := bc.dynamicValue(key); set {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// dynamicValue returns the value of the config at the dynamic config level
+// managed by this BrokerConfig, and whether it is set at that level.
+func (bc *BrokerConfig) dynamicValue(key string) (*string, bool) {
+	c, ok := bc.Configs[key]
+	if !ok {
+		return nil, false
+	}
+	source := bc.dynamicSource()
+	if c.Source == source {
+		return c.Value, true
+	}
+	for _, s := range c.Synonyms {
+		if s.Source == source {
+			return s.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (bc *BrokerConfig) dynamicSource() kmsg.ConfigSource {
+	if bc.BrokerID == nil {
+		return kmsg.ConfigSourceDynamicDefaultBrokerConfig
+	}
+	return kmsg.ConfigSourceDynamicBrokerConfig
+}
+
+// ToObservation returns the observed values of the desired config keys.
+// Keys that Kafka did not return are omitted.
+func (bc *BrokerConfig) ToObservation(desired map[string]*string) v1alpha1.BrokerConfigObservation {
+	o := v1alpha1.BrokerConfigObservation{}
+	for _, key := range sortedKeys(desired) {
+		c, ok := bc.Configs[key]
+		if !ok {
+			continue
+		}
+		o.Configs = append(o.Configs, v1alpha1.BrokerConfigValue{
+			Name:      c.Key,
+			Value:     c.Value,
+			Source:    c.Source.String(),
+			Sensitive: c.Sensitive,
+		})
+	}
+	return o
+}
+
+// IsUpToDate returns true if every desired config is set at the dynamic
+// config level managed by the BrokerConfig with the desired value, and every
+// config with a nil value is not. The values of sensitive configs are not
+// returned by Kafka, so they are only checked for presence.
+func IsUpToDate(in *v1alpha1.BrokerConfigParameters, observed *BrokerConfig) bool {
+	for key, value := range in.Config {
+		current, set := observed.dynamicValue(key)
+		if value == nil {
+			if set {
+				return false
+			}
+			continue
+		}
+		if !set {
+			return false
+		}
+		if observed.Configs[key].Sensitive {
+			continue
+		}
+		if current == nil || *current != *value {
+			return false
+		}
+	}
+	return true
+}
+
+func brokers(brokerID *int32) []int32 {
+	if brokerID == nil {
+		return nil
+	}
+	return []int32{*brokerID}
+}
+
+func resourceName(brokerID *int32) string {
+	if brokerID == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*brokerID))
+}
+
+func sortedKeys(m map[string]*string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,258 @@
+package brokerconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const testRetention = "log.retention.ms"
+
+// fakeBrokerConfigAdmin is an in-process implementation of AdminClient for
+// unit tests.
+type fakeBrokerConfigAdmin struct {
+	described   kadm.ResourceConfigs
+	describeErr error
+	alterErr    error
+
+	brokers []int32
+	altered []kadm.AlterConfig
+}
+
+func (f *fakeBrokerConfigAdmin) DescribeBrokerConfigs(_ context.Context, brokers ...int32) (kadm.ResourceConfigs, error) {
+	f.brokers = brokers
+	return f.described, f.describeErr
+}
+
+func (f *fakeBrokerConfigAdmin) AlterBrokerConfigs(_ context.Context, configs []kadm.AlterConfig, brokers ...int32) (kadm.AlterConfigsResponses, error) {
+	f.brokers = brokers
+	f.altered = configs
+	return kadm.AlterConfigsResponses{{Name: resourceName(brokerPtr(brokers)), Err: f.alterErr}}, nil
+}
+
+func brokerPtr(brokers []int32) *int32 {
+	if len(brokers) == 0 {
+		return nil
+	}
+	return &brokers[0]
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// perBroker returns the configs of broker 1 with log.retention.ms set at the
+// given source and a static fallback.
+func perBroker(value string, source kmsg.ConfigSource) *BrokerConfig {
+	return &BrokerConfig{
+		BrokerID: ptr(int32(1)),
+		Configs: map[string]kadm.Config{
+			testRetention: {
+				Key:    testRetention,
+				Value:  ptr(value),
+				Source: source,
+				Synonyms: []kadm.ConfigSynonym{
+					{Key: testRetention, Value: ptr(value), Source: source},
+					{Key: testRetention, Value: ptr("604800000"), Source: kmsg.ConfigSourceStaticBrokerConfig},
+				},
+			},
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	retention := kadm.Config{Key: testRetention, Value: ptr("1000"), Source: kmsg.ConfigSourceDynamicDefaultBrokerConfig}
+
+	cases := map[string]struct {
+		brokerID    *int32
+		cl          *fakeBrokerConfigAdmin
+		want        *BrokerConfig
+		wantBrokers []int32
+		wantErr     string
+	}{
+		"ClusterDefault": {
+			cl: &fakeBrokerConfigAdmin{described: kadm.ResourceConfigs{{Name: "", Configs: []kadm.Config{retention}}}},
+			want: &BrokerConfig{Configs: map[string]kadm.Config{
+				testRetention: retention,
+			}},
+		},
+		"Broker": {
+			brokerID: ptr(int32(2)),
+			cl:       &fakeBrokerConfigAdmin{described: kadm.ResourceConfigs{{Name: "2", Configs: []kadm.Config{retention}}}},
+			want: &BrokerConfig{BrokerID: ptr(int32(2)), Configs: map[string]kadm.Config{
+				testRetention: retention,
+			}},
+			wantBrokers: []int32{2},
+		},
+		"NoResponse": {
+			brokerID: ptr(int32(2)),
+			cl:       &fakeBrokerConfigAdmin{described: kadm.ResourceConfigs{{Name: "3"}}},
+			wantErr:  errNoDescribeResponse,
+		},
+		"ResourceError": {
+			cl:      &fakeBrokerConfigAdmin{described: kadm.ResourceConfigs{{Name: "", Err: kerr.ClusterAuthorizationFailed}}},
+			wantErr: errCannotDescribeConfigs,
+		},
+		"RequestError": {
+			cl:      &fakeBrokerConfigAdmin{describeErr: errors.New("boom")},
+			wantErr: errCannotDescribeConfigs,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Get(context.Background(), tc.cl, tc.brokerID)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Get(...): -want, +got:\n%s", diff)
+			}
+			assert.Equal(t, tc.wantBrokers, tc.cl.brokers)
+		})
+	}
+}
+
+func TestAlter(t *testing.T) {
+	cases := map[string]struct {
+		desired  map[string]*string
+		existing *BrokerConfig
+		alterErr error
+		want     []kadm.AlterConfig
+		wantErr  bool
+	}{
+		"SetsStaticOnlyConfig": {
+			desired:  map[string]*string{testRetention: ptr("1000")},
+			existing: perBroker("604800000", kmsg.ConfigSourceStaticBrokerConfig),
+			want:     []kadm.AlterConfig{{Op: kadm.SetConfig, Name: testRetention, Value: ptr("1000")}},
+		},
+		"ChangesDynamicValue": {
+			desired:  map[string]*string{testRetention: ptr("1000")},
+			existing: perBroker("2000", kmsg.ConfigSourceDynamicBrokerConfig),
+			want:     []kadm.AlterConfig{{Op: kadm.SetConfig, Name: testRetention, Value: ptr("1000")}},
+		},
+		"Unchanged": {
+			desired:  map[string]*string{testRetention: ptr("1000")},
+			existing: perBroker("1000", kmsg.ConfigSourceDynamicBrokerConfig),
+		},
+		"RemovesNilValue": {
+			desired:  map[string]*string{testRetention: nil},
+			existing: perBroker("1000", kmsg.ConfigSourceDynamicBrokerConfig),
+			want:     []kadm.AlterConfig{{Op: kadm.DeleteConfig, Name: testRetention}},
+		},
+		"NilValueNotSet": {
+			desired:  map[string]*string{testRetention: nil},
+			existing: perBroker("604800000", kmsg.ConfigSourceStaticBrokerConfig),
+		},
+		"BrokerError": {
+			desired:  map[string]*string{testRetention: ptr("1000")},
+			existing: perBroker("2000", kmsg.ConfigSourceDynamicBrokerConfig),
+			alterErr: kerr.InvalidConfig,
+			want:     []kadm.AlterConfig{{Op: kadm.SetConfig, Name: testRetention, Value: ptr("1000")}},
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeBrokerConfigAdmin{alterErr: tc.alterErr}
+			err := Alter(context.Background(), cl, tc.desired, tc.existing)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if diff := cmp.Diff(tc.want, cl.altered); diff != "" {
+				t.Errorf("Alter(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cl := &fakeBrokerConfigAdmin{}
+	existing := &BrokerConfig{Configs: map[string]kadm.Config{
+		testRetention:       {Key: testRetention, Value: ptr("1000"), Source: kmsg.ConfigSourceDynamicDefaultBrokerConfig},
+		"log.segment.bytes": {Key: "log.segment.bytes", Value: ptr("1024"), Source: kmsg.ConfigSourceDefaultConfig},
+	}}
+
+	err := Delete(context.Background(), cl, existing.ManagedKeys(map[string]*string{
+		testRetention:       ptr("1000"),
+		"log.segment.bytes": ptr("1024"),
+	}), existing)
+	require.NoError(t, err)
+	if diff := cmp.Diff([]kadm.AlterConfig{{Op: kadm.DeleteConfig, Name: testRetention}}, cl.altered); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s", diff)
+	}
+	assert.Empty(t, cl.brokers)
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		config   map[string]*string
+		observed *BrokerConfig
+		want     bool
+	}{
+		"UpToDate": {
+			config:   map[string]*string{testRetention: ptr("1000")},
+			observed: perBroker("1000", kmsg.ConfigSourceDynamicBrokerConfig),
+			want:     true,
+		},
+		"SameValueFromStaticConfig": {
+			config:   map[string]*string{testRetention: ptr("604800000")},
+			observed: perBroker("604800000", kmsg.ConfigSourceStaticBrokerConfig),
+		},
+		"ValueDiffers": {
+			config:   map[string]*string{testRetention: ptr("1000")},
+			observed: perBroker("2000", kmsg.ConfigSourceDynamicBrokerConfig),
+		},
+		"NilValueSet": {
+			config:   map[string]*string{testRetention: nil},
+			observed: perBroker("1000", kmsg.ConfigSourceDynamicBrokerConfig),
+		},
+		"NilValueNotSet": {
+			config:   map[string]*string{testRetention: nil},
+			observed: perBroker("604800000", kmsg.ConfigSourceStaticBrokerConfig),
+			want:     true,
+		},
+		"Sensitive": {
+			config: map[string]*string{"sasl.jaas.config": ptr("secret")},
+			observed: &BrokerConfig{BrokerID: ptr(int32(1)), Configs: map[string]kadm.Config{
+				"sasl.jaas.config": {Key: "sasl.jaas.config", Sensitive: true, Source: kmsg.ConfigSourceDynamicBrokerConfig},
+			}},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in := &v1alpha1.BrokerConfigParameters{Config: tc.config}
+			assert.Equal(t, tc.want, IsUpToDate(in, tc.observed))
+		})
+	}
+}
+
+func TestToObservation(t *testing.T) {
+	got := perBroker("1000", kmsg.ConfigSourceDynamicBrokerConfig).ToObservation(map[string]*string{
+		testRetention: ptr("1000"),
+		"unknown":     ptr("x"),
+	})
+	want := v1alpha1.BrokerConfigObservation{Configs: []v1alpha1.BrokerConfigValue{
+		{Name: testRetention, Value: ptr("1000"), Source: "DYNAMIC_BROKER_CONFIG"},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToObservation(...): -want, +got:\n%s", diff)
+	}
+}
@@ -4,45 +4,379 @@ import (
 	"crypto/sha256"
 	"errors"
 	"sync"
+	"time"
 
-	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// ClientCache caches a *kadm.Client keyed by a digest of credential bytes.
-// If the provided secret changes/rotates, a new client is created.
+// Defaults of the client cache shared by all controllers.
+const (
+	DefaultClientCacheSize        = 32
+	DefaultClientCacheIdleTimeout = 10 * time.Minute
+)
+
+// Reasons a client is evicted from a ClientCache.
+const (
+	evictionReasonIdle    = "idle"
+	evictionReasonSize    = "size"
+	evictionReasonRotated = "rotated"
+)
+
+// ClientKey identifies the ProviderConfig a client was created for.
+type ClientKey struct {
+	// Kind is the group kind of the ProviderConfig, e.g.
+	// ProviderConfig.kafka.crossplane.io.
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// ClientCache caches a *Client per ProviderConfig, along with a digest
+// of the credential bytes it was created from. If the credentials of a
+// ProviderConfig change or rotate, a new client is created. At most a fixed
+// number of clients are cached, and clients unused for the idle timeout are
+// evicted.
+//
+// Clients are created and closed outside of the cache lock, so that a slow
+// cluster does not hold up the reconciles of other ProviderConfigs. Callers
+// that ask for a client while it is being created wait for it instead of
+// creating another one.
+//
+// Clients are handed out with a release function. An evicted client is closed
+// once every caller released it, so that in-flight reconciles are not cut off.
+// Closing a client also stops its Kerberos ticket renewal when using GSSAPI.
 type ClientCache struct {
-	mu           sync.Mutex
-	cachedClient *kadm.Client
-	credsDigest  [sha256.Size]byte // SHA-256 hash of credentials, avoids storing secret material
+	mu       sync.Mutex
+	entries  map[ClientKey]*cacheEntry
+	creating map[ClientKey]*creation
+
+	maxSize     int
+	idleTimeout time.Duration
+	metrics     *ClientCacheMetrics
+
+	now   func() time.Time
+	close func(*Client)
 }
 
-// GetOrCreate returns the cached client if the credential digest is unchanged,
-// otherwise closes the old client and calls newFn to create a new one.
-func (c *ClientCache) GetOrCreate(creds []byte, newFn func() (*kadm.Client, error)) (*kadm.Client, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+type cacheEntry struct {
+	client      *Client
+	credsDigest [sha256.Size]byte // SHA-256 hash of credentials, avoids storing secret material
+	lastUsed    time.Time
+
+	// refs is the number of callers that have not released the client yet.
+	refs int
+	// evicted is true once the entry left the cache. The client is closed
+	// when refs drops to zero.
+	evicted bool
+}
+
+// A creation is a client that is being created for a key.
+type creation struct {
+	credsDigest [sha256.Size]byte
+	// done is closed once the creation finished, after which err is set if
+	// it failed.
+	done chan struct{}
+	err  error
+}
+
+// errCreationAbandoned is returned to the callers waiting for a client whose
+// creation did not finish, which happens if newFn panics.
+var errCreationAbandoned = errors.New("client creation was abandoned")
+
+// A ClientCacheOption configures a ClientCache.
+type ClientCacheOption func(*ClientCache)
+
+// WithMaxClients sets the maximum number of cached clients. The least
+// recently used client is evicted to make room for a new one.
+func WithMaxClients(n int) ClientCacheOption {
+	return func(c *ClientCache) {
+		c.maxSize = n
+	}
+}
+
+// WithIdleTimeout sets how long a client may go unused before it is evicted.
+// Clients are never evicted for being idle if d is zero.
+func WithIdleTimeout(d time.Duration) ClientCacheOption {
+	return func(c *ClientCache) {
+		c.idleTimeout = d
+	}
+}
+
+// WithCacheMetrics records cache hits, misses and evictions in m.
+func WithCacheMetrics(m *ClientCacheMetrics) ClientCacheOption {
+	return func(c *ClientCache) {
+		c.metrics = m
+	}
+}
+
+// NewClientCache returns a ClientCache with the supplied options.
+func NewClientCache(o ...ClientCacheOption) *ClientCache {
+	c := &ClientCache{
+		entries:     map[ClientKey]*cacheEntry{},
+		creating:    map[ClientKey]*creation{},
+		maxSize:     DefaultClientCacheSize,
+		idleTimeout: DefaultClientCacheIdleTimeout,
+		now:         time.Now,
+		close:       (*Client).Close,
+	}
+	for _, fn := range o {
+		fn(c)
+	}
+	return c
+}
 
+// GetOrCreate returns the cached client of the ProviderConfig if its
+// credential digest is unchanged, otherwise it calls newFn to create a new
+// client and evicts the old one. Concurrent callers for the same
+// ProviderConfig wait for a client that is being created, and share its
+// error if they asked for the same credentials. The returned release function
+// must be called once the caller is done with the client.
+func (c *ClientCache) GetOrCreate(key ClientKey, creds []byte, newFn func() (*Client, error)) (*Client, func(), error) {
 	digest := sha256.Sum256(creds)
-	if c.cachedClient != nil && digest == c.credsDigest {
-		return c.cachedClient, nil
+
+	c.mu.Lock()
+	var closing []*Client
+	for {
+		now := c.now()
+		closing = append(closing, c.evictIdle(now)...)
+		if e, ok := c.entries[key]; ok && e.credsDigest == digest {
+			c.metrics.hit()
+			svc, release := c.acquire(e, now)
+			c.mu.Unlock()
+			c.closeAll(closing)
+			return svc, release, nil
+		}
+		p, ok := c.creating[key]
+		if !ok {
+			break
+		}
+
+		// Wait for the client that is being created, then look again.
+		c.mu.Unlock()
+		c.closeAll(closing)
+		closing = nil
+		<-p.done
+		if p.err != nil && p.credsDigest == digest {
+			return nil, nil, p.err
+		}
+		c.mu.Lock()
 	}
 
+	c.metrics.miss()
+	p := &creation{credsDigest: digest, done: make(chan struct{})}
+	c.creating[key] = p
+	c.mu.Unlock()
+	c.closeAll(closing)
+	defer c.abandon(key, p)
+
 	svc, err := newFn()
+	if err == nil && svc == nil {
+		err = errors.New("newFn returned nil client")
+	}
+
+	c.mu.Lock()
+	c.finish(key, p, err)
 	if err != nil {
-		return nil, err
+		c.mu.Unlock()
+		return nil, nil, err
+	}
+
+	// Only evict the old client after successfully creating the new one,
+	// ensuring cache consistency even if newFn() fails.
+	closing = nil
+	if old, ok := c.entries[key]; ok {
+		closing = append(closing, c.evict(key, old, evictionReasonRotated)...)
+	}
+	for c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		closing = append(closing, c.evictLeastRecentlyUsed()...)
+	}
+
+	e := &cacheEntry{client: svc, credsDigest: digest}
+	c.entries[key] = e
+	c.metrics.setClients(len(c.entries))
+	svc, release := c.acquire(e, c.now())
+	c.mu.Unlock()
+	c.closeAll(closing)
+	return svc, release, nil
+}
+
+// finish ends the creation of the key and wakes the callers waiting for it.
+// The lock must be held.
+func (c *ClientCache) finish(key ClientKey, p *creation, err error) {
+	if c.creating[key] != p {
+		return
+	}
+	delete(c.creating, key)
+	p.err = err
+	close(p.done)
+}
+
+// abandon ends the creation of the key if newFn did not return.
+func (c *ClientCache) abandon(key ClientKey, p *creation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.finish(key, p, errCreationAbandoned)
+}
+
+// Len returns the number of cached clients.
+func (c *ClientCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// acquire hands out the client of the entry. The lock must be held.
+func (c *ClientCache) acquire(e *cacheEntry, now time.Time) (*Client, func()) {
+	e.refs++
+	e.lastUsed = now
+
+	var once sync.Once
+	return e.client, func() {
+		once.Do(func() { c.release(e) })
+	}
+}
+
+func (c *ClientCache) release(e *cacheEntry) {
+	c.mu.Lock()
+	e.refs--
+	e.lastUsed = c.now()
+	closeNow := e.evicted && e.refs == 0
+	c.mu.Unlock()
+
+	if closeNow {
+		c.close(e.client)
+	}
+}
+
+// closeAll closes the clients. The lock must not be held, as closing a
+// client waits for its in-flight requests.
+func (c *ClientCache) closeAll(clients []*Client) {
+	for _, cl := range clients {
+		c.close(cl)
+	}
+}
+
+// evictIdle evicts the clients that have not been used for the idle timeout
+// and are not in use, and returns the clients to close.
+func (c *ClientCache) evictIdle(now time.Time) []*Client {
+	if c.idleTimeout <= 0 {
+		return nil
+	}
+	var closing []*Client
+	for k, e := range c.entries {
+		if e.refs == 0 && now.Sub(e.lastUsed) >= c.idleTimeout {
+			closing = append(closing, c.evict(k, e, evictionReasonIdle)...)
+		}
+	}
+	return closing
+}
+
+// evictLeastRecentlyUsed evicts the least recently used client, preferring
+// clients that are not in use, and returns the clients to close.
+func (c *ClientCache) evictLeastRecentlyUsed() []*Client {
+	var lruKey ClientKey
+	var lru *cacheEntry
+	for k, e := range c.entries {
+		if lru == nil || evictsBefore(e, lru) {
+			lruKey, lru = k, e
+		}
+	}
+	return c.evict(lruKey, lru, evictionReasonSize)
+}
+
+// evictsBefore returns true if a should be evicted before b.
+func evictsBefore(a, b *cacheEntry) bool {
+	if (a.refs == 0) != (b.refs == 0) {
+		return a.refs == 0
 	}
+	return a.lastUsed.Before(b.lastUsed)
+}
 
-	if svc == nil {
-		return nil, errors.New("newFn returned nil client")
+// evict removes the entry from the cache and returns its client if it can be
+// closed right away, which the caller does once it released the lock. The
+// lock must be held.
+func (c *ClientCache) evict(k ClientKey, e *cacheEntry, reason string) []*Client {
+	delete(c.entries, k)
+	e.evicted = true
+	c.metrics.evicted(reason)
+	c.metrics.setClients(len(c.entries))
+	if e.refs == 0 {
+		return []*Client{e.client}
 	}
+	return nil
+}
 
-	// Only close the old client after successfully creating the new one, ensuring cache
-	// consistency even if newFn() fails. Also avoids storing raw secret material.
-	if c.cachedClient != nil {
-		c.cachedClient.Close()
+// ClientCacheMetrics are the Prometheus metrics of a ClientCache.
+type ClientCacheMetrics struct {
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions *prometheus.CounterVec
+	clients   prometheus.Gauge
+}
+
+// NewClientCacheMetrics returns the metrics of a ClientCache, to be
+// registered with a Prometheus registry.
+func NewClientCacheMetrics() *ClientCacheMetrics {
+	return &ClientCacheMetrics{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: "provider_kafka",
+			Name:      "client_cache_hits_total",
+			Help:      "The number of Kafka client lookups served from the client cache",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: "provider_kafka",
+			Name:      "client_cache_misses_total",
+			Help:      "The number of Kafka client lookups that created a new client",
+		}),
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "provider_kafka",
+			Name:      "client_cache_evictions_total",
+			Help:      "The number of Kafka clients evicted from the client cache, by reason: idle, size or rotated credentials",
+		}, []string{"reason"}),
+		clients: prometheus.NewGauge(prometheus.GaugeOpts{
+			Subsystem: "provider_kafka",
+			Name:      "client_cache_clients",
+			Help:      "The number of Kafka clients in the client cache",
+		}),
 	}
+}
 
-	c.cachedClient = svc
-	c.credsDigest = digest
-	return svc, nil
+// Describe sends the descriptors of the metrics to ch.
+func (m *ClientCacheMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hits.Describe(ch)
+	m.misses.Describe(ch)
+	m.evictions.Describe(ch)
+	m.clients.Describe(ch)
+}
+
+// Collect sends the metrics to ch.
+func (m *ClientCacheMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.hits.Collect(ch)
+	m.misses.Collect(ch)
+	m.evictions.Collect(ch)
+	m.clients.Collect(ch)
+}
+
+func (m *ClientCacheMetrics) hit() {
+	if m != nil {
+		m.hits.Inc()
+	}
+}
+
+func (m *ClientCacheMetrics) miss() {
+	if m != nil {
+		m.misses.Inc()
+	}
+}
+
+func (m *ClientCacheMetrics) evicted(reason string) {
+	if m != nil {
+		m.evictions.WithLabelValues(reason).Inc()
+	}
+}
+
+func (m *ClientCacheMetrics) setClients(n int) {
+	if m != nil {
+		m.clients.Set(float64(n))
+	}
 }
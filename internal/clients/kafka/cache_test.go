@@ -5,60 +5,83 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twmb/franz-go/pkg/kadm"
 )
 
+var (
+	testKey      = ClientKey{Kind: "ProviderConfig.kafka.crossplane.io", Name: "default"}
+	testOtherKey = ClientKey{Kind: "ProviderConfig.kafka.crossplane.io", Name: "other"}
+)
+
+// newTestCache returns a ClientCache that records closed clients instead of
+// closing them, and whose clock is advanced by the returned function.
+func newTestCache(o ...ClientCacheOption) (*ClientCache, *[]*Client, func(time.Duration)) {
+	c := NewClientCache(o...)
+	closed := &[]*Client{}
+	c.close = func(cl *Client) { *closed = append(*closed, cl) }
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	return c, closed, func(d time.Duration) { now = now.Add(d) }
+}
+
+func newTestClient() (*Client, error) {
+	return &Client{}, nil
+}
+
 // TestGetOrCreateCacheHit verifies that cached clients are reused with same credentials.
 func TestGetOrCreateCacheHit(t *testing.T) {
-	cache := &ClientCache{}
+	cache, _, _ := newTestCache()
 	creds := []byte("secret123")
 	var callCount int32
 
-	newFn := func() (*kadm.Client, error) {
+	newFn := func() (*Client, error) {
 		atomic.AddInt32(&callCount, 1)
-		return &kadm.Client{}, nil
+		return &Client{}, nil
 	}
 
-	client1, err := cache.GetOrCreate(creds, newFn)
+	client1, release1, err := cache.GetOrCreate(testKey, creds, newFn)
 	require.NoError(t, err)
+	release1()
 	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
 
-	client2, err := cache.GetOrCreate(creds, newFn)
+	client2, release2, err := cache.GetOrCreate(testKey, creds, newFn)
 	require.NoError(t, err)
+	release2()
 	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount)) // Should not call newFn again
 	assert.Same(t, client1, client2)
 }
 
 // TestGetOrCreateErrorHandling verifies that errors from newFn are propagated.
 func TestGetOrCreateErrorHandling(t *testing.T) {
-	cache := &ClientCache{}
+	cache, _, _ := newTestCache()
 	creds := []byte("secret")
 	testErr := errors.New("creation failed")
 
-	newFn := func() (*kadm.Client, error) {
+	newFn := func() (*Client, error) {
 		return nil, testErr
 	}
 
-	_, err := cache.GetOrCreate(creds, newFn)
+	_, _, err := cache.GetOrCreate(testKey, creds, newFn)
 	require.Error(t, err)
 	assert.Equal(t, testErr, err)
 
 	// Cache should be empty after error
-	assert.Nil(t, cache.cachedClient)
+	assert.Equal(t, 0, cache.Len())
 }
 
 // TestGetOrCreateConcurrentAccess verifies thread-safety with concurrent calls.
 func TestGetOrCreateConcurrentAccess(t *testing.T) {
-	cache := &ClientCache{}
+	cache := NewClientCache()
 	creds := []byte("secret")
 	var creationCount int32
 
-	newFn := func() (*kadm.Client, error) {
+	newFn := func() (*Client, error) {
 		atomic.AddInt32(&creationCount, 1)
-		return &kadm.Client{}, nil
+		return &Client{}, nil
 	}
 
 	// Launch multiple goroutines requesting the same credentials
@@ -69,102 +92,261 @@ func TestGetOrCreateConcurrentAccess(t *testing.T) {
 	for i := 0; i < goroutines; i++ {
 		go func() {
 			defer wg.Done()
-			_, err := cache.GetOrCreate(creds, newFn)
+			_, release, err := cache.GetOrCreate(testKey, creds, newFn)
 			assert.NoError(t, err)
+			release()
 		}()
 	}
 
 	wg.Wait()
 
-	// With the lock held during newFn(), only 1 client should be created
+	// Callers wait for the client that is being created, so only 1 client
+	// should be created
 	assert.Equal(t, int32(1), atomic.LoadInt32(&creationCount))
-	assert.NotNil(t, cache.cachedClient)
+	assert.Equal(t, 1, cache.Len())
 }
 
 // TestGetOrCreateEmptyCredentials verifies behavior with empty credential bytes.
 func TestGetOrCreateEmptyCredentials(t *testing.T) {
-	cache := &ClientCache{}
+	cache, _, _ := newTestCache()
 	emptyCreds := []byte{}
 	var callCount int32
 
-	newFn := func() (*kadm.Client, error) {
+	newFn := func() (*Client, error) {
 		atomic.AddInt32(&callCount, 1)
-		return &kadm.Client{}, nil
+		return &Client{}, nil
 	}
 
-	_, err := cache.GetOrCreate(emptyCreds, newFn)
+	_, _, err := cache.GetOrCreate(testKey, emptyCreds, newFn)
 	require.NoError(t, err)
 	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
 
-	_, err = cache.GetOrCreate(emptyCreds, newFn)
+	_, _, err = cache.GetOrCreate(testKey, emptyCreds, newFn)
 	require.NoError(t, err)
 	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount)) // Should reuse cached client
 }
 
 // TestGetOrCreateCredentialComparison verifies that credential comparison is byte-exact.
 func TestGetOrCreateCredentialComparison(t *testing.T) {
-	cache := &ClientCache{}
+	cache, _, _ := newTestCache()
 	creds1 := []byte("secret")
 	creds2 := []byte("secret")
 	var callCount int32
 
-	newFn := func() (*kadm.Client, error) {
+	newFn := func() (*Client, error) {
 		atomic.AddInt32(&callCount, 1)
-		return &kadm.Client{}, nil
+		return &Client{}, nil
 	}
 
 	// Same credentials (different objects, same content)
-	_, err := cache.GetOrCreate(creds1, newFn)
+	_, _, err := cache.GetOrCreate(testKey, creds1, newFn)
 	require.NoError(t, err)
 	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
 
 	// Should reuse client even though it's a different object
-	_, err = cache.GetOrCreate(creds2, newFn)
+	_, _, err = cache.GetOrCreate(testKey, creds2, newFn)
 	require.NoError(t, err)
 	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
 }
 
 // TestGetOrCreateErrorPreservesCacheState verifies that cache remains unchanged if newFn fails.
 func TestGetOrCreateErrorPreservesCacheState(t *testing.T) {
-	cache := &ClientCache{}
+	cache, closed, _ := newTestCache()
 	creds1 := []byte("secret1")
 	creds2 := []byte("secret2")
-	var callCount int32
-
-	newFn := func() (*kadm.Client, error) {
-		atomic.AddInt32(&callCount, 1)
-		return &kadm.Client{}, nil
-	}
 
 	// Create initial client with creds1
-	client1, err := cache.GetOrCreate(creds1, newFn)
+	client1, release, err := cache.GetOrCreate(testKey, creds1, newTestClient)
 	require.NoError(t, err)
-	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
-	originalDigest := cache.credsDigest
+	release()
+	originalDigest := cache.entries[testKey].credsDigest
 
 	// Try to rotate to creds2, but newFn fails
-	failingFn := func() (*kadm.Client, error) {
+	failingFn := func() (*Client, error) {
 		return nil, errors.New("connection failed")
 	}
 
-	_, err = cache.GetOrCreate(creds2, failingFn)
+	_, _, err = cache.GetOrCreate(testKey, creds2, failingFn)
 	require.Error(t, err)
 
 	// Verify cache is unchanged - still has original client and digest
-	assert.Same(t, client1, cache.cachedClient)
-	assert.Equal(t, originalDigest, cache.credsDigest)
+	assert.Same(t, client1, cache.entries[testKey].client)
+	assert.Equal(t, originalDigest, cache.entries[testKey].credsDigest)
+	assert.Empty(t, *closed)
 }
 
 // TestGetOrCreateNilClientRejected verifies that a nil client is treated as an error.
 func TestGetOrCreateNilClientRejected(t *testing.T) {
-	cache := &ClientCache{}
+	cache, _, _ := newTestCache()
 	creds := []byte("secret")
 
-	nilClientFn := func() (*kadm.Client, error) {
+	nilClientFn := func() (*Client, error) {
 		return nil, nil
 	}
 
-	_, err := cache.GetOrCreate(creds, nilClientFn)
+	_, _, err := cache.GetOrCreate(testKey, creds, nilClientFn)
 	require.Error(t, err)
-	assert.Nil(t, cache.cachedClient)
+	assert.Equal(t, 0, cache.Len())
+}
+
+// TestGetOrCreateKeyedByProviderConfig verifies that ProviderConfigs with
+// different credentials do not replace each other's clients.
+func TestGetOrCreateKeyedByProviderConfig(t *testing.T) {
+	cache, closed, _ := newTestCache()
+
+	client1, _, err := cache.GetOrCreate(testKey, []byte("cluster-a"), newTestClient)
+	require.NoError(t, err)
+	client2, _, err := cache.GetOrCreate(testOtherKey, []byte("cluster-b"), newTestClient)
+	require.NoError(t, err)
+	assert.NotSame(t, client1, client2)
+
+	got, _, err := cache.GetOrCreate(testKey, []byte("cluster-a"), newTestClient)
+	require.NoError(t, err)
+	assert.Same(t, client1, got)
+	assert.Equal(t, 2, cache.Len())
+	assert.Empty(t, *closed)
+}
+
+// TestGetOrCreateRotationWaitsForRelease verifies that a client replaced
+// because its credentials rotated is only closed once it was released.
+func TestGetOrCreateRotationWaitsForRelease(t *testing.T) {
+	cache, closed, _ := newTestCache()
+
+	old, releaseOld, err := cache.GetOrCreate(testKey, []byte("secret1"), newTestClient)
+	require.NoError(t, err)
+
+	rotated, releaseRotated, err := cache.GetOrCreate(testKey, []byte("secret2"), newTestClient)
+	require.NoError(t, err)
+	assert.NotSame(t, old, rotated)
+	assert.Empty(t, *closed, "client in use must not be closed")
+
+	releaseOld()
+	releaseOld() // Releasing twice must not close twice.
+	assert.Equal(t, []*Client{old}, *closed)
+
+	releaseRotated()
+	assert.Equal(t, []*Client{old}, *closed)
+	assert.Equal(t, 1, cache.Len())
+}
+
+// TestGetOrCreateSizeBound verifies that the least recently used client is
+// evicted once the cache is full, preferring clients not in use.
+func TestGetOrCreateSizeBound(t *testing.T) {
+	cache, closed, advance := newTestCache(WithMaxClients(2))
+	thirdKey := ClientKey{Kind: testKey.Kind, Name: "third"}
+
+	inUse, _, err := cache.GetOrCreate(testKey, []byte("a"), newTestClient)
+	require.NoError(t, err)
+	advance(time.Second)
+	idle, release, err := cache.GetOrCreate(testOtherKey, []byte("b"), newTestClient)
+	require.NoError(t, err)
+	release()
+	advance(time.Second)
+
+	_, _, err = cache.GetOrCreate(thirdKey, []byte("c"), newTestClient)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, []*Client{idle}, *closed)
+
+	got, _, err := cache.GetOrCreate(testKey, []byte("a"), newTestClient)
+	require.NoError(t, err)
+	assert.Same(t, inUse, got)
+}
+
+// TestGetOrCreateIdleEviction verifies that clients unused for the idle
+// timeout are evicted, unless they are in use.
+func TestGetOrCreateIdleEviction(t *testing.T) {
+	m := NewClientCacheMetrics()
+	cache, closed, advance := newTestCache(WithIdleTimeout(time.Minute), WithCacheMetrics(m))
+
+	idle, release, err := cache.GetOrCreate(testKey, []byte("a"), newTestClient)
+	require.NoError(t, err)
+	release()
+	_, _, err = cache.GetOrCreate(testOtherKey, []byte("b"), newTestClient)
+	require.NoError(t, err)
+
+	advance(2 * time.Minute)
+	_, _, err = cache.GetOrCreate(testOtherKey, []byte("b"), newTestClient)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*Client{idle}, *closed)
+	assert.Equal(t, 1, cache.Len())
+
+	assert.InDelta(t, 1, testutil.ToFloat64(m.hits), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.misses), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.evictions.WithLabelValues(evictionReasonIdle)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.clients), 0)
+}
+
+// TestGetOrCreateDoesNotBlockOtherKeys verifies that creating a client does
+// not hold up callers for other ProviderConfigs, and that callers for the
+// same ProviderConfig do not create another client.
+func TestGetOrCreateDoesNotBlockOtherKeys(t *testing.T) {
+	cache, _, _ := newTestCache()
+	started, unblock := make(chan struct{}), make(chan struct{})
+	var callCount int32
+
+	slowFn := func() (*Client, error) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			close(started)
+		}
+		<-unblock
+		return &Client{}, nil
+	}
+
+	clients := make(chan *Client, 2)
+	get := func() {
+		cl, release, err := cache.GetOrCreate(testKey, []byte("a"), slowFn)
+		assert.NoError(t, err)
+		release()
+		clients <- cl
+	}
+	go get()
+	<-started
+	go get()
+
+	_, release, err := cache.GetOrCreate(testOtherKey, []byte("b"), newTestClient)
+	require.NoError(t, err)
+	release()
+
+	close(unblock)
+	assert.Same(t, <-clients, <-clients)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+	assert.Equal(t, 2, cache.Len())
+}
+
+// TestGetOrCreateAbandoned verifies that a creation that panics does not keep
+// later callers waiting.
+func TestGetOrCreateAbandoned(t *testing.T) {
+	cache, _, _ := newTestCache()
+
+	assert.Panics(t, func() {
+		_, _, _ = cache.GetOrCreate(testKey, []byte("a"), func() (*Client, error) {
+			panic("boom")
+		})
+	})
+	assert.Empty(t, cache.creating)
+
+	_, _, err := cache.GetOrCreate(testKey, []byte("a"), newTestClient)
+	require.NoError(t, err)
+}
+
+// TestGetOrCreateClosesOutsideLock verifies that evicted clients are closed
+// after the cache lock was released, as closing waits for in-flight requests.
+func TestGetOrCreateClosesOutsideLock(t *testing.T) {
+	cache, _, _ := newTestCache(WithMaxClients(1))
+	var closed []*Client
+	cache.close = func(cl *Client) {
+		cache.Len() // Deadlocks if the lock is held.
+		closed = append(closed, cl)
+	}
+
+	old, release, err := cache.GetOrCreate(testKey, []byte("a"), newTestClient)
+	require.NoError(t, err)
+	_, _, err = cache.GetOrCreate(testOtherKey, []byte("b"), newTestClient)
+	require.NoError(t, err)
+	assert.Empty(t, closed, "client in use must not be closed")
+
+	release()
+	assert.Equal(t, []*Client{old}, closed)
 }
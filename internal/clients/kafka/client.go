@@ -17,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
 	"github.com/twmb/franz-go/pkg/sasl"
 	kaws "github.com/twmb/franz-go/pkg/sasl/aws"
 	"github.com/twmb/franz-go/pkg/sasl/plain"
@@ -30,18 +31,69 @@ import (
 // Set before starting controllers; defaults to LogLevelWarn.
 var LogLevel = kgo.LogLevelWarn
 
-// NewAdminClient creates a new AdminClient with supplied credentials
-func NewAdminClient(ctx context.Context, data []byte, kube client.Client) (*kadm.Client, error) { // nolint: gocyclo
+// ParseConfig parses the Kafka client configuration from ProviderConfig
+// credentials.
+func ParseConfig(data []byte) (Config, error) {
 	kc := Config{}
-
 	if err := json.Unmarshal(data, &kc); err != nil {
-		return nil, fmt.Errorf("%s: %w", errCannotParse, err)
+		return Config{}, fmt.Errorf("%s: %w", errCannotParse, err)
+	}
+	return kc, nil
+}
+
+// SecretRefs returns the fields of the configuration that reference a
+// Kubernetes Secret, which can only be read with a Kubernetes client.
+func (c Config) SecretRefs() []string {
+	var refs []string
+	if c.TLS != nil && c.TLS.CACertificateSecretRef != nil {
+		refs = append(refs, "tls.caCertificateSecretRef")
+	}
+	if c.TLS != nil && c.TLS.ClientCertificateSecretRef != nil {
+		refs = append(refs, "tls.clientCertificateSecretRef")
+	}
+	if c.SASL != nil && c.SASL.OAuth != nil && c.SASL.OAuth.ClientSecretRef != nil {
+		refs = append(refs, "sasl.oauth.clientSecretRef")
+	}
+	if c.SASL != nil && c.SASL.Kerberos != nil && c.SASL.Kerberos.KeytabSecretRef != nil {
+		refs = append(refs, "sasl.kerberos.keytabSecretRef")
+	}
+	return refs
+}
+
+// A Client is a Kafka admin client that can also issue the raw requests kadm
+// has no builder for, such as the requests for ACLs on User resources.
+type Client struct {
+	*kadm.Client
+	raw *kgo.Client
+}
+
+// NewClient returns a Client that issues its requests with the supplied
+// franz-go client.
+func NewClient(cl *kgo.Client) *Client {
+	return &Client{Client: kadm.NewClient(cl), raw: cl}
+}
+
+// Request issues a raw request to the cluster, see kgo.Client.Request.
+func (c *Client) Request(ctx context.Context, req kmsg.Request) (kmsg.Response, error) {
+	return c.raw.Request(ctx, req)
+}
+
+// NewAdminClient creates a new Client with supplied credentials. The
+// Kubernetes client may be nil if the credentials reference no Secrets.
+func NewAdminClient(ctx context.Context, data []byte, kube client.Client) (*Client, error) { // nolint: gocyclo
+	kc, err := ParseConfig(data)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(kc.Brokers) == 0 {
 		return nil, errors.New(errMissingBrokers)
 	}
 
+	if refs := kc.SecretRefs(); kube == nil && len(refs) > 0 {
+		return nil, fmt.Errorf("%s: %s", errSecretRefWithoutKube, strings.Join(refs, ", "))
+	}
+
 	// Validate TLS configuration if provided
 	if kc.TLS != nil && kc.TLS.DialTimeoutSeconds < 0 {
 		return nil, fmt.Errorf("%s (received: %d)", errInvalidDialTimeout, kc.TLS.DialTimeoutSeconds)
@@ -49,14 +101,8 @@ func NewAdminClient(ctx context.Context, data []byte, kube client.Client) (*kadm
 
 	// Validate SASL configuration if provided
 	if kc.SASL != nil {
-		if kc.SASL.Mechanism == "" {
-			return nil, errors.New(errMissingSASLMechanism)
-		}
-		// AWS MSK IAM uses IAM credentials, not username/password
-		if !strings.EqualFold(kc.SASL.Mechanism, "aws-msk-iam") {
-			if kc.SASL.Username == "" || kc.SASL.Password == "" {
-				return nil, errors.New(errMissingSASLCredentials)
-			}
+		if err := validateSASL(kc.SASL); err != nil {
+			return nil, err
 		}
 	}
 
@@ -71,39 +117,55 @@ func NewAdminClient(ctx context.Context, data []byte, kube client.Client) (*kadm
 			err       error
 		)
 		switch name := kc.SASL.Mechanism; strings.ToLower(name) {
-		case "plain":
+		case saslMechanismPlain:
 			mechanism = plain.Auth{
 				User: kc.SASL.Username,
 				Pass: kc.SASL.Password,
 			}.AsMechanism()
-		case "aws-msk-iam":
+		case saslMechanismAwsMskIam:
 			mechanism, err = newAwsMskIamMechanism(ctx, kc.SASL)
 			if err != nil {
 				return nil, err
 			}
-		case "scram-sha-512":
+		case saslMechanismScramSha256:
+			mechanism = scram.Auth{
+				User: kc.SASL.Username,
+				Pass: kc.SASL.Password,
+			}.AsSha256Mechanism()
+		case saslMechanismScramSha512:
 			mechanism = scram.Auth{
 				User: kc.SASL.Username,
 				Pass: kc.SASL.Password,
 			}.AsSha512Mechanism()
+		case saslMechanismOAuthBearer:
+			mechanism, err = newOAuthBearerMechanism(ctx, kc.SASL.OAuth, kube)
+			if err != nil {
+				return nil, err
+			}
+		case saslMechanismGSSAPI:
+			mechanism, err = newGSSAPIMechanism(ctx, kc.SASL.Kerberos, kube)
+			if err != nil {
+				return nil, err
+			}
 		default:
-			return nil, fmt.Errorf("SASL mechanism %q not supported, only PLAIN / SCRAM-SHA-512 / AWS-MSK-IAM are supported for now", kc.SASL.Mechanism)
+			return nil, fmt.Errorf("SASL mechanism %q not supported, only PLAIN / SCRAM-SHA-256 / SCRAM-SHA-512 / AWS-MSK-IAM / OAUTHBEARER / GSSAPI are supported for now", kc.SASL.Mechanism)
 		}
 		opts = append(opts, kgo.SASL(mechanism))
 	}
 
-	// Determine dial timeout and whether TLS is needed
-	dialTimeout := defaultTLSDialTimeoutSeconds
+	// The dial timeout of the client settings takes precedence over the one
+	// of the TLS settings.
+	defaults := ClientDefaults
 	if kc.TLS != nil && kc.TLS.DialTimeoutSeconds > 0 {
-		dialTimeout = kc.TLS.DialTimeoutSeconds
+		defaults.DialTimeout = time.Duration(kc.TLS.DialTimeoutSeconds) * time.Second
 	}
-
-	isAwsMskIam := kc.SASL != nil && strings.EqualFold(kc.SASL.Mechanism, "aws-msk-iam")
-
-	// Set dial timeout if TLS or AWS-MSK-IAM (which requires TLS)
-	if kc.TLS != nil || isAwsMskIam {
-		opts = append(opts, kgo.DialTimeout(time.Duration(dialTimeout)*time.Second))
+	tuning, err := defaults.override(kc.Client)
+	if err != nil {
+		return nil, err
 	}
+	opts = append(opts, tuning.opts()...)
+
+	isAwsMskIam := kc.SASL != nil && strings.EqualFold(kc.SASL.Mechanism, saslMechanismAwsMskIam)
 
 	// Configure TLS
 	if kc.TLS != nil {
@@ -125,7 +187,28 @@ func NewAdminClient(ctx context.Context, data []byte, kube client.Client) (*kadm
 	if err != nil {
 		return nil, err
 	}
-	return kadm.NewClient(c), nil
+	return NewClient(c), nil
+}
+
+// validateSASL checks that the SASL configuration carries the credentials
+// required by its mechanism.
+func validateSASL(s *SASL) error {
+	switch strings.ToLower(s.Mechanism) {
+	case "":
+		return errors.New(errMissingSASLMechanism)
+	case saslMechanismAwsMskIam:
+		// AWS MSK IAM uses IAM credentials, not username/password
+		return nil
+	case saslMechanismOAuthBearer:
+		return validateOAuth(s.OAuth)
+	case saslMechanismGSSAPI:
+		return validateKerberos(s.Kerberos)
+	default:
+		if s.Username == "" || s.Password == "" {
+			return errors.New(errMissingSASLCredentials)
+		}
+		return nil
+	}
 }
 
 // configureClientCertificate sets up client certificate authentication in the TLS config,
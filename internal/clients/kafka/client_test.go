@@ -146,6 +146,106 @@ func TestNewAdminClient_ZeroDialTimeout(t *testing.T) {
 	require.NoError(t, err, "expected no error for zero DialTimeoutSeconds (should use default)")
 }
 
+// TestNewAdminClient_InvalidClientSettings tests that invalid client settings are rejected
+func TestNewAdminClient_InvalidClientSettings(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	data := []byte(`{
+		"brokers": ["localhost:9092"],
+		"client": {
+			"requestTimeout": "soon"
+		}
+	}`)
+	client, err := NewAdminClient(ctx, data, nil)
+	assert.Nil(t, client, "expected client to be nil for invalid client settings")
+	require.ErrorContains(t, err, errInvalidClientSettings)
+	require.ErrorContains(t, err, "requestTimeout")
+}
+
+// TestNewAdminClient_SecretRefsWithoutKube tests that credentials referencing
+// Secrets are rejected rather than read without a Kubernetes client
+func TestNewAdminClient_SecretRefsWithoutKube(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	data := []byte(`{
+		"brokers": ["localhost:9092"],
+		"tls": {
+			"caCertificateSecretRef": {"name": "ca", "namespace": "kafka", "caField": "ca.crt"}
+		},
+		"sasl": {
+			"mechanism": "GSSAPI",
+			"kerberos": {"principal": "admin", "realm": "EXAMPLE.COM", "keytabSecretRef": {"name": "keytab", "namespace": "kafka"}}
+		}
+	}`)
+	client, err := NewAdminClient(ctx, data, nil)
+	assert.Nil(t, client, "expected client to be nil for Secret references without a Kubernetes client")
+	require.ErrorContains(t, err, errSecretRefWithoutKube)
+	require.ErrorContains(t, err, "tls.caCertificateSecretRef, sasl.kerberos.keytabSecretRef")
+}
+
+// TestNewAdminClient_SASLMechanisms tests that every supported SASL mechanism
+// passes validation (doesn't test connectivity)
+func TestNewAdminClient_SASLMechanisms(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		sasl    string
+		wantErr string
+	}{
+		"ScramSha256": {
+			sasl: `{"mechanism": "SCRAM-SHA-256", "username": "user1", "password": "password123"}`,
+		},
+		"ScramSha512": {
+			sasl: `{"mechanism": "scram-sha-512", "username": "user1", "password": "password123"}`,
+		},
+		"ScramSha256MissingPassword": {
+			sasl:    `{"mechanism": "SCRAM-SHA-256", "username": "user1"}`,
+			wantErr: errMissingSASLCredentials,
+		},
+		"OAuthBearer": {
+			sasl: `{"mechanism": "OAUTHBEARER", "oauth": {
+				"tokenEndpoint": "https://idp.example.com/token",
+				"clientId": "id",
+				"clientSecret": "secret"
+			}}`,
+		},
+		"OAuthBearerMissingOAuth": {
+			sasl:    `{"mechanism": "OAUTHBEARER"}`,
+			wantErr: errMissingOAuthConfig,
+		},
+		"GSSAPIMissingKerberos": {
+			sasl:    `{"mechanism": "GSSAPI"}`,
+			wantErr: errMissingKerberosConfig,
+		},
+		"Unsupported": {
+			sasl:    `{"mechanism": "DIGEST-MD5", "username": "user1", "password": "password123"}`,
+			wantErr: "not supported",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			data := []byte(`{"brokers": ["localhost:9092"], "sasl": ` + tc.sasl + `}`)
+			client, err := NewAdminClient(ctx, data, nil)
+			if tc.wantErr != "" {
+				assert.Nil(t, client)
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			client.Close()
+		})
+	}
+}
+
 // generateTestCertificate generates a self-signed certificate and key pair for testing
 func generateTestCertificate(t *testing.T) (certPEM, keyPEM []byte) {
 	t.Helper()
@@ -23,9 +23,34 @@ type ClientCertificateSecretRef struct {
 
 // Config is a Kafka client configuration
 type Config struct {
-	Brokers []string `json:"brokers"`
-	SASL    *SASL    `json:"sasl,omitempty"`
-	TLS     *TLS     `json:"tls,omitempty"`
+	Brokers []string        `json:"brokers"`
+	SASL    *SASL           `json:"sasl,omitempty"`
+	TLS     *TLS            `json:"tls,omitempty"`
+	Client  *ClientSettings `json:"client,omitempty"`
+}
+
+// ClientSettings tune the connections and requests of the Kafka client.
+// Durations use Go time.Duration format (e.g. "10s", "1m"). Unset fields
+// default to the provider flags, see ClientDefaults.
+type ClientSettings struct {
+	// DialTimeout is the timeout for opening a connection to a broker. It
+	// takes precedence over TLS.DialTimeoutSeconds.
+	DialTimeout string `json:"dialTimeout,omitempty"`
+	// RequestTimeout is the time a broker has to answer a request, on top of
+	// any timeout carried by the request itself.
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+	// RequestRetries is the number of times a failed request is retried.
+	RequestRetries *int `json:"requestRetries,omitempty"`
+	// RetryBackoff is the fixed time to wait between retries.
+	RetryBackoff string `json:"retryBackoff,omitempty"`
+	// MetadataMaxAge is how often the cluster metadata is refreshed.
+	MetadataMaxAge string `json:"metadataMaxAge,omitempty"`
+	// ConnIdleTimeout is how long an unused connection is kept open.
+	ConnIdleTimeout string `json:"connIdleTimeout,omitempty"`
+	// ClientID is the client ID sent to the brokers.
+	ClientID string `json:"clientId,omitempty"`
+	// Rack is the rack of the client.
+	Rack string `json:"rack,omitempty"`
 }
 
 // SASL is an sasl option
@@ -38,6 +63,75 @@ type SASL struct {
 	IAMCredentialsExpiryWindow string `json:"iamCredentialsExpiryWindow,omitempty"`
 	Username                   string `json:"username"`
 	Password                   string `json:"password"` //nolint:gosec
+	// OAuth configures the OAuth 2.0 client credentials flow used to obtain
+	// tokens for the OAUTHBEARER mechanism.
+	OAuth *OAuth `json:"oauth,omitempty"`
+	// Kerberos configures the keytab login used by the GSSAPI mechanism.
+	Kerberos *Kerberos `json:"kerberos,omitempty"`
+}
+
+// Kerberos is a GSSAPI option for authenticating with a Kerberos keytab.
+// Tickets are renewed, or requested again with the keytab, before they
+// expire for as long as the client is cached.
+type Kerberos struct {
+	// Principal is the client principal without realm, e.g. "kafka-admin" or
+	// "kafka-admin/host.example.com".
+	Principal string `json:"principal"`
+	Realm     string `json:"realm"`
+	// ServiceName is the primary of the broker service principal. Defaults to
+	// "kafka".
+	ServiceName string `json:"serviceName,omitempty"`
+	// Krb5Conf is the content of the krb5.conf file describing the realm.
+	Krb5Conf string `json:"krb5Conf"`
+	// KeytabSecretRef and KeytabPath are mutually exclusive.
+	KeytabSecretRef *KeytabSecretRef `json:"keytabSecretRef,omitempty"`
+	// KeytabPath is read when the client is created.
+	KeytabPath string `json:"keytabPath,omitempty"`
+	// DisablePAFXFAST disables the PA-FX-FAST pre-authentication, which is
+	// required for Active Directory KDCs.
+	DisablePAFXFAST bool `json:"disablePAFXFAST,omitempty"`
+}
+
+// KeytabSecretRef is a Kerberos option for reading the keytab from a
+// Kubernetes secret
+type KeytabSecretRef struct {
+	KeytabField string `json:"keytabField,omitempty"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+}
+
+// OAuth is an OAuth 2.0 client credentials option for the OAUTHBEARER SASL
+// mechanism. Tokens are cached and refreshed before they expire.
+type OAuth struct {
+	// TokenEndpoint is the URL of the token endpoint of the identity provider.
+	TokenEndpoint string `json:"tokenEndpoint"`
+	// ClientID is the OAuth client ID. It may instead be read from the
+	// clientIdField of ClientSecretRef.
+	ClientID string `json:"clientId,omitempty"`
+	// ClientSecret is the OAuth client secret. Mutually exclusive with
+	// ClientSecretRef.
+	ClientSecret    string                `json:"clientSecret,omitempty"` //nolint:gosec
+	ClientSecretRef *OAuthClientSecretRef `json:"clientSecretRef,omitempty"`
+	// Scopes are the scopes requested for the token.
+	Scopes []string `json:"scopes,omitempty"`
+	// Audience is sent as the audience parameter of the token request, as
+	// required by some identity providers.
+	Audience string `json:"audience,omitempty"`
+	// Extensions are SASL extensions sent with the token, for example the
+	// logicalCluster and identityPoolId required by Confluent Cloud.
+	Extensions map[string]string `json:"extensions,omitempty"`
+	// TokenExpiryWindow controls how early cached tokens are refreshed before
+	// expiry. Uses Go time.Duration format (e.g. "1m", "30s"). Defaults to "1m".
+	TokenExpiryWindow string `json:"tokenExpiryWindow,omitempty"`
+}
+
+// OAuthClientSecretRef is an OAuth option for reading the client credentials
+// from a Kubernetes secret
+type OAuthClientSecretRef struct {
+	ClientIDField     string `json:"clientIdField,omitempty"`
+	ClientSecretField string `json:"clientSecretField,omitempty"`
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
 }
 
 // TLS is an option for enabling encryption in transit.
@@ -66,7 +160,7 @@ type TLS struct {
 	ClientSessionCacheCapacity int      `json:"clientSessionCacheCapacity,omitempty"`
 	CurvePreferences           []string `json:"curvePreferences,omitempty"`
 	// DialTimeoutSeconds specifies the timeout for establishing TLS connections. Must be >= 0 (negative values rejected).
-	// 0 uses the default (10 seconds).
+	// 0 uses the dial timeout of the client settings.
 	DialTimeoutSeconds          int      `json:"dialTimeoutSeconds,omitempty"`
 	DynamicRecordSizingDisabled bool     `json:"dynamicRecordSizingDisabled,omitempty"`
 	InsecureSkipVerify          bool     `json:"insecureSkipVerify"`
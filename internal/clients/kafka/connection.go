@@ -0,0 +1,151 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// MergeConnection merges the typed connection of a ProviderConfig into the
+// JSON credentials and returns the Kafka client configuration accepted by
+// NewAdminClient. Each of brokers, sasl, tls and client is taken from the
+// connection when set there, replacing the whole block of the credentials. Passwords
+// and client secrets referenced by the connection are read into the
+// configuration, so that the client is recreated when they rotate; keytabs
+// and certificates are read when the client is created, as for the JSON
+// credentials. The credentials are returned unchanged if there is no
+// connection.
+func MergeConnection(ctx context.Context, kube client.Client, data []byte, conn *v1alpha1.ConnectionConfig) ([]byte, error) {
+	if conn == nil {
+		return data, nil
+	}
+
+	kc := Config{}
+	if len(data) > 0 {
+		var err error
+		if kc, err = ParseConfig(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(conn.Brokers) > 0 {
+		kc.Brokers = conn.Brokers
+	}
+	if conn.SASL != nil {
+		s, err := saslFromConnection(ctx, kube, conn.SASL)
+		if err != nil {
+			return nil, err
+		}
+		kc.SASL = s
+	}
+	if conn.TLS != nil {
+		kc.TLS = tlsFromConnection(conn.TLS)
+	}
+	if c := conn.Client; c != nil {
+		kc.Client = &ClientSettings{
+			DialTimeout:     c.DialTimeout,
+			RequestTimeout:  c.RequestTimeout,
+			RequestRetries:  c.RequestRetries,
+			RetryBackoff:    c.RetryBackoff,
+			MetadataMaxAge:  c.MetadataMaxAge,
+			ConnIdleTimeout: c.ConnIdleTimeout,
+			ClientID:        c.ClientID,
+			Rack:            c.Rack,
+		}
+	}
+	return json.Marshal(kc)
+}
+
+func saslFromConnection(ctx context.Context, kube client.Client, in *v1alpha1.SASLConfig) (*SASL, error) {
+	s := &SASL{
+		Mechanism:                  in.Mechanism,
+		Username:                   in.Username,
+		RoleArn:                    in.RoleArn,
+		IAMCredentialsExpiryWindow: in.IAMCredentialsExpiryWindow,
+	}
+	if in.PasswordSecretRef != nil {
+		pw, err := secretValue(ctx, kube, in.PasswordSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errCannotReadSASLPasswordSecret, err)
+		}
+		s.Password = pw
+	}
+	if o := in.OAuth; o != nil {
+		cs, err := secretValue(ctx, kube, &o.ClientSecretSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errCannotReadOAuthSecret, err)
+		}
+		s.OAuth = &OAuth{
+			TokenEndpoint:     o.TokenEndpoint,
+			ClientID:          o.ClientID,
+			ClientSecret:      cs,
+			Scopes:            o.Scopes,
+			Audience:          o.Audience,
+			Extensions:        o.Extensions,
+			TokenExpiryWindow: o.TokenExpiryWindow,
+		}
+	}
+	if k := in.Kerberos; k != nil {
+		s.Kerberos = &Kerberos{
+			Principal:       k.Principal,
+			Realm:           k.Realm,
+			ServiceName:     k.ServiceName,
+			Krb5Conf:        k.Krb5Conf,
+			KeytabPath:      k.KeytabPath,
+			DisablePAFXFAST: k.DisablePAFXFAST,
+		}
+		if sr := k.KeytabSecretRef; sr != nil {
+			s.Kerberos.KeytabSecretRef = &KeytabSecretRef{Name: sr.Name, Namespace: sr.Namespace, KeytabField: sr.Key}
+		}
+	}
+	return s, nil
+}
+
+func tlsFromConnection(in *v1alpha1.TLSConfig) *TLS {
+	t := &TLS{
+		CACertificateFile:           in.CACertificateFile,
+		CipherSuites:                in.CipherSuites,
+		ClientSessionCacheCapacity:  in.ClientSessionCacheCapacity,
+		CurvePreferences:            in.CurvePreferences,
+		DialTimeoutSeconds:          in.DialTimeoutSeconds,
+		DynamicRecordSizingDisabled: in.DynamicRecordSizingDisabled,
+		InsecureSkipVerify:          in.InsecureSkipVerify,
+		MaxVersion:                  in.MaxVersion,
+		MinVersion:                  in.MinVersion,
+		NextProtos:                  in.NextProtos,
+		ServerName:                  in.ServerName,
+		SessionTicketsDisabled:      in.SessionTicketsDisabled,
+	}
+	if sr := in.CACertificateSecretRef; sr != nil {
+		t.CACertificateSecretRef = &CACertificateSecretRef{Name: sr.Name, Namespace: sr.Namespace, CAField: sr.Key}
+	}
+	if sr := in.ClientCertificateSecretRef; sr != nil {
+		t.ClientCertificateSecretRef = &ClientCertificateSecretRef{
+			Name:      sr.Name,
+			Namespace: sr.Namespace,
+			CertField: sr.CertField,
+			KeyField:  sr.KeyField,
+		}
+	}
+	if p := in.ClientCertificatePath; p != nil {
+		t.ClientCertificatePath = &ClientCertificatePath{CertFile: p.CertFile, KeyFile: p.KeyFile}
+	}
+	return t
+}
+
+func secretValue(ctx context.Context, kube client.Client, sel *xpv2.SecretKeySelector) (string, error) {
+	b, err := resource.ExtractSecret(ctx, kube, xpv2.CommonCredentialSelectors{SecretRef: sel})
+	if err != nil {
+		return "", err
+	}
+	if len(b) == 0 {
+		return "", fmt.Errorf("missing or empty field %q in secret %s/%s", sel.Key, sel.Namespace, sel.Name)
+	}
+	return string(b), nil
+}
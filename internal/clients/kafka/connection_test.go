@@ -0,0 +1,182 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestMergeConnection(t *testing.T) {
+	t.Parallel()
+
+	selector := func(key string) *xpv2.SecretKeySelector {
+		return &xpv2.SecretKeySelector{SecretReference: xpv2.SecretReference{Name: "kafka", Namespace: "crossplane-system"}, Key: key}
+	}
+	secrets := &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte("s3cret"), "clientSecret": []byte("oauth-s3cret")}
+		return nil
+	}}
+	jsonCreds := []byte(`{
+		"brokers": ["json:9092"],
+		"sasl": {"mechanism": "PLAIN", "username": "json-user", "password": "json-pass"},
+		"tls": {"insecureSkipVerify": true}
+	}`)
+
+	cases := map[string]struct {
+		data    []byte
+		conn    *v1alpha1.ConnectionConfig
+		kube    client.Client
+		want    Config
+		wantErr bool
+	}{
+		"CredentialsOnly": {
+			data: jsonCreds,
+			want: Config{
+				Brokers: []string{"json:9092"},
+				SASL:    &SASL{Mechanism: "PLAIN", Username: "json-user", Password: "json-pass"},
+				TLS:     &TLS{InsecureSkipVerify: true},
+			},
+		},
+		"ConnectionOnly": {
+			conn: &v1alpha1.ConnectionConfig{
+				Brokers: []string{"typed:9092"},
+				SASL:    &v1alpha1.SASLConfig{Mechanism: "SCRAM-SHA-512", Username: "typed-user", PasswordSecretRef: selector("password")},
+			},
+			kube: secrets,
+			want: Config{
+				Brokers: []string{"typed:9092"},
+				SASL:    &SASL{Mechanism: "SCRAM-SHA-512", Username: "typed-user", Password: "s3cret"},
+			},
+		},
+		"BrokersOverridden": {
+			data: jsonCreds,
+			conn: &v1alpha1.ConnectionConfig{Brokers: []string{"typed:9092"}},
+			want: Config{
+				Brokers: []string{"typed:9092"},
+				SASL:    &SASL{Mechanism: "PLAIN", Username: "json-user", Password: "json-pass"},
+				TLS:     &TLS{InsecureSkipVerify: true},
+			},
+		},
+		"ClientSettings": {
+			data: jsonCreds,
+			conn: &v1alpha1.ConnectionConfig{Client: &v1alpha1.ClientConfig{DialTimeout: "5s", RequestRetries: ptr(3), ClientID: "provider-kafka"}},
+			want: Config{
+				Brokers: []string{"json:9092"},
+				SASL:    &SASL{Mechanism: "PLAIN", Username: "json-user", Password: "json-pass"},
+				TLS:     &TLS{InsecureSkipVerify: true},
+				Client:  &ClientSettings{DialTimeout: "5s", RequestRetries: ptr(3), ClientID: "provider-kafka"},
+			},
+		},
+		"BlocksReplacedNotMerged": {
+			data: jsonCreds,
+			conn: &v1alpha1.ConnectionConfig{
+				SASL: &v1alpha1.SASLConfig{Mechanism: "AWS-MSK-IAM", RoleArn: "arn:aws:iam::123456789012:role/kafka"},
+				TLS: &v1alpha1.TLSConfig{
+					MinVersion:             "TLS12",
+					CACertificateSecretRef: selector("ca.crt"),
+					ClientCertificateSecretRef: &v1alpha1.ClientCertificateSecretReference{
+						SecretReference: xpv2.SecretReference{Name: "client", Namespace: "crossplane-system"},
+					},
+				},
+			},
+			want: Config{
+				Brokers: []string{"json:9092"},
+				SASL:    &SASL{Mechanism: "AWS-MSK-IAM", RoleArn: "arn:aws:iam::123456789012:role/kafka"},
+				TLS: &TLS{
+					MinVersion:                 "TLS12",
+					CACertificateSecretRef:     &CACertificateSecretRef{Name: "kafka", Namespace: "crossplane-system", CAField: "ca.crt"},
+					ClientCertificateSecretRef: &ClientCertificateSecretRef{Name: "client", Namespace: "crossplane-system"},
+				},
+			},
+		},
+		"OAuthAndKerberos": {
+			conn: &v1alpha1.ConnectionConfig{
+				Brokers: []string{"typed:9092"},
+				SASL: &v1alpha1.SASLConfig{
+					Mechanism: "OAUTHBEARER",
+					OAuth: &v1alpha1.OAuthConfig{
+						TokenEndpoint:         "https://idp.example.com/token",
+						ClientID:              "provider-kafka",
+						ClientSecretSecretRef: *selector("clientSecret"),
+						Scopes:                []string{"kafka"},
+					},
+					Kerberos: &v1alpha1.KerberosConfig{Principal: "kafka-admin", Realm: "EXAMPLE.COM", KeytabSecretRef: selector("krb5.keytab")},
+				},
+			},
+			kube: secrets,
+			want: Config{
+				Brokers: []string{"typed:9092"},
+				SASL: &SASL{
+					Mechanism: "OAUTHBEARER",
+					OAuth: &OAuth{
+						TokenEndpoint: "https://idp.example.com/token",
+						ClientID:      "provider-kafka",
+						ClientSecret:  "oauth-s3cret",
+						Scopes:        []string{"kafka"},
+					},
+					Kerberos: &Kerberos{
+						Principal:       "kafka-admin",
+						Realm:           "EXAMPLE.COM",
+						KeytabSecretRef: &KeytabSecretRef{Name: "kafka", Namespace: "crossplane-system", KeytabField: "krb5.keytab"},
+					},
+				},
+			},
+		},
+		"MissingSecretKey": {
+			conn: &v1alpha1.ConnectionConfig{
+				SASL: &v1alpha1.SASLConfig{Mechanism: "PLAIN", Username: "typed-user", PasswordSecretRef: selector("nope")},
+			},
+			kube:    secrets,
+			wantErr: true,
+		},
+		"SecretNotFound": {
+			conn: &v1alpha1.ConnectionConfig{
+				SASL: &v1alpha1.SASLConfig{Mechanism: "PLAIN", Username: "typed-user", PasswordSecretRef: selector("password")},
+			},
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			wantErr: true,
+		},
+		"InvalidCredentials": {
+			data:    []byte("brokers: [a]"),
+			conn:    &v1alpha1.ConnectionConfig{Brokers: []string{"typed:9092"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := MergeConnection(context.Background(), tc.kube, tc.data, tc.conn)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			kc, err := ParseConfig(got)
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, kc); diff != "" {
+				t.Errorf("MergeConnection(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeConnection_NoConnection(t *testing.T) {
+	t.Parallel()
+
+	// The credentials are passed through byte for byte, so that existing
+	// clients stay cached.
+	data := []byte(`{"brokers": ["json:9092"]}`)
+	got, err := MergeConnection(context.Background(), nil, data, nil)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
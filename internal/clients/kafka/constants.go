@@ -7,49 +7,101 @@ import (
 
 const (
 	defaultIAMCredentialsExpiryWindow = 5 * time.Minute
+	defaultOAuthTokenExpiryWindow     = time.Minute
+	oauthTokenRequestTimeout          = 30 * time.Second
 
 	// ACL resource types
 	ACLResourceTypeTopic           = "Topic"
 	ACLResourceTypeGroup           = "Group"
 	ACLResourceTypeTransactionalID = "TransactionalID"
 	ACLResourceTypeCluster         = "Cluster"
+	ACLResourceTypeDelegationToken = "DelegationToken"
+	ACLResourceTypeUser            = "User"
 	ACLResourceTypeAny             = "Any"
 
 	// ACL operations
-	ACLOperationAlterConfigs = "AlterConfigs"
-	ACLOperationRead         = "Read"
-	ACLOperationWrite        = "Write"
-	ACLOperationDescribe     = "Describe"
+	ACLOperationAlterConfigs    = "AlterConfigs"
+	ACLOperationRead            = "Read"
+	ACLOperationWrite           = "Write"
+	ACLOperationDescribe        = "Describe"
+	ACLOperationIdempotentWrite = "IdempotentWrite"
+	ACLOperationCreateTokens    = "CreateTokens"
+	ACLOperationDescribeTokens  = "DescribeTokens"
+	ACLOperationAll             = "All"
+	ACLOperationAny             = "Any"
 
 	// ACL permission and pattern types
-	ACLPermissionTypeAllow = "Allow"
-	ACLPatternTypeLiteral  = "Literal"
+	ACLPermissionTypeAllow   = "Allow"
+	ACLPermissionTypeDeny    = "Deny"
+	ACLPermissionTypeAny     = "Any"
+	ACLPermissionTypeUnknown = "Unknown"
+	ACLPatternTypeLiteral    = "Literal"
+
+	// ACLClusterName is the name of the only Cluster ACL resource.
+	ACLClusterName = "kafka-cluster"
 
 	// default Secret field names for TLS certificates, like managed by cert-manager
 	defaultCACertificateField         = "ca.crt"
 	defaultClientCertificateKeyField  = "tls.key"
 	defaultClientCertificateCertField = "tls.crt"
-	defaultTLSDialTimeoutSeconds      = 10
+
+	// default Secret field name for Kerberos keytabs
+	defaultKeytabField = "krb5.keytab"
+
+	defaultKerberosServiceName = "kafka"
+
+	// default Secret field names for OAuth client credentials
+	defaultOAuthClientIDField     = "clientId"
+	defaultOAuthClientSecretField = "clientSecret"
+
+	// SASL mechanisms
+	saslMechanismPlain       = "plain"
+	saslMechanismScramSha256 = "scram-sha-256"
+	saslMechanismScramSha512 = "scram-sha-512"
+	saslMechanismAwsMskIam   = "aws-msk-iam"
+	saslMechanismOAuthBearer = "oauthbearer"
+	saslMechanismGSSAPI      = "gssapi"
 
 	tlsVersion12 = "TLS12"
 	tlsVersion13 = "TLS13"
 
 	errMissingBrokers                 = "at least one broker address is required"
+	errSecretRefWithoutKube           = "cannot read Secret references without a Kubernetes client"
 	errCannotAppendCACert             = "cannot append CA certificate to pool"
+	errCannotGetOAuthToken            = "cannot get OAuth token"
 	errCannotParse                    = "cannot parse credentials"
 	errCannotReadCACertFile           = "cannot read CA cert file"
 	errCannotReadCACertSecret         = "cannot read CA cert secret"
+	errCannotReadOAuthSecret          = "cannot read OAuth client secret"
+	errCannotReadSASLPasswordSecret   = "cannot read SASL password secret"
 	errCannotReadClientCertFile       = "cannot read client cert file"
 	errCannotReadClientCertSecret     = "cannot read client cert secret"
+	errCannotReadKeytabFile           = "cannot read keytab file"
+	errCannotReadKeytabSecret         = "cannot read keytab secret"
 	errInvalidCipherSuite             = "invalid cipher suite"
 	errInvalidCipherSuiteTLS13        = "cipherSuites cannot be configured in TLS13"
+	errInvalidClientSettings          = "invalid client settings"
 	errInvalidClientSessionCache      = "invalid client session cache capacity: must be >= 0"
 	errInvalidCurve                   = "invalid curve preference"
 	errInvalidDialTimeout             = "invalid dial timeout: must be >= 0"
+	errInvalidKeytab                  = "invalid keytab"
+	errInvalidKrb5Conf                = "invalid krb5.conf"
+	errInvalidOAuthTokenEndpoint      = "invalid OAuth token endpoint: must be an http or https URL"
+	errInvalidOAuthTokenExpiryWindow  = "invalid OAuth token expiry window"
 	errInvalidTLSVersion              = "invalid TLS version"
 	errMissingCACertSecretRefKeys     = "missing CA cert ref secret name or namespace"
 	errMissingClientCertFileKeys      = "missing client certificate keyFile or certFile"
 	errMissingClientCertSecretRefKeys = "missing client cert ref secret name or namespace"
+	errMissingKerberosConfig          = "SASL kerberos configuration is required for the GSSAPI mechanism"
+	errMissingKerberosPrincipal       = "Kerberos principal and realm are required"
+	errMissingKeytab                  = "one of Kerberos keytabSecretRef and keytabPath is required"
+	errMissingKeytabSecretRefKeys     = "missing keytab secret ref name or namespace"
+	errMissingKrb5Conf                = "Kerberos krb5Conf is required"
+	errMultipleKeytabs                = "cannot specify both Kerberos keytabSecretRef and keytabPath"
+	errMissingOAuthConfig             = "SASL oauth configuration is required for the OAUTHBEARER mechanism"
+	errMissingOAuthCredentials        = "OAuth clientId and clientSecret (or clientSecretRef) are required"
+	errMissingOAuthSecretRefKeys      = "missing OAuth client secret ref name or namespace"
+	errMultipleOAuthSecrets           = "cannot specify both OAuth clientSecret and clientSecretRef"
 	errMissingSASLCredentials         = "SASL username and password are required"
 	errMissingSASLMechanism           = "SASL mechanism is required"
 )
@@ -0,0 +1,254 @@
+package consumergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// AdminClient is the subset of kadm.Client methods used by this package.
+// *kadm.Client satisfies this interface without any changes to callers.
+type AdminClient interface {
+	DescribeGroups(ctx context.Context, groups ...string) (kadm.DescribedGroups, error)
+	FetchOffsets(ctx context.Context, group string) (kadm.OffsetResponses, error)
+	ListStartOffsets(ctx context.Context, topics ...string) (kadm.ListedOffsets, error)
+	ListEndOffsets(ctx context.Context, topics ...string) (kadm.ListedOffsets, error)
+	ListOffsetsAfterMilli(ctx context.Context, millisecond int64, topics ...string) (kadm.ListedOffsets, error)
+	CommitOffsets(ctx context.Context, group string, os kadm.Offsets) (kadm.OffsetResponses, error)
+	DeleteGroups(ctx context.Context, groups ...string) (kadm.DeleteGroupResponses, error)
+}
+
+// ConsumerGroup is a holistic representation of a Kafka consumer group and
+// the lag of its committed offsets.
+type ConsumerGroup struct {
+	Name         string
+	State        string
+	ProtocolType string
+	Members      int
+	Lag          kadm.GroupLag
+}
+
+// Offset reset strategies.
+const (
+	StrategyEarliest  = "Earliest"
+	StrategyLatest    = "Latest"
+	StrategyTimestamp = "Timestamp"
+	StrategyExplicit  = "Explicit"
+)
+
+// Consumer group states reported by Kafka.
+const (
+	StateEmpty = "Empty"
+	StateDead  = "Dead"
+)
+
+const (
+	errCannotDescribeGroup   = "cannot describe consumer group"
+	errCannotFetchOffsets    = "cannot fetch committed offsets"
+	errCannotListOffsets     = "cannot list offsets"
+	errCannotCommitOffsets   = "cannot commit offsets"
+	errCannotDeleteGroup     = "cannot delete consumer group"
+	errNoDeleteResponse      = "no delete response for consumer group"
+	errUnknownStrategy       = "unknown offset reset strategy"
+	errMissingTimestamp      = "offset reset strategy Timestamp requires a timestamp"
+	errNoTopicsToReset       = "no topics to reset offsets for"
+	errGroupNotFoundDescribe = "cannot find consumer group in describe result"
+
+	// ErrGroupNotEmpty indicates that offsets cannot be reset because the
+	// group has active members
+	ErrGroupNotEmpty = "consumer group has active members"
+)
+
+// Get gets the consumer group from the Kafka side, including the committed
+// offsets and lag of every partition it has committed or been assigned.
+// A group that does not exist is reported in state Dead.
+func Get(ctx context.Context, client AdminClient, name string) (*ConsumerGroup, error) {
+	g, err := describe(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := client.FetchOffsets(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotFetchOffsets, err)
+	}
+
+	topics := commits.Partitions()
+	topics.Merge(g.AssignedPartitions())
+
+	var ends kadm.ListedOffsets
+	if t := topics.Topics(); len(t) > 0 {
+		ends, err = client.ListEndOffsets(ctx, t...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errCannotListOffsets, err)
+		}
+	}
+
+	return &ConsumerGroup{
+		Name:         name,
+		State:        g.State,
+		ProtocolType: g.ProtocolType,
+		Members:      len(g.Members),
+		Lag:          kadm.CalculateGroupLag(g, commits, ends),
+	}, nil
+}
+
+// Exists returns true unless Kafka reports the group as Dead, which is the
+// state of groups that do not exist.
+func (g *ConsumerGroup) Exists() bool {
+	return g.State != StateDead
+}
+
+func describe(ctx context.Context, client AdminClient, name string) (kadm.DescribedGroup, error) {
+	described, err := client.DescribeGroups(ctx, name)
+	if err != nil {
+		return kadm.DescribedGroup{}, fmt.Errorf("%s: %w", errCannotDescribeGroup, err)
+	}
+	g, ok := described[name]
+	if !ok {
+		return kadm.DescribedGroup{}, errors.New(errGroupNotFoundDescribe)
+	}
+	if g.Err != nil && !errors.Is(g.Err, kerr.GroupIDNotFound) {
+		return kadm.DescribedGroup{}, fmt.Errorf("%s: %w", errCannotDescribeGroup, g.Err)
+	}
+	if g.Err != nil {
+		g.State = StateDead
+	}
+	return g, nil
+}
+
+// ResetOffsets commits the offsets described by reset for the group. Offsets
+// can only be reset while the group has no active members.
+func ResetOffsets(ctx context.Context, client AdminClient, name string, reset *v1alpha1.ConsumerGroupOffsetReset) error {
+	g, err := describe(ctx, client, name)
+	if err != nil {
+		return err
+	}
+	if g.State != StateEmpty && g.State != StateDead {
+		return fmt.Errorf("%s: group is %s with %d members", ErrGroupNotEmpty, g.State, len(g.Members))
+	}
+
+	os, err := resetOffsets(ctx, client, name, reset)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.CommitOffsets(ctx, name, os)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotCommitOffsets, err)
+	}
+	if err := resp.Error(); err != nil {
+		return fmt.Errorf("%s: %w", errCannotCommitOffsets, err)
+	}
+	return nil
+}
+
+// resetOffsets returns the offsets to commit for reset.
+func resetOffsets(ctx context.Context, client AdminClient, name string, reset *v1alpha1.ConsumerGroupOffsetReset) (kadm.Offsets, error) {
+	if reset.Strategy == StrategyExplicit {
+		os := make(kadm.Offsets)
+		for _, o := range reset.Offsets {
+			os.Add(kadm.Offset{Topic: o.Topic, Partition: o.Partition, At: o.Offset, LeaderEpoch: -1})
+		}
+		return os, nil
+	}
+
+	topics := reset.Topics
+	if len(topics) == 0 {
+		commits, err := client.FetchOffsets(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errCannotFetchOffsets, err)
+		}
+		topics = commits.Partitions().Topics()
+	}
+	if len(topics) == 0 {
+		return nil, errors.New(errNoTopicsToReset)
+	}
+
+	var (
+		listed kadm.ListedOffsets
+		err    error
+	)
+	switch reset.Strategy {
+	case StrategyEarliest:
+		listed, err = client.ListStartOffsets(ctx, topics...)
+	case StrategyLatest:
+		listed, err = client.ListEndOffsets(ctx, topics...)
+	case StrategyTimestamp:
+		if reset.Timestamp == nil {
+			return nil, errors.New(errMissingTimestamp)
+		}
+		listed, err = client.ListOffsetsAfterMilli(ctx, reset.Timestamp.UnixMilli(), topics...)
+	default:
+		return nil, fmt.Errorf("%s: %q", errUnknownStrategy, reset.Strategy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotListOffsets, err)
+	}
+	if err := listed.Error(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotListOffsets, err)
+	}
+	return listed.Offsets(), nil
+}
+
+// Delete deletes the consumer group from the Kafka side. Deleting a group
+// that does not exist is not an error.
+func Delete(ctx context.Context, client AdminClient, name string) error {
+	resp, err := client.DeleteGroups(ctx, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotDeleteGroup, err)
+	}
+	r, ok := resp[name]
+	if !ok {
+		return errors.New(errNoDeleteResponse)
+	}
+	if r.Err != nil && !errors.Is(r.Err, kerr.GroupIDNotFound) {
+		return fmt.Errorf("%s: %w", errCannotDeleteGroup, r.Err)
+	}
+	return nil
+}
+
+// ToObservation converts the consumer group to a ConsumerGroupObservation.
+func (g *ConsumerGroup) ToObservation() v1alpha1.ConsumerGroupObservation {
+	o := v1alpha1.ConsumerGroupObservation{
+		State:        g.State,
+		ProtocolType: g.ProtocolType,
+		Members:      g.Members,
+	}
+	for _, l := range g.Lag.Sorted() {
+		if l.Partition < 0 {
+			// kadm reports missing topics under partition -1.
+			continue
+		}
+		p := v1alpha1.ConsumerGroupPartitionLag{
+			Topic:           l.Topic,
+			Partition:       l.Partition,
+			CommittedOffset: l.Commit.At,
+			EndOffset:       l.End.Offset,
+			Lag:             l.Lag,
+		}
+		if l.Member != nil {
+			p.MemberID = l.Member.MemberID
+		}
+		if l.Lag > 0 {
+			o.TotalLag += l.Lag
+		}
+		o.Partitions = append(o.Partitions, p)
+	}
+	return o
+}
+
+// IsUpToDate returns true if the requested offset reset, if any, has already
+// been applied.
+func IsUpToDate(in *v1alpha1.ConsumerGroupParameters, applied *v1alpha1.ConsumerGroupOffsetReset) bool {
+	if in.OffsetReset == nil {
+		return true
+	}
+	return equality.Semantic.DeepEqual(in.OffsetReset, applied)
+}
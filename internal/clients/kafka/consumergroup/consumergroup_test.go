@@ -0,0 +1,315 @@
+package consumergroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const (
+	testGroup  = "orders-consumer"
+	testTopic  = "orders"
+	testTopic2 = "payments"
+)
+
+// fakeGroupAdmin is an in-process implementation of AdminClient for unit tests.
+type fakeGroupAdmin struct {
+	described   kadm.DescribedGroups
+	describeErr error
+	commits     kadm.OffsetResponses
+	start       kadm.ListedOffsets
+	end         kadm.ListedOffsets
+	afterMilli  kadm.ListedOffsets
+	commitErr   error
+	deleted     kadm.DeleteGroupResponses
+
+	listedTopics []string
+	millis       int64
+	committed    kadm.Offsets
+}
+
+func (f *fakeGroupAdmin) DescribeGroups(_ context.Context, _ ...string) (kadm.DescribedGroups, error) {
+	return f.described, f.describeErr
+}
+
+func (f *fakeGroupAdmin) FetchOffsets(_ context.Context, _ string) (kadm.OffsetResponses, error) {
+	return f.commits, nil
+}
+
+func (f *fakeGroupAdmin) ListStartOffsets(_ context.Context, topics ...string) (kadm.ListedOffsets, error) {
+	f.listedTopics = topics
+	return f.start, nil
+}
+
+func (f *fakeGroupAdmin) ListEndOffsets(_ context.Context, topics ...string) (kadm.ListedOffsets, error) {
+	f.listedTopics = topics
+	return f.end, nil
+}
+
+func (f *fakeGroupAdmin) ListOffsetsAfterMilli(_ context.Context, millis int64, topics ...string) (kadm.ListedOffsets, error) {
+	f.millis = millis
+	f.listedTopics = topics
+	return f.afterMilli, nil
+}
+
+func (f *fakeGroupAdmin) CommitOffsets(_ context.Context, _ string, os kadm.Offsets) (kadm.OffsetResponses, error) {
+	f.committed = os
+	resp := make(kadm.OffsetResponses)
+	os.Each(func(o kadm.Offset) {
+		if resp[o.Topic] == nil {
+			resp[o.Topic] = make(map[int32]kadm.OffsetResponse)
+		}
+		resp[o.Topic][o.Partition] = kadm.OffsetResponse{Offset: o, Err: f.commitErr}
+	})
+	return resp, nil
+}
+
+func (f *fakeGroupAdmin) DeleteGroups(_ context.Context, _ ...string) (kadm.DeleteGroupResponses, error) {
+	return f.deleted, nil
+}
+
+func listed(topic string, offsets ...int64) kadm.ListedOffsets {
+	ps := make(map[int32]kadm.ListedOffset, len(offsets))
+	for p, o := range offsets {
+		ps[int32(p)] = kadm.ListedOffset{Topic: topic, Partition: int32(p), Offset: o, LeaderEpoch: -1}
+	}
+	return kadm.ListedOffsets{topic: ps}
+}
+
+func commits(topic string, offsets ...int64) kadm.OffsetResponses {
+	ps := make(map[int32]kadm.OffsetResponse, len(offsets))
+	for p, o := range offsets {
+		ps[int32(p)] = kadm.OffsetResponse{Offset: kadm.Offset{Topic: topic, Partition: int32(p), At: o}}
+	}
+	return kadm.OffsetResponses{topic: ps}
+}
+
+func group(state string, members ...kadm.DescribedGroupMember) kadm.DescribedGroups {
+	return kadm.DescribedGroups{testGroup: {Group: testGroup, State: state, ProtocolType: "consumer", Members: members}}
+}
+
+func TestGet(t *testing.T) {
+	cases := map[string]struct {
+		cl      *fakeGroupAdmin
+		want    v1alpha1.ConsumerGroupObservation
+		exists  bool
+		wantErr bool
+	}{
+		"EmptyGroupWithLag": {
+			cl: &fakeGroupAdmin{
+				described: group(StateEmpty),
+				commits:   commits(testTopic, 5, 10),
+				end:       listed(testTopic, 8, 10),
+			},
+			want: v1alpha1.ConsumerGroupObservation{
+				State:        StateEmpty,
+				ProtocolType: "consumer",
+				TotalLag:     3,
+				Partitions: []v1alpha1.ConsumerGroupPartitionLag{
+					{Topic: testTopic, Partition: 0, CommittedOffset: 5, EndOffset: 8, Lag: 3},
+					{Topic: testTopic, Partition: 1, CommittedOffset: 10, EndOffset: 10, Lag: 0},
+				},
+			},
+			exists: true,
+		},
+		"DeadGroup": {
+			cl: &fakeGroupAdmin{described: group(StateDead)},
+			want: v1alpha1.ConsumerGroupObservation{
+				State:        StateDead,
+				ProtocolType: "consumer",
+			},
+		},
+		"GroupIDNotFound": {
+			cl: &fakeGroupAdmin{described: kadm.DescribedGroups{testGroup: {Group: testGroup, Err: kerr.GroupIDNotFound}}},
+			want: v1alpha1.ConsumerGroupObservation{
+				State: StateDead,
+			},
+		},
+		"DescribeError": {
+			cl:      &fakeGroupAdmin{describeErr: errors.New("boom")},
+			wantErr: true,
+		},
+		"GroupError": {
+			cl:      &fakeGroupAdmin{described: kadm.DescribedGroups{testGroup: {Group: testGroup, Err: kerr.GroupAuthorizationFailed}}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Get(context.Background(), tc.cl, testGroup)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.exists, got.Exists())
+			if diff := cmp.Diff(tc.want, got.ToObservation()); diff != "" {
+				t.Errorf("Get(...).ToObservation(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResetOffsets(t *testing.T) {
+	ts := metav1.NewTime(time.UnixMilli(1700000000000))
+
+	cases := map[string]struct {
+		cl         *fakeGroupAdmin
+		reset      v1alpha1.ConsumerGroupOffsetReset
+		want       kadm.Offsets
+		wantTopics []string
+		wantMillis int64
+		wantErr    string
+	}{
+		"Earliest": {
+			cl: &fakeGroupAdmin{
+				described: group(StateEmpty),
+				commits:   commits(testTopic, 5),
+				start:     listed(testTopic, 2),
+			},
+			reset:      v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyEarliest},
+			want:       listed(testTopic, 2).Offsets(),
+			wantTopics: []string{testTopic},
+		},
+		"LatestForListedTopics": {
+			cl: &fakeGroupAdmin{
+				described: group(StateDead),
+				end:       listed(testTopic2, 40),
+			},
+			reset:      v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyLatest, Topics: []string{testTopic2}},
+			want:       listed(testTopic2, 40).Offsets(),
+			wantTopics: []string{testTopic2},
+		},
+		"Timestamp": {
+			cl: &fakeGroupAdmin{
+				described:  group(StateEmpty),
+				commits:    commits(testTopic, 5),
+				afterMilli: listed(testTopic, 3),
+			},
+			reset:      v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyTimestamp, Timestamp: &ts},
+			want:       listed(testTopic, 3).Offsets(),
+			wantTopics: []string{testTopic},
+			wantMillis: 1700000000000,
+		},
+		"Explicit": {
+			cl: &fakeGroupAdmin{described: group(StateEmpty)},
+			reset: v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyExplicit, Offsets: []v1alpha1.ConsumerGroupPartitionOffset{
+				{Topic: testTopic, Partition: 1, Offset: 42},
+			}},
+			want: kadm.Offsets{testTopic: {1: {Topic: testTopic, Partition: 1, At: 42, LeaderEpoch: -1}}},
+		},
+		"ActiveMembers": {
+			cl:      &fakeGroupAdmin{described: group("Stable", kadm.DescribedGroupMember{MemberID: "m-1"})},
+			reset:   v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyEarliest},
+			wantErr: ErrGroupNotEmpty,
+		},
+		"NoTopics": {
+			cl:      &fakeGroupAdmin{described: group(StateEmpty)},
+			reset:   v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyEarliest},
+			wantErr: errNoTopicsToReset,
+		},
+		"CommitError": {
+			cl: &fakeGroupAdmin{
+				described: group(StateEmpty),
+				commits:   commits(testTopic, 5),
+				start:     listed(testTopic, 2),
+				commitErr: kerr.UnknownMemberID,
+			},
+			reset:   v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyEarliest},
+			wantErr: errCannotCommitOffsets,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ResetOffsets(context.Background(), tc.cl, testGroup, &tc.reset)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, tc.cl.committed); diff != "" {
+				t.Errorf("ResetOffsets(...): -want committed, +got committed:\n%s", diff)
+			}
+			assert.Equal(t, tc.wantTopics, tc.cl.listedTopics)
+			assert.Equal(t, tc.wantMillis, tc.cl.millis)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		resp    kadm.DeleteGroupResponses
+		wantErr bool
+	}{
+		"Deleted": {
+			resp: kadm.DeleteGroupResponses{testGroup: {Group: testGroup}},
+		},
+		"AlreadyGone": {
+			resp: kadm.DeleteGroupResponses{testGroup: {Group: testGroup, Err: kerr.GroupIDNotFound}},
+		},
+		"NotEmpty": {
+			resp:    kadm.DeleteGroupResponses{testGroup: {Group: testGroup, Err: kerr.NonEmptyGroup}},
+			wantErr: true,
+		},
+		"NoResponse": {
+			resp:    kadm.DeleteGroupResponses{},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Delete(context.Background(), &fakeGroupAdmin{deleted: tc.resp}, testGroup)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	earliest := &v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyEarliest}
+
+	cases := map[string]struct {
+		in      v1alpha1.ConsumerGroupParameters
+		applied *v1alpha1.ConsumerGroupOffsetReset
+		want    bool
+	}{
+		"NoReset": {
+			want: true,
+		},
+		"NotApplied": {
+			in: v1alpha1.ConsumerGroupParameters{OffsetReset: earliest},
+		},
+		"Applied": {
+			in:      v1alpha1.ConsumerGroupParameters{OffsetReset: earliest},
+			applied: earliest.DeepCopy(),
+			want:    true,
+		},
+		"RevisionChanged": {
+			in:      v1alpha1.ConsumerGroupParameters{OffsetReset: &v1alpha1.ConsumerGroupOffsetReset{Strategy: StrategyEarliest, Revision: "2"}},
+			applied: earliest.DeepCopy(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsUpToDate(&tc.in, tc.applied))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package delegationtoken
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// AdminClient is the subset of kadm.Client methods used by this package.
+// *kadm.Client satisfies this interface without any changes to callers.
+type AdminClient interface {
+	CreateDelegationToken(ctx context.Context, d kadm.CreateDelegationToken) (kadm.DelegationToken, error)
+	RenewDelegationToken(ctx context.Context, hmac []byte, renewTime time.Duration) (time.Time, error)
+	ExpireDelegationToken(ctx context.Context, hmac []byte, expiry time.Duration) (time.Time, error)
+	DescribeDelegationTokens(ctx context.Context, owners ...kadm.Principal) (kadm.DelegationTokens, error)
+}
+
+// Connection detail keys published for a DelegationToken.
+const (
+	ConnectionKeyTokenID = "tokenId"
+	ConnectionKeyHMAC    = "hmac"
+	ConnectionKeyBrokers = "brokers"
+)
+
+// DefaultRenewBefore is used when no renewal margin is configured.
+const DefaultRenewBefore = time.Hour
+
+const (
+	// serverDefault makes Kafka use its configured default for the max
+	// lifetime or renew period of a token, as kadm sends durations in
+	// milliseconds.
+	serverDefault = -time.Millisecond
+	// expireNow makes Kafka expire a token immediately, as a negative expiry
+	// period expires it at the time of the request.
+	expireNow = -time.Millisecond
+)
+
+const (
+	errCannotCreate     = "cannot create delegation token"
+	errCannotDescribe   = "cannot describe delegation tokens"
+	errCannotExpire     = "cannot expire delegation token"
+	errCannotRenew      = "cannot renew delegation token"
+	errInvalidPrincipal = "principal is not of the form Type:Name"
+)
+
+// ParsePrincipal converts a principal such as User:alice to its kadm
+// representation.
+func ParsePrincipal(s string) (kadm.Principal, error) {
+	typ, name, ok := strings.Cut(s, ":")
+	if !ok || typ == "" || name == "" {
+		return kadm.Principal{}, fmt.Errorf("%s: %q", errInvalidPrincipal, s)
+	}
+	return kadm.Principal{Type: typ, Name: name}, nil
+}
+
+// principalString converts a kadm principal to the notation of ACLs.
+func principalString(p kadm.Principal) string {
+	if p.Type == "" {
+		return "User:" + p.Name
+	}
+	return p.Type + ":" + p.Name
+}
+
+// Get returns the delegation token of the ID, or nil if Kafka has no such
+// token. Only the tokens of the owner are described if it is not empty.
+func Get(ctx context.Context, client AdminClient, owner, id string) (*kadm.DelegationToken, error) {
+	var owners []kadm.Principal
+	if owner != "" {
+		p, err := ParsePrincipal(owner)
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, p)
+	}
+	tokens, err := client.DescribeDelegationTokens(ctx, owners...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribe, err)
+	}
+	for i := range tokens {
+		if tokens[i].TokenID == id {
+			return &tokens[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Create creates a delegation token with the owner, renewers and max lifetime
+// of the parameters.
+func Create(ctx context.Context, client AdminClient, params *v1alpha1.DelegationTokenParameters) (kadm.DelegationToken, error) {
+	req := kadm.CreateDelegationToken{MaxLifetime: serverDefault}
+	if params.Owner != "" {
+		p, err := ParsePrincipal(params.Owner)
+		if err != nil {
+			return kadm.DelegationToken{}, err
+		}
+		req.Owner = &p
+	}
+	for _, r := range params.Renewers {
+		p, err := ParsePrincipal(r)
+		if err != nil {
+			return kadm.DelegationToken{}, err
+		}
+		req.Renewers = append(req.Renewers, p)
+	}
+	if params.MaxLifetime != nil {
+		req.MaxLifetime = params.MaxLifetime.Duration
+	}
+	t, err := client.CreateDelegationToken(ctx, req)
+	if err != nil {
+		return kadm.DelegationToken{}, fmt.Errorf("%s: %w", errCannotCreate, err)
+	}
+	return t, nil
+}
+
+// Replace expires the delegation token of the ID, if Kafka still has it, and
+// creates a new token with the parameters, so that the replaced token does not
+// linger in Kafka.
+func Replace(ctx context.Context, client AdminClient, params *v1alpha1.DelegationTokenParameters, id string) (kadm.DelegationToken, error) {
+	if id != "" {
+		old, err := Get(ctx, client, params.Owner, id)
+		if err != nil {
+			return kadm.DelegationToken{}, err
+		}
+		if old != nil {
+			if err := Expire(ctx, client, old.HMAC); err != nil {
+				return kadm.DelegationToken{}, err
+			}
+		}
+	}
+	return Create(ctx, client, params)
+}
+
+// Renew renews the delegation token by the renew period the brokers are
+// configured with and returns its new expiry timestamp.
+func Renew(ctx context.Context, client AdminClient, hmac []byte) (time.Time, error) {
+	expiry, err := client.RenewDelegationToken(ctx, hmac, serverDefault)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", errCannotRenew, err)
+	}
+	return expiry, nil
+}
+
+// Expire expires the delegation token immediately. Tokens that are already
+// expired or gone are ignored.
+func Expire(ctx context.Context, client AdminClient, hmac []byte) error {
+	_, err := client.ExpireDelegationToken(ctx, hmac, expireNow)
+	if err == nil || errors.Is(err, kerr.DelegationTokenNotFound) || errors.Is(err, kerr.DelegationTokenExpired) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", errCannotExpire, err)
+}
+
+// RenewBefore returns how long before it expires the token of the parameters
+// is renewed.
+func RenewBefore(params *v1alpha1.DelegationTokenParameters) time.Duration {
+	if params.RenewBefore == nil {
+		return DefaultRenewBefore
+	}
+	return params.RenewBefore.Duration
+}
+
+// IsExpired returns true if the token has expired at the time.
+func IsExpired(t *kadm.DelegationToken, now time.Time) bool {
+	return !now.Before(t.ExpiryTimestamp)
+}
+
+// NeedsRenewal returns true if the token expires within renewBefore of the
+// time and renewing it can still extend its expiry.
+func NeedsRenewal(t *kadm.DelegationToken, now time.Time, renewBefore time.Duration) bool {
+	return t.ExpiryTimestamp.Sub(now) < renewBefore && t.ExpiryTimestamp.Before(t.MaxTimestamp)
+}
+
+// NeedsReplacement returns true if the token reaches its max lifetime within
+// renewBefore of the time, so that renewing it cannot keep it valid. Tokens
+// whose whole lifetime is shorter than renewBefore are only replaced once they
+// expire, as their replacement would be due right away.
+func NeedsReplacement(t *kadm.DelegationToken, now time.Time, renewBefore time.Duration) bool {
+	return t.MaxTimestamp.Sub(now) < renewBefore && t.MaxTimestamp.Sub(t.IssueTimestamp) > renewBefore
+}
+
+// ToObservation converts a delegation token to a DelegationTokenObservation.
+func ToObservation(t *kadm.DelegationToken) v1alpha1.DelegationTokenObservation {
+	o := v1alpha1.DelegationTokenObservation{
+		TokenID:         t.TokenID,
+		Owner:           principalString(t.Owner),
+		IssueTimestamp:  &metav1.Time{Time: t.IssueTimestamp},
+		ExpiryTimestamp: &metav1.Time{Time: t.ExpiryTimestamp},
+		MaxTimestamp:    &metav1.Time{Time: t.MaxTimestamp},
+	}
+	for _, r := range t.Renewers {
+		o.Renewers = append(o.Renewers, principalString(r))
+	}
+	return o
+}
+
+// HMAC returns the HMAC of the token as clients use it for SCRAM password.
+func HMAC(t *kadm.DelegationToken) string {
+	return base64.StdEncoding.EncodeToString(t.HMAC)
+}
@@ -0,0 +1,270 @@
+package delegationtoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const testTokenID = "token-1"
+
+var testNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeTokenAdmin is an in-process implementation of AdminClient for unit tests.
+type fakeTokenAdmin struct {
+	tokens    kadm.DelegationTokens
+	err       error
+	owners    []kadm.Principal
+	created   *kadm.CreateDelegationToken
+	expired   []byte
+	expiryDur time.Duration
+}
+
+func (f *fakeTokenAdmin) CreateDelegationToken(_ context.Context, d kadm.CreateDelegationToken) (kadm.DelegationToken, error) {
+	f.created = &d
+	return kadm.DelegationToken{TokenID: testTokenID}, f.err
+}
+
+func (f *fakeTokenAdmin) RenewDelegationToken(_ context.Context, _ []byte, _ time.Duration) (time.Time, error) {
+	return testNow.Add(24 * time.Hour), f.err
+}
+
+func (f *fakeTokenAdmin) ExpireDelegationToken(_ context.Context, hmac []byte, expiry time.Duration) (time.Time, error) {
+	f.expired, f.expiryDur = hmac, expiry
+	return testNow, f.err
+}
+
+func (f *fakeTokenAdmin) DescribeDelegationTokens(_ context.Context, owners ...kadm.Principal) (kadm.DelegationTokens, error) {
+	f.owners = owners
+	return f.tokens, f.err
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	token := kadm.DelegationToken{TokenID: testTokenID, HMAC: []byte("secret")}
+	other := kadm.DelegationToken{TokenID: "token-2"}
+
+	cases := map[string]struct {
+		cl         *fakeTokenAdmin
+		owner      string
+		want       *kadm.DelegationToken
+		wantOwners []kadm.Principal
+		wantErr    bool
+	}{
+		"Found": {
+			cl:   &fakeTokenAdmin{tokens: kadm.DelegationTokens{other, token}},
+			want: &token,
+		},
+		"FoundForOwner": {
+			cl:         &fakeTokenAdmin{tokens: kadm.DelegationTokens{token}},
+			owner:      "User:alice",
+			want:       &token,
+			wantOwners: []kadm.Principal{{Type: "User", Name: "alice"}},
+		},
+		"NotFound": {
+			cl: &fakeTokenAdmin{tokens: kadm.DelegationTokens{other}},
+		},
+		"InvalidOwner": {
+			cl:      &fakeTokenAdmin{},
+			owner:   "alice",
+			wantErr: true,
+		},
+		"DescribeError": {
+			cl:      &fakeTokenAdmin{err: kerr.DelegationTokenAuthDisabled},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Get(context.Background(), tc.cl, tc.owner, testTokenID)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Get(...): -want, +got:\n%s", diff)
+			}
+			assert.Equal(t, tc.wantOwners, tc.cl.owners)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	owner := kadm.Principal{Type: "User", Name: "alice"}
+	cases := map[string]struct {
+		params v1alpha1.DelegationTokenParameters
+		want   kadm.CreateDelegationToken
+	}{
+		"Defaults": {
+			want: kadm.CreateDelegationToken{MaxLifetime: -time.Millisecond},
+		},
+		"OwnerRenewersAndMaxLifetime": {
+			params: v1alpha1.DelegationTokenParameters{
+				Owner:       "User:alice",
+				Renewers:    []string{"User:scheduler"},
+				MaxLifetime: &metav1.Duration{Duration: 12 * time.Hour},
+			},
+			want: kadm.CreateDelegationToken{
+				Owner:       &owner,
+				Renewers:    []kadm.Principal{{Type: "User", Name: "scheduler"}},
+				MaxLifetime: 12 * time.Hour,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeTokenAdmin{}
+			got, err := Create(context.Background(), cl, &tc.params)
+			require.NoError(t, err)
+			assert.Equal(t, testTokenID, got.TokenID)
+			if diff := cmp.Diff(&tc.want, cl.created); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	old := kadm.DelegationToken{TokenID: "token-0", HMAC: []byte("old")}
+	cases := map[string]struct {
+		id          string
+		tokens      kadm.DelegationTokens
+		wantExpired []byte
+	}{
+		"FirstToken": {},
+		"ExpireReplaced": {
+			id:          "token-0",
+			tokens:      kadm.DelegationTokens{old},
+			wantExpired: []byte("old"),
+		},
+		"ReplacedGone": {
+			id: "token-0",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeTokenAdmin{tokens: tc.tokens}
+			got, err := Replace(context.Background(), cl, &v1alpha1.DelegationTokenParameters{}, tc.id)
+			require.NoError(t, err)
+			assert.Equal(t, testTokenID, got.TokenID)
+			assert.Equal(t, tc.wantExpired, cl.expired)
+			assert.NotNil(t, cl.created, "Replace(...) should create a token")
+		})
+	}
+}
+
+func TestExpire(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		err     error
+		wantErr bool
+	}{
+		"Expired":      {},
+		"NotFound":     {err: kerr.DelegationTokenNotFound},
+		"AlreadyGone":  {err: kerr.DelegationTokenExpired},
+		"BrokerFailed": {err: errors.New("boom"), wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cl := &fakeTokenAdmin{err: tc.err}
+			err := Expire(context.Background(), cl, []byte("secret"))
+			assert.Equal(t, tc.wantErr, err != nil, "Expire(...): %v", err)
+			assert.Equal(t, []byte("secret"), cl.expired)
+			assert.Equal(t, expireNow, cl.expiryDur)
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	token := func(issued, expires, max time.Duration) *kadm.DelegationToken {
+		return &kadm.DelegationToken{
+			IssueTimestamp:  testNow.Add(issued),
+			ExpiryTimestamp: testNow.Add(expires),
+			MaxTimestamp:    testNow.Add(max),
+		}
+	}
+
+	cases := map[string]struct {
+		token           *kadm.DelegationToken
+		wantExpired     bool
+		wantRenewal     bool
+		wantReplacement bool
+	}{
+		"Fresh": {
+			token: token(-time.Hour, 23*time.Hour, 7*24*time.Hour),
+		},
+		"DueForRenewal": {
+			token:       token(-23*time.Hour, 30*time.Minute, 6*24*time.Hour),
+			wantRenewal: true,
+		},
+		"DueForReplacement": {
+			token:           token(-7*24*time.Hour, 30*time.Minute, 30*time.Minute),
+			wantReplacement: true,
+		},
+		"ShortLivedNotReplaced": {
+			token: token(-30*time.Minute, 20*time.Minute, 20*time.Minute),
+		},
+		"Expired": {
+			token:           token(-7*24*time.Hour, 0, 0),
+			wantExpired:     true,
+			wantReplacement: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.wantExpired, IsExpired(tc.token, testNow), "IsExpired(...)")
+			assert.Equal(t, tc.wantRenewal, NeedsRenewal(tc.token, testNow, DefaultRenewBefore), "NeedsRenewal(...)")
+			assert.Equal(t, tc.wantReplacement, NeedsReplacement(tc.token, testNow, DefaultRenewBefore), "NeedsReplacement(...)")
+		})
+	}
+}
+
+func TestToObservation(t *testing.T) {
+	t.Parallel()
+
+	token := &kadm.DelegationToken{
+		Owner:           kadm.Principal{Type: "User", Name: "alice"},
+		IssueTimestamp:  testNow,
+		ExpiryTimestamp: testNow.Add(24 * time.Hour),
+		MaxTimestamp:    testNow.Add(7 * 24 * time.Hour),
+		TokenID:         testTokenID,
+		HMAC:            []byte("secret"),
+		Renewers:        []kadm.Principal{{Name: "scheduler"}},
+	}
+	want := v1alpha1.DelegationTokenObservation{
+		TokenID:         testTokenID,
+		Owner:           "User:alice",
+		Renewers:        []string{"User:scheduler"},
+		IssueTimestamp:  &metav1.Time{Time: testNow},
+		ExpiryTimestamp: &metav1.Time{Time: testNow.Add(24 * time.Hour)},
+		MaxTimestamp:    &metav1.Time{Time: testNow.Add(7 * 24 * time.Hour)},
+	}
+	if diff := cmp.Diff(want, ToObservation(token)); diff != "" {
+		t.Errorf("ToObservation(...): -want, +got:\n%s", diff)
+	}
+	assert.Equal(t, "c2VjcmV0", HMAC(token))
+}
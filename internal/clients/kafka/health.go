@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/jcmturner/gokrb5/v8/krberror"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const (
+	errCannotGetBrokerMetadata = "cannot get broker metadata"
+	errCannotGetAPIVersions    = "cannot get broker API versions"
+)
+
+// clusterAdmin is the subset of kadm.Client methods used to check the health
+// of a cluster. *kadm.Client satisfies this interface.
+type clusterAdmin interface {
+	BrokerMetadata(ctx context.Context) (kadm.Metadata, error)
+	ApiVersions(ctx context.Context) (kadm.BrokersApiVersions, error)
+}
+
+// CheckCluster checks that the cluster answers metadata and API versions
+// requests, and returns what it observed.
+func CheckCluster(ctx context.Context, client clusterAdmin) (*v1alpha1.ClusterObservation, error) {
+	m, err := client.BrokerMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotGetBrokerMetadata, err)
+	}
+	vs, err := client.ApiVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotGetAPIVersions, err)
+	}
+
+	now := metav1.Now()
+	o := &v1alpha1.ClusterObservation{
+		ClusterID:     m.Cluster,
+		BrokerCount:   len(m.Brokers),
+		LastCheckTime: &now,
+	}
+	if m.Controller >= 0 {
+		controller := m.Controller
+		o.ControllerID = &controller
+	}
+
+	// Report the version of the controller, or of the broker with the
+	// lowest node ID if the controller did not answer.
+	if v, ok := vs[m.Controller]; ok && v.Err == nil {
+		o.KafkaVersion = v.VersionGuess()
+		return o, nil
+	}
+	for _, v := range vs.Sorted() {
+		if v.Err == nil {
+			o.KafkaVersion = v.VersionGuess()
+			break
+		}
+	}
+	return o, nil
+}
+
+// HealthCheckReason returns the reason a health check failed with the
+// supplied error.
+func HealthCheckReason(err error) xpv2.ConditionReason {
+	if isAuthenticationError(err) {
+		return v1alpha1.ReasonAuthenticationFailed
+	}
+	if isTLSError(err) {
+		return v1alpha1.ReasonTLSHandshakeFailed
+	}
+	return v1alpha1.ReasonBrokersUnreachable
+}
+
+func isAuthenticationError(err error) bool {
+	if errors.Is(err, kerr.SaslAuthenticationFailed) ||
+		errors.Is(err, kerr.UnsupportedSaslMechanism) ||
+		errors.Is(err, kerr.IllegalSaslState) {
+		return true
+	}
+	var authErr *kadm.AuthError
+	var retrieveErr *oauth2.RetrieveError
+	var krbErr krberror.Krberror
+	var kdcErr messages.KRBError
+	return errors.As(err, &authErr) ||
+		errors.As(err, &retrieveErr) ||
+		errors.As(err, &krbErr) ||
+		errors.As(err, &kdcErr)
+}
+
+func isTLSError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var headerErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &headerErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
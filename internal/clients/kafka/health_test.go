@@ -0,0 +1,142 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jcmturner/gokrb5/v8/krberror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"golang.org/x/oauth2"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// fakeClusterAdmin is an in-process implementation of clusterAdmin for unit
+// tests.
+type fakeClusterAdmin struct {
+	metadata    kadm.Metadata
+	metadataErr error
+	versions    kadm.BrokersApiVersions
+	versionsErr error
+}
+
+func (f *fakeClusterAdmin) BrokerMetadata(context.Context) (kadm.Metadata, error) {
+	return f.metadata, f.metadataErr
+}
+
+func (f *fakeClusterAdmin) ApiVersions(context.Context) (kadm.BrokersApiVersions, error) {
+	return f.versions, f.versionsErr
+}
+
+func TestCheckCluster(t *testing.T) {
+	controller := int32(2)
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		cl      *fakeClusterAdmin
+		want    *v1alpha1.ClusterObservation
+		wantErr string
+	}{
+		"Observed": {
+			cl: &fakeClusterAdmin{
+				metadata: kadm.Metadata{
+					Cluster:    "abc",
+					Controller: controller,
+					Brokers:    kadm.BrokerDetails{{NodeID: 1}, {NodeID: 2}, {NodeID: 3}},
+				},
+				versions: kadm.BrokersApiVersions{2: {NodeID: 2, Err: errBoom}},
+			},
+			want: &v1alpha1.ClusterObservation{ClusterID: "abc", ControllerID: &controller, BrokerCount: 3},
+		},
+		"NoController": {
+			cl: &fakeClusterAdmin{
+				metadata: kadm.Metadata{Cluster: "abc", Controller: -1, Brokers: kadm.BrokerDetails{{NodeID: 1}}},
+			},
+			want: &v1alpha1.ClusterObservation{ClusterID: "abc", BrokerCount: 1},
+		},
+		"MetadataError": {
+			cl:      &fakeClusterAdmin{metadataErr: errBoom},
+			wantErr: errCannotGetBrokerMetadata,
+		},
+		"ApiVersionsError": {
+			cl:      &fakeClusterAdmin{versionsErr: errBoom},
+			wantErr: errCannotGetAPIVersions,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := CheckCluster(context.Background(), tc.cl)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, got.LastCheckTime)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(v1alpha1.ClusterObservation{}, "LastCheckTime")); diff != "" {
+				t.Errorf("CheckCluster(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthCheckReason(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want xpv2.ConditionReason
+	}{
+		"SASLAuthenticationFailed": {
+			err:  fmt.Errorf("%s: %w", errCannotGetBrokerMetadata, kerr.SaslAuthenticationFailed),
+			want: v1alpha1.ReasonAuthenticationFailed,
+		},
+		"UnsupportedSASLMechanism": {
+			err:  kerr.UnsupportedSaslMechanism,
+			want: v1alpha1.ReasonAuthenticationFailed,
+		},
+		"OAuthTokenRequestFailed": {
+			err:  fmt.Errorf("%s: %w", errCannotGetOAuthToken, &oauth2.RetrieveError{ErrorCode: "invalid_client"}),
+			want: v1alpha1.ReasonAuthenticationFailed,
+		},
+		"KerberosLoginFailed": {
+			err:  fmt.Errorf("%s: %w", errCannotGetBrokerMetadata, krberror.New(krberror.KDCError, "preauth failed")),
+			want: v1alpha1.ReasonAuthenticationFailed,
+		},
+		"UnknownAuthority": {
+			err:  &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}},
+			want: v1alpha1.ReasonTLSHandshakeFailed,
+		},
+		"PlaintextBroker": {
+			err:  fmt.Errorf("unable to dial: %w", tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}),
+			want: v1alpha1.ReasonTLSHandshakeFailed,
+		},
+		"HandshakeAlert": {
+			err:  tls.AlertError(42),
+			want: v1alpha1.ReasonTLSHandshakeFailed,
+		},
+		"ConnectionRefused": {
+			err:  fmt.Errorf("unable to dial: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}),
+			want: v1alpha1.ReasonBrokersUnreachable,
+		},
+		"Timeout": {
+			err:  context.DeadlineExceeded,
+			want: v1alpha1.ReasonBrokersUnreachable,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, HealthCheckReason(tc.err))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	krbclient "github.com/jcmturner/gokrb5/v8/client"
+	krbconfig "github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/kerberos"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateKerberos checks the Kerberos configuration of the GSSAPI mechanism.
+func validateKerberos(k *Kerberos) error {
+	if k == nil {
+		return errors.New(errMissingKerberosConfig)
+	}
+	if k.Principal == "" || k.Realm == "" {
+		return errors.New(errMissingKerberosPrincipal)
+	}
+	if k.Krb5Conf == "" {
+		return errors.New(errMissingKrb5Conf)
+	}
+	if k.KeytabSecretRef != nil && k.KeytabPath != "" {
+		return errors.New(errMultipleKeytabs)
+	}
+	switch {
+	case k.KeytabSecretRef != nil:
+		if k.KeytabSecretRef.Name == "" || k.KeytabSecretRef.Namespace == "" {
+			return errors.New(errMissingKeytabSecretRefKeys)
+		}
+	case k.KeytabPath == "":
+		return errors.New(errMissingKeytab)
+	}
+	return nil
+}
+
+// newGSSAPIMechanism builds a SASL mechanism for Kerberos GSSAPI
+// authentication with a keytab. The Kerberos client logs in on the first SASL
+// handshake and renews its ticket in the background, logging in again with
+// the keytab once the ticket can no longer be renewed, until the Kafka client
+// is closed.
+func newGSSAPIMechanism(ctx context.Context, k *Kerberos, kube client.Client) (sasl.Mechanism, error) {
+	cfg, err := krbconfig.NewFromString(k.Krb5Conf)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errInvalidKrb5Conf, err)
+	}
+	kt, err := loadKeytab(ctx, k, kube)
+	if err != nil {
+		return nil, err
+	}
+	return kerberos.Auth{
+		Client:  krbclient.NewWithKeytab(k.Principal, k.Realm, kt, cfg, krbclient.DisablePAFXFAST(k.DisablePAFXFAST)),
+		Service: valueOrDefault(k.ServiceName, defaultKerberosServiceName),
+	}.AsMechanismWithClose(), nil
+}
+
+// loadKeytab reads the keytab from the referenced Kubernetes Secret or from
+// disk.
+func loadKeytab(ctx context.Context, k *Kerberos, kube client.Client) (*keytab.Keytab, error) {
+	kt := keytab.New()
+	if sr := k.KeytabSecretRef; sr != nil {
+		secret := &corev1.Secret{}
+		if err := kube.Get(ctx, k8stypes.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}, secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", errCannotReadKeytabSecret, err)
+		}
+		f := valueOrDefault(sr.KeytabField, defaultKeytabField)
+		b := secret.Data[f]
+		if len(b) == 0 {
+			return nil, fmt.Errorf("missing or empty keytab field %q in secret %s/%s", f, sr.Namespace, sr.Name)
+		}
+		if err := kt.Unmarshal(b); err != nil {
+			return nil, fmt.Errorf("%s, using field %q from secret %q in namespace %q: %w", errInvalidKeytab, f, sr.Name, sr.Namespace, err)
+		}
+		return kt, nil
+	}
+
+	b, err := os.ReadFile(k.KeytabPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", errCannotReadKeytabFile, k.KeytabPath, err)
+	}
+	if err := kt.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf("%s %q: %w", errInvalidKeytab, k.KeytabPath, err)
+	}
+	return kt, nil
+}
@@ -0,0 +1,209 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/sasl"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testRealm     = "EXAMPLE.COM"
+	testPrincipal = "kafka-admin"
+	testKrb5Conf  = `[libdefaults]
+  default_realm = EXAMPLE.COM
+
+[realms]
+  EXAMPLE.COM = {
+    kdc = kdc.example.com:88
+  }
+`
+)
+
+// newTestKeytab returns a marshalled keytab with an AES256 key for the
+// principal.
+func newTestKeytab(t *testing.T, principal string) (*keytab.Keytab, []byte) {
+	t.Helper()
+	kt := keytab.New()
+	require.NoError(t, kt.AddEntry(principal, testRealm, "password", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96))
+	b, err := kt.Marshal()
+	require.NoError(t, err)
+	return kt, b
+}
+
+func TestValidateKerberos(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in      *Kerberos
+		wantErr string
+	}{
+		"KeytabPath": {
+			in: &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf, KeytabPath: "/etc/kafka/krb5.keytab"},
+		},
+		"KeytabSecretRef": {
+			in: &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf, KeytabSecretRef: &KeytabSecretRef{Name: "keytab", Namespace: "kafka"}},
+		},
+		"Missing": {
+			wantErr: errMissingKerberosConfig,
+		},
+		"MissingRealm": {
+			in:      &Kerberos{Principal: testPrincipal, Krb5Conf: testKrb5Conf, KeytabPath: "/etc/kafka/krb5.keytab"},
+			wantErr: errMissingKerberosPrincipal,
+		},
+		"MissingKrb5Conf": {
+			in:      &Kerberos{Principal: testPrincipal, Realm: testRealm, KeytabPath: "/etc/kafka/krb5.keytab"},
+			wantErr: errMissingKrb5Conf,
+		},
+		"MissingKeytab": {
+			in:      &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf},
+			wantErr: errMissingKeytab,
+		},
+		"BothKeytabs": {
+			in: &Kerberos{
+				Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf,
+				KeytabPath: "/etc/kafka/krb5.keytab", KeytabSecretRef: &KeytabSecretRef{Name: "keytab", Namespace: "kafka"},
+			},
+			wantErr: errMultipleKeytabs,
+		},
+		"IncompleteKeytabSecretRef": {
+			in:      &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf, KeytabSecretRef: &KeytabSecretRef{Name: "keytab"}},
+			wantErr: errMissingKeytabSecretRefKeys,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validateKerberos(tc.in)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLoadKeytab(t *testing.T) {
+	t.Parallel()
+
+	_, b := newTestKeytab(t, testPrincipal)
+	path := filepath.Join(t.TempDir(), "krb5.keytab")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	getSecret := func(data map[string][]byte) *test.MockClient {
+		return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}}
+	}
+	ref := &KeytabSecretRef{Name: "keytab", Namespace: "kafka"}
+
+	cases := map[string]struct {
+		in      *Kerberos
+		kube    client.Client
+		wantErr string
+	}{
+		"File": {
+			in: &Kerberos{KeytabPath: path},
+		},
+		"FileNotFound": {
+			in:      &Kerberos{KeytabPath: filepath.Join(t.TempDir(), "missing.keytab")},
+			wantErr: errCannotReadKeytabFile,
+		},
+		"SecretDefaultField": {
+			in:   &Kerberos{KeytabSecretRef: ref},
+			kube: getSecret(map[string][]byte{defaultKeytabField: b}),
+		},
+		"SecretCustomField": {
+			in:   &Kerberos{KeytabSecretRef: &KeytabSecretRef{Name: "keytab", Namespace: "kafka", KeytabField: "keytab"}},
+			kube: getSecret(map[string][]byte{"keytab": b}),
+		},
+		"SecretMissingField": {
+			in:      &Kerberos{KeytabSecretRef: ref},
+			kube:    getSecret(map[string][]byte{}),
+			wantErr: "missing or empty keytab field",
+		},
+		"SecretInvalidKeytab": {
+			in:      &Kerberos{KeytabSecretRef: ref},
+			kube:    getSecret(map[string][]byte{defaultKeytabField: []byte("not a keytab")}),
+			wantErr: errInvalidKeytab,
+		},
+		"SecretNotFound": {
+			in:      &Kerberos{KeytabSecretRef: ref},
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			wantErr: errCannotReadKeytabSecret,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			kt, err := loadKeytab(context.Background(), tc.in, tc.kube)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			_, _, err = kt.GetEncryptionKey(types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, testPrincipal), testRealm, 1, etypeID.AES256_CTS_HMAC_SHA1_96)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewGSSAPIMechanism(t *testing.T) {
+	t.Parallel()
+
+	_, b := newTestKeytab(t, testPrincipal)
+	path := filepath.Join(t.TempDir(), "krb5.keytab")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	cases := map[string]struct {
+		in      *Kerberos
+		wantErr string
+	}{
+		"Keytab": {
+			in: &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf, KeytabPath: path},
+		},
+		"InvalidKrb5Conf": {
+			in:      &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: "[libdefaults]\n  ticket_lifetime = forever\n", KeytabPath: path},
+			wantErr: errInvalidKrb5Conf,
+		},
+		"KeytabNotFound": {
+			in:      &Kerberos{Principal: testPrincipal, Realm: testRealm, Krb5Conf: testKrb5Conf, KeytabPath: filepath.Join(t.TempDir(), "missing.keytab")},
+			wantErr: errCannotReadKeytabFile,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			m, err := newGSSAPIMechanism(context.Background(), tc.in, nil)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "GSSAPI", m.Name())
+			// The Kafka client destroys the Kerberos client, stopping its
+			// ticket renewal, when it is closed.
+			c, ok := m.(sasl.ClosingMechanism)
+			require.True(t, ok)
+			c.Close()
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/oauth"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateOAuth checks the OAuth configuration of the OAUTHBEARER mechanism.
+func validateOAuth(o *OAuth) error {
+	if o == nil {
+		return errors.New(errMissingOAuthConfig)
+	}
+	u, err := url.Parse(o.TokenEndpoint)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%s (received: %q)", errInvalidOAuthTokenEndpoint, o.TokenEndpoint)
+	}
+	if o.ClientSecret != "" && o.ClientSecretRef != nil {
+		return errors.New(errMultipleOAuthSecrets)
+	}
+	switch {
+	case o.ClientSecretRef != nil:
+		if o.ClientSecretRef.Name == "" || o.ClientSecretRef.Namespace == "" {
+			return errors.New(errMissingOAuthSecretRefKeys)
+		}
+	case o.ClientID == "" || o.ClientSecret == "":
+		return errors.New(errMissingOAuthCredentials)
+	}
+	if o.TokenExpiryWindow != "" {
+		if d, err := time.ParseDuration(o.TokenExpiryWindow); err != nil || d < 0 {
+			return fmt.Errorf("%s %q: must be a non-negative duration", errInvalidOAuthTokenExpiryWindow, o.TokenExpiryWindow)
+		}
+	}
+	return nil
+}
+
+// newOAuthBearerMechanism builds a SASL mechanism for OAUTHBEARER
+// authentication using the OAuth 2.0 client credentials flow. The token
+// source is constructed once and captured by the returned closure, so tokens
+// are cached across SASL handshakes for the lifetime of the Kafka client and
+// only requested again when they are about to expire (TokenExpiryWindow).
+func newOAuthBearerMechanism(ctx context.Context, o *OAuth, kube client.Client) (sasl.Mechanism, error) {
+	id, secret, err := oauthClientCredentials(ctx, o, kube)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &clientcredentials.Config{
+		ClientID:     id,
+		ClientSecret: secret,
+		TokenURL:     o.TokenEndpoint,
+		Scopes:       o.Scopes,
+	}
+	if o.Audience != "" {
+		cfg.EndpointParams = url.Values{"audience": {o.Audience}}
+	}
+	expiryWindow := defaultOAuthTokenExpiryWindow
+	if o.TokenExpiryWindow != "" {
+		// Validated by validateOAuth.
+		expiryWindow, _ = time.ParseDuration(o.TokenExpiryWindow)
+	}
+	ts := oauth2.ReuseTokenSourceWithExpiry(nil, oauthTokenFetcher{cfg: cfg}, expiryWindow)
+	extensions := maps.Clone(o.Extensions)
+
+	return oauth.Oauth(func(context.Context) (oauth.Auth, error) {
+		t, err := ts.Token()
+		if err != nil {
+			return oauth.Auth{}, fmt.Errorf("%s: %w", errCannotGetOAuthToken, err)
+		}
+		return oauth.Auth{
+			Token:      t.AccessToken,
+			Extensions: extensions,
+		}, nil
+	}), nil
+}
+
+// oauthTokenFetcher requests a new token from the token endpoint on every
+// call. Caching is left to the wrapping oauth2.ReuseTokenSourceWithExpiry,
+// which controls how early tokens are refreshed.
+type oauthTokenFetcher struct {
+	cfg *clientcredentials.Config
+}
+
+// Token requests a new token. The request is not bound to the context of the
+// reconcile that created the client, which is cancelled long before the
+// client stops refreshing tokens.
+func (f oauthTokenFetcher) Token() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), oauthTokenRequestTimeout)
+	defer cancel()
+	return f.cfg.Token(ctx)
+}
+
+// oauthClientCredentials returns the OAuth client ID and secret, reading them
+// from the referenced Kubernetes Secret if configured. An inline client ID
+// takes precedence over the one in the Secret.
+func oauthClientCredentials(ctx context.Context, o *OAuth, kube client.Client) (string, string, error) {
+	sr := o.ClientSecretRef
+	if sr == nil {
+		return o.ClientID, o.ClientSecret, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}, secret); err != nil {
+		return "", "", fmt.Errorf("%s: %w", errCannotReadOAuthSecret, err)
+	}
+
+	id := o.ClientID
+	if id == "" {
+		f := valueOrDefault(sr.ClientIDField, defaultOAuthClientIDField)
+		if id = string(secret.Data[f]); id == "" {
+			return "", "", fmt.Errorf("missing or empty OAuth client ID field %q in secret %s/%s", f, sr.Namespace, sr.Name)
+		}
+	}
+
+	f := valueOrDefault(sr.ClientSecretField, defaultOAuthClientSecretField)
+	cs := string(secret.Data[f])
+	if cs == "" {
+		return "", "", fmt.Errorf("missing or empty OAuth client secret field %q in secret %s/%s", f, sr.Namespace, sr.Name)
+	}
+	return id, cs, nil
+}
@@ -0,0 +1,211 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestValidateOAuth(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in      *OAuth
+		wantErr string
+	}{
+		"Inline": {
+			in: &OAuth{TokenEndpoint: "https://idp.example.com/token", ClientID: "id", ClientSecret: "secret", TokenExpiryWindow: "30s"},
+		},
+		"SecretRef": {
+			in: &OAuth{TokenEndpoint: "https://idp.example.com/token", ClientSecretRef: &OAuthClientSecretRef{Name: "oauth", Namespace: "kafka"}},
+		},
+		"Missing": {
+			wantErr: errMissingOAuthConfig,
+		},
+		"InvalidTokenEndpoint": {
+			in:      &OAuth{TokenEndpoint: "idp.example.com/token", ClientID: "id", ClientSecret: "secret"},
+			wantErr: errInvalidOAuthTokenEndpoint,
+		},
+		"MissingClientSecret": {
+			in:      &OAuth{TokenEndpoint: "https://idp.example.com/token", ClientID: "id"},
+			wantErr: errMissingOAuthCredentials,
+		},
+		"BothClientSecrets": {
+			in: &OAuth{
+				TokenEndpoint:   "https://idp.example.com/token",
+				ClientSecret:    "secret",
+				ClientSecretRef: &OAuthClientSecretRef{Name: "oauth", Namespace: "kafka"},
+			},
+			wantErr: errMultipleOAuthSecrets,
+		},
+		"IncompleteSecretRef": {
+			in:      &OAuth{TokenEndpoint: "https://idp.example.com/token", ClientSecretRef: &OAuthClientSecretRef{Name: "oauth"}},
+			wantErr: errMissingOAuthSecretRefKeys,
+		},
+		"InvalidTokenExpiryWindow": {
+			in:      &OAuth{TokenEndpoint: "https://idp.example.com/token", ClientID: "id", ClientSecret: "secret", TokenExpiryWindow: "-1m"},
+			wantErr: errInvalidOAuthTokenExpiryWindow,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validateOAuth(tc.in)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestOAuthClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	getSecret := func(data map[string][]byte) *test.MockClient {
+		return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}}
+	}
+	ref := &OAuthClientSecretRef{Name: "oauth", Namespace: "kafka"}
+
+	cases := map[string]struct {
+		in         *OAuth
+		kube       client.Client
+		wantID     string
+		wantSecret string
+		wantErr    bool
+	}{
+		"Inline": {
+			in:         &OAuth{ClientID: "id", ClientSecret: "secret"},
+			wantID:     "id",
+			wantSecret: "secret",
+		},
+		"SecretDefaultFields": {
+			in:         &OAuth{ClientSecretRef: ref},
+			kube:       getSecret(map[string][]byte{"clientId": []byte("id"), "clientSecret": []byte("secret")}),
+			wantID:     "id",
+			wantSecret: "secret",
+		},
+		"SecretCustomFieldsInlineID": {
+			in: &OAuth{ClientID: "inline", ClientSecretRef: &OAuthClientSecretRef{
+				Name: "oauth", Namespace: "kafka", ClientSecretField: "password",
+			}},
+			kube:       getSecret(map[string][]byte{"clientId": []byte("id"), "password": []byte("secret")}),
+			wantID:     "inline",
+			wantSecret: "secret",
+		},
+		"SecretMissingField": {
+			in:      &OAuth{ClientSecretRef: ref},
+			kube:    getSecret(map[string][]byte{"clientId": []byte("id")}),
+			wantErr: true,
+		},
+		"SecretNotFound": {
+			in:      &OAuth{ClientSecretRef: ref},
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			id, secret, err := oauthClientCredentials(context.Background(), tc.in, tc.kube)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, id)
+			assert.Equal(t, tc.wantSecret, secret)
+		})
+	}
+}
+
+func TestNewOAuthBearerMechanism(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		expiresIn    int
+		expiryWindow string
+		wantRequests int32
+	}{
+		"TokenCached": {
+			expiresIn:    3600,
+			wantRequests: 1,
+		},
+		"TokenRefreshedWithinExpiryWindow": {
+			expiresIn:    30,
+			expiryWindow: "1m",
+			wantRequests: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				id, secret, _ := r.BasicAuth()
+				if id != "id" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" ||
+					r.FormValue("scope") != "kafka" || r.FormValue("audience") != "cluster" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, tc.expiresIn)
+			}))
+			defer srv.Close()
+
+			m, err := newOAuthBearerMechanism(context.Background(), &OAuth{
+				TokenEndpoint:     srv.URL,
+				ClientID:          "id",
+				ClientSecret:      "secret",
+				Scopes:            []string{"kafka"},
+				Audience:          "cluster",
+				Extensions:        map[string]string{"logicalCluster": "lkc-1"},
+				TokenExpiryWindow: tc.expiryWindow,
+			}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "OAUTHBEARER", m.Name())
+
+			var last []byte
+			for range 2 {
+				_, last, err = m.Authenticate(context.Background(), "broker:9092")
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantRequests, requests.Load())
+			assert.Contains(t, string(last), fmt.Sprintf("auth=Bearer token-%d", tc.wantRequests))
+			assert.Contains(t, string(last), "logicalCluster=lkc-1")
+		})
+	}
+}
+
+func TestNewOAuthBearerMechanism_TokenError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	m, err := newOAuthBearerMechanism(context.Background(), &OAuth{TokenEndpoint: srv.URL, ClientID: "id", ClientSecret: "wrong"}, nil)
+	require.NoError(t, err)
+	_, _, err = m.Authenticate(context.Background(), "broker:9092")
+	require.ErrorContains(t, err, errCannotGetOAuthToken)
+}
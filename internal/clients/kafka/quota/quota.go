@@ -0,0 +1,233 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// AdminClient is the subset of kadm.Client methods used by this package.
+// *kadm.Client satisfies this interface without any changes to callers.
+type AdminClient interface {
+	DescribeClientQuotas(ctx context.Context, strict bool, entityComponents []kadm.DescribeClientQuotaComponent) (kadm.DescribedClientQuotas, error)
+	AlterClientQuotas(ctx context.Context, entries []kadm.AlterClientQuotaEntry) (kadm.AlteredClientQuotas, error)
+}
+
+// Quota is the set of client quotas of a single entity.
+type Quota struct {
+	Entity kadm.ClientQuotaEntity
+	Values map[string]float64
+}
+
+// Quota entity types.
+const (
+	EntityTypeUser     = "user"
+	EntityTypeClientID = "client-id"
+)
+
+// Quota keys managed by a Quota.
+const (
+	KeyProducerByteRate       = "producer_byte_rate"
+	KeyConsumerByteRate       = "consumer_byte_rate"
+	KeyRequestPercentage      = "request_percentage"
+	KeyControllerMutationRate = "controller_mutation_rate"
+)
+
+// Keys are the quota keys managed by a Quota. Other quotas of the entity are
+// left untouched.
+var Keys = []string{KeyProducerByteRate, KeyConsumerByteRate, KeyRequestPercentage, KeyControllerMutationRate}
+
+const (
+	errCannotDescribeQuotas = "cannot describe client quotas"
+	errCannotAlterQuotas    = "cannot alter client quotas"
+	errNoAlterResponse      = "no alter response for client quota entity"
+	errInvalidQuotaValue    = "invalid quota value"
+	errNoEntity             = "quota entity requires a user or client ID"
+)
+
+// Entity returns the Kafka quota entity described by the parameters.
+func Entity(in *v1alpha1.QuotaParameters) (kadm.ClientQuotaEntity, error) {
+	var e kadm.ClientQuotaEntity
+	if in.User != nil {
+		e = append(e, component(EntityTypeUser, in.User))
+	}
+	if in.ClientID != nil {
+		e = append(e, component(EntityTypeClientID, in.ClientID))
+	}
+	if len(e) == 0 {
+		return nil, errors.New(errNoEntity)
+	}
+	return e, nil
+}
+
+func component(typ string, n *v1alpha1.QuotaEntityName) kadm.ClientQuotaEntityComponent {
+	c := kadm.ClientQuotaEntityComponent{Type: typ}
+	if !n.Default {
+		name := n.Name
+		c.Name = &name
+	}
+	return c
+}
+
+// Get gets the quotas of the entity from the Kafka side. An entity without
+// quotas is returned with no values.
+func Get(ctx context.Context, client AdminClient, entity kadm.ClientQuotaEntity) (*Quota, error) {
+	components := make([]kadm.DescribeClientQuotaComponent, 0, len(entity))
+	for _, c := range entity {
+		d := kadm.DescribeClientQuotaComponent{Type: c.Type, MatchName: c.Name}
+		if c.Name == nil {
+			d.MatchType = kmsg.QuotasMatchTypeDefault
+		}
+		components = append(components, d)
+	}
+
+	described, err := client.DescribeClientQuotas(ctx, true, components)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribeQuotas, err)
+	}
+
+	q := &Quota{Entity: entity, Values: map[string]float64{}}
+	for _, d := range described {
+		if d.Entity.String() != entity.String() {
+			continue
+		}
+		for _, v := range d.Values {
+			q.Values[v.Key] = v.Value
+		}
+	}
+	return q, nil
+}
+
+// Exists returns true if any of the quotas managed by a Quota are set on the
+// entity.
+func (q *Quota) Exists() bool {
+	for _, k := range Keys {
+		if _, ok := q.Values[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Alter sets the desired quotas of the entity and removes the managed quotas
+// that are not desired.
+func Alter(ctx context.Context, client AdminClient, in *v1alpha1.QuotaParameters, existing *Quota) error {
+	desired, err := values(&in.QuotaValues)
+	if err != nil {
+		return err
+	}
+
+	var ops []kadm.AlterClientQuotaOp
+	for _, k := range Keys {
+		want, ok := desired[k]
+		got, set := existing.Values[k]
+		switch {
+		case ok && (!set || got != want):
+			ops = append(ops, kadm.AlterClientQuotaOp{Key: k, Value: want})
+		case !ok && set:
+			ops = append(ops, kadm.AlterClientQuotaOp{Key: k, Remove: true})
+		}
+	}
+	return alter(ctx, client, existing.Entity, ops)
+}
+
+// Delete removes the managed quotas from the entity.
+func Delete(ctx context.Context, client AdminClient, existing *Quota) error {
+	var ops []kadm.AlterClientQuotaOp
+	for _, k := range Keys {
+		if _, set := existing.Values[k]; set {
+			ops = append(ops, kadm.AlterClientQuotaOp{Key: k, Remove: true})
+		}
+	}
+	return alter(ctx, client, existing.Entity, ops)
+}
+
+func alter(ctx context.Context, client AdminClient, entity kadm.ClientQuotaEntity, ops []kadm.AlterClientQuotaOp) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	resp, err := client.AlterClientQuotas(ctx, []kadm.AlterClientQuotaEntry{{Entity: entity, Ops: ops}})
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotAlterQuotas, err)
+	}
+	if len(resp) == 0 {
+		return errors.New(errNoAlterResponse)
+	}
+	if r := resp[0]; r.Err != nil {
+		if r.ErrMessage != "" {
+			return fmt.Errorf("%s: %w: %s", errCannotAlterQuotas, r.Err, r.ErrMessage)
+		}
+		return fmt.Errorf("%s: %w", errCannotAlterQuotas, r.Err)
+	}
+	return nil
+}
+
+// values converts the quota values to the float values used by Kafka.
+func values(in *v1alpha1.QuotaValues) (map[string]float64, error) {
+	vs := map[string]float64{}
+	if in.ProducerByteRate != nil {
+		vs[KeyProducerByteRate] = float64(*in.ProducerByteRate)
+	}
+	if in.ConsumerByteRate != nil {
+		vs[KeyConsumerByteRate] = float64(*in.ConsumerByteRate)
+	}
+	for k, v := range map[string]*string{
+		KeyRequestPercentage:      in.RequestPercentage,
+		KeyControllerMutationRate: in.ControllerMutationRate,
+	} {
+		if v == nil {
+			continue
+		}
+		f, err := strconv.ParseFloat(*v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", errInvalidQuotaValue, k, err)
+		}
+		vs[k] = f
+	}
+	return vs, nil
+}
+
+// ToObservation converts the quota to a QuotaObservation.
+func (q *Quota) ToObservation() v1alpha1.QuotaObservation {
+	o := v1alpha1.QuotaObservation{Entity: q.Entity.String()}
+	if v, ok := q.Values[KeyProducerByteRate]; ok {
+		o.ProducerByteRate = ptr(int64(v))
+	}
+	if v, ok := q.Values[KeyConsumerByteRate]; ok {
+		o.ConsumerByteRate = ptr(int64(v))
+	}
+	if v, ok := q.Values[KeyRequestPercentage]; ok {
+		o.RequestPercentage = ptr(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	if v, ok := q.Values[KeyControllerMutationRate]; ok {
+		o.ControllerMutationRate = ptr(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return o
+}
+
+// IsUpToDate returns true if the managed quotas of the entity match the
+// desired quotas exactly.
+func IsUpToDate(in *v1alpha1.QuotaParameters, observed *Quota) (bool, error) {
+	desired, err := values(&in.QuotaValues)
+	if err != nil {
+		return false, err
+	}
+	for _, k := range Keys {
+		want, ok := desired[k]
+		got, set := observed.Values[k]
+		if ok != set || want != got {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,270 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const testUser = "alice"
+
+// fakeQuotaAdmin is an in-process implementation of AdminClient for unit
+// tests.
+type fakeQuotaAdmin struct {
+	described   kadm.DescribedClientQuotas
+	describeErr error
+	alterErr    error
+
+	components []kadm.DescribeClientQuotaComponent
+	altered    []kadm.AlterClientQuotaEntry
+}
+
+func (f *fakeQuotaAdmin) DescribeClientQuotas(_ context.Context, _ bool, components []kadm.DescribeClientQuotaComponent) (kadm.DescribedClientQuotas, error) {
+	f.components = components
+	return f.described, f.describeErr
+}
+
+func (f *fakeQuotaAdmin) AlterClientQuotas(_ context.Context, entries []kadm.AlterClientQuotaEntry) (kadm.AlteredClientQuotas, error) {
+	f.altered = entries
+	return kadm.AlteredClientQuotas{{Entity: entries[0].Entity, Err: f.alterErr}}, nil
+}
+
+func userEntity() kadm.ClientQuotaEntity {
+	return kadm.ClientQuotaEntity{{Type: EntityTypeUser, Name: ptr(testUser)}}
+}
+
+func TestEntity(t *testing.T) {
+	cases := map[string]struct {
+		in      v1alpha1.QuotaParameters
+		want    kadm.ClientQuotaEntity
+		wantErr bool
+	}{
+		"User": {
+			in:   v1alpha1.QuotaParameters{User: &v1alpha1.QuotaEntityName{Name: testUser}},
+			want: userEntity(),
+		},
+		"DefaultClientOfUser": {
+			in: v1alpha1.QuotaParameters{
+				User:     &v1alpha1.QuotaEntityName{Name: testUser},
+				ClientID: &v1alpha1.QuotaEntityName{Default: true},
+			},
+			want: kadm.ClientQuotaEntity{
+				{Type: EntityTypeUser, Name: ptr(testUser)},
+				{Type: EntityTypeClientID},
+			},
+		},
+		"NoEntity": {
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Entity(&tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Entity(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	defaultUser := kadm.ClientQuotaEntity{{Type: EntityTypeUser}}
+
+	cases := map[string]struct {
+		entity         kadm.ClientQuotaEntity
+		cl             *fakeQuotaAdmin
+		want           map[string]float64
+		wantComponents []kadm.DescribeClientQuotaComponent
+		exists         bool
+		wantErr        bool
+	}{
+		"Named": {
+			entity: userEntity(),
+			cl: &fakeQuotaAdmin{described: kadm.DescribedClientQuotas{
+				{Entity: userEntity(), Values: kadm.ClientQuotaValues{{Key: KeyProducerByteRate, Value: 1024}}},
+			}},
+			want:           map[string]float64{KeyProducerByteRate: 1024},
+			wantComponents: []kadm.DescribeClientQuotaComponent{{Type: EntityTypeUser, MatchName: ptr(testUser)}},
+			exists:         true,
+		},
+		"Default": {
+			entity: defaultUser,
+			cl: &fakeQuotaAdmin{described: kadm.DescribedClientQuotas{
+				{Entity: defaultUser, Values: kadm.ClientQuotaValues{{Key: KeyRequestPercentage, Value: 50}}},
+			}},
+			want:           map[string]float64{KeyRequestPercentage: 50},
+			wantComponents: []kadm.DescribeClientQuotaComponent{{Type: EntityTypeUser, MatchType: kmsg.QuotasMatchTypeDefault}},
+			exists:         true,
+		},
+		"OnlyUnmanagedQuotas": {
+			entity: userEntity(),
+			cl: &fakeQuotaAdmin{described: kadm.DescribedClientQuotas{
+				{Entity: userEntity(), Values: kadm.ClientQuotaValues{{Key: "connection_creation_rate", Value: 5}}},
+			}},
+			want:           map[string]float64{"connection_creation_rate": 5},
+			wantComponents: []kadm.DescribeClientQuotaComponent{{Type: EntityTypeUser, MatchName: ptr(testUser)}},
+		},
+		"NoQuotas": {
+			entity:         userEntity(),
+			cl:             &fakeQuotaAdmin{},
+			want:           map[string]float64{},
+			wantComponents: []kadm.DescribeClientQuotaComponent{{Type: EntityTypeUser, MatchName: ptr(testUser)}},
+		},
+		"RequestError": {
+			entity:  userEntity(),
+			cl:      &fakeQuotaAdmin{describeErr: errors.New("boom")},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Get(context.Background(), tc.cl, tc.entity)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got.Values); diff != "" {
+				t.Errorf("Get(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantComponents, tc.cl.components); diff != "" {
+				t.Errorf("Get(...): -want components, +got components:\n%s", diff)
+			}
+			assert.Equal(t, tc.exists, got.Exists())
+		})
+	}
+}
+
+func TestAlter(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.QuotaValues
+		existing map[string]float64
+		alterErr error
+		want     []kadm.AlterClientQuotaOp
+		wantErr  bool
+	}{
+		"Create": {
+			in: v1alpha1.QuotaValues{ProducerByteRate: ptr(int64(1024)), RequestPercentage: ptr("12.5")},
+			want: []kadm.AlterClientQuotaOp{
+				{Key: KeyProducerByteRate, Value: 1024},
+				{Key: KeyRequestPercentage, Value: 12.5},
+			},
+		},
+		"ChangeAndRemove": {
+			in:       v1alpha1.QuotaValues{ConsumerByteRate: ptr(int64(2048))},
+			existing: map[string]float64{KeyConsumerByteRate: 1024, KeyControllerMutationRate: 10, "connection_creation_rate": 5},
+			want: []kadm.AlterClientQuotaOp{
+				{Key: KeyConsumerByteRate, Value: 2048},
+				{Key: KeyControllerMutationRate, Remove: true},
+			},
+		},
+		"Unchanged": {
+			in:       v1alpha1.QuotaValues{ConsumerByteRate: ptr(int64(2048))},
+			existing: map[string]float64{KeyConsumerByteRate: 2048},
+		},
+		"InvalidValue": {
+			in:      v1alpha1.QuotaValues{RequestPercentage: ptr("lots")},
+			wantErr: true,
+		},
+		"BrokerError": {
+			in:       v1alpha1.QuotaValues{ProducerByteRate: ptr(int64(1024))},
+			alterErr: kerr.InvalidRequest,
+			want:     []kadm.AlterClientQuotaOp{{Key: KeyProducerByteRate, Value: 1024}},
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeQuotaAdmin{alterErr: tc.alterErr}
+			in := &v1alpha1.QuotaParameters{QuotaValues: tc.in}
+			err := Alter(context.Background(), cl, in, &Quota{Entity: userEntity(), Values: tc.existing})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			var got []kadm.AlterClientQuotaOp
+			if len(cl.altered) > 0 {
+				got = cl.altered[0].Ops
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Alter(...): -want ops, +got ops:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cl := &fakeQuotaAdmin{}
+	existing := &Quota{Entity: userEntity(), Values: map[string]float64{KeyProducerByteRate: 1024, "connection_creation_rate": 5}}
+
+	require.NoError(t, Delete(context.Background(), cl, existing))
+	want := []kadm.AlterClientQuotaEntry{{Entity: userEntity(), Ops: []kadm.AlterClientQuotaOp{{Key: KeyProducerByteRate, Remove: true}}}}
+	if diff := cmp.Diff(want, cl.altered); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.QuotaValues
+		observed map[string]float64
+		want     bool
+	}{
+		"UpToDate": {
+			in:       v1alpha1.QuotaValues{ProducerByteRate: ptr(int64(1024)), ControllerMutationRate: ptr("2.5")},
+			observed: map[string]float64{KeyProducerByteRate: 1024, KeyControllerMutationRate: 2.5, "connection_creation_rate": 5},
+			want:     true,
+		},
+		"ValueDiffers": {
+			in:       v1alpha1.QuotaValues{ProducerByteRate: ptr(int64(1024))},
+			observed: map[string]float64{KeyProducerByteRate: 2048},
+		},
+		"Missing": {
+			in: v1alpha1.QuotaValues{ProducerByteRate: ptr(int64(1024))},
+		},
+		"ExtraManagedQuota": {
+			in:       v1alpha1.QuotaValues{ProducerByteRate: ptr(int64(1024))},
+			observed: map[string]float64{KeyProducerByteRate: 1024, KeyConsumerByteRate: 1024},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsUpToDate(&v1alpha1.QuotaParameters{QuotaValues: tc.in}, &Quota{Values: tc.observed})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestToObservation(t *testing.T) {
+	q := &Quota{Entity: userEntity(), Values: map[string]float64{KeyConsumerByteRate: 2048, KeyRequestPercentage: 12.5}}
+	want := v1alpha1.QuotaObservation{
+		Entity: "{user=alice}",
+		QuotaValues: v1alpha1.QuotaValues{
+			ConsumerByteRate:  ptr(int64(2048)),
+			RequestPercentage: ptr("12.5"),
+		},
+	}
+	if diff := cmp.Diff(want, q.ToObservation()); diff != "" {
+		t.Errorf("ToObservation(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,205 @@
+package topic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// configType is the type of a topic config value as defined by Kafka.
+type configType int
+
+const (
+	typeBoolean configType = iota
+	typeInt
+	typeLong
+	typeDouble
+	typeString
+	typeList
+)
+
+func (t configType) String() string {
+	switch t {
+	case typeBoolean:
+		return "boolean"
+	case typeInt:
+		return "int"
+	case typeLong:
+		return "long"
+	case typeDouble:
+		return "double"
+	case typeList:
+		return "list"
+	default:
+		return "string"
+	}
+}
+
+// configDef describes the valid values of a topic config.
+type configDef struct {
+	typ configType
+	min *float64
+	max *float64
+	// values are the valid values of a string config, or of every element of
+	// a list config. Any value is valid if empty.
+	values []string
+}
+
+func atLeast(typ configType, min float64) configDef {
+	return configDef{typ: typ, min: &min}
+}
+
+func between(typ configType, min, max float64) configDef {
+	return configDef{typ: typ, min: &min, max: &max}
+}
+
+func oneOf(typ configType, values ...string) configDef {
+	return configDef{typ: typ, values: values}
+}
+
+// topicConfigs is the catalog of topic configs known to the provider, with
+// the types and valid values documented by Apache Kafka.
+var topicConfigs = map[string]configDef{
+	"cleanup.policy":                          oneOf(typeList, "compact", "delete"),
+	"compression.gzip.level":                  between(typeInt, -1, 9),
+	"compression.lz4.level":                   between(typeInt, 1, 17),
+	"compression.type":                        oneOf(typeString, "uncompressed", "zstd", "lz4", "snappy", "gzip", "producer"),
+	"compression.zstd.level":                  between(typeInt, -131072, 22),
+	"delete.retention.ms":                     atLeast(typeLong, 0),
+	"file.delete.delay.ms":                    atLeast(typeLong, 0),
+	"flush.messages":                          atLeast(typeLong, 1),
+	"flush.ms":                                atLeast(typeLong, 0),
+	"follower.replication.throttled.replicas": {typ: typeList},
+	"index.interval.bytes":                    atLeast(typeInt, 0),
+	"leader.replication.throttled.replicas":   {typ: typeList},
+	"local.retention.bytes":                   atLeast(typeLong, -2),
+	"local.retention.ms":                      atLeast(typeLong, -2),
+	"max.compaction.lag.ms":                   atLeast(typeLong, 1),
+	"max.message.bytes":                       atLeast(typeInt, 0),
+	"message.downconversion.enable":           {typ: typeBoolean},
+	"message.format.version":                  {typ: typeString},
+	"message.timestamp.after.max.ms":          atLeast(typeLong, 0),
+	"message.timestamp.before.max.ms":         atLeast(typeLong, 0),
+	"message.timestamp.difference.max.ms":     atLeast(typeLong, 0),
+	"message.timestamp.type":                  oneOf(typeString, "CreateTime", "LogAppendTime"),
+	"min.cleanable.dirty.ratio":               between(typeDouble, 0, 1),
+	"min.compaction.lag.ms":                   atLeast(typeLong, 0),
+	"min.insync.replicas":                     atLeast(typeInt, 1),
+	"preallocate":                             {typ: typeBoolean},
+	"remote.log.copy.disable":                 {typ: typeBoolean},
+	"remote.log.delete.on.disable":            {typ: typeBoolean},
+	"remote.storage.enable":                   {typ: typeBoolean},
+	"retention.bytes":                         {typ: typeLong},
+	"retention.ms":                            atLeast(typeLong, -1),
+	"segment.bytes":                           atLeast(typeInt, 14),
+	"segment.index.bytes":                     atLeast(typeInt, 4),
+	"segment.jitter.ms":                       atLeast(typeLong, 0),
+	"segment.ms":                              atLeast(typeLong, 1),
+	"unclean.leader.election.enable":          {typ: typeBoolean},
+}
+
+const (
+	errInvalidTopicConfig = "invalid topic config"
+	errUnknownConfig      = "unknown config"
+)
+
+// ValidateConfig checks the config keys and values of the parameters against
+// the catalog of known topic configs. Keys missing from the catalog are
+// accepted if the broker reported them in known, which is the observed config
+// of an existing topic, so that configs of newer Kafka versions can be used.
+// Null values are not validated.
+func ValidateConfig(in *v1alpha1.TopicParameters, known map[string]*string) error {
+	config := in.Config
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, k := range keys {
+		def, ok := topicConfigs[k]
+		if !ok {
+			if _, ok := known[k]; !ok && !in.AllowUnknownConfig {
+				errs = append(errs, fmt.Errorf("%s %q", errUnknownConfig, k))
+			}
+			continue
+		}
+		if v := config[k]; v != nil {
+			if err := def.validate(*v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", k, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", errInvalidTopicConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (d configDef) validate(v string) error {
+	switch d.typ {
+	case typeBoolean:
+		if !strings.EqualFold(v, "true") && !strings.EqualFold(v, "false") {
+			return fmt.Errorf("value %q is not a boolean", v)
+		}
+	case typeInt, typeLong, typeDouble:
+		n, err := d.parseNumber(v)
+		if err != nil {
+			return err
+		}
+		if d.min != nil && n < *d.min {
+			return fmt.Errorf("value %s is less than the minimum %s", v, formatNumber(*d.min))
+		}
+		if d.max != nil && n > *d.max {
+			return fmt.Errorf("value %s is greater than the maximum %s", v, formatNumber(*d.max))
+		}
+	case typeString:
+		if len(d.values) > 0 && !slices.Contains(d.values, v) {
+			return fmt.Errorf("value %q is not one of %s", v, strings.Join(d.values, ", "))
+		}
+	case typeList:
+		if len(d.values) == 0 {
+			return nil
+		}
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); !slices.Contains(d.values, e) {
+				return fmt.Errorf("element %q is not one of %s", e, strings.Join(d.values, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func (d configDef) parseNumber(v string) (float64, error) {
+	switch d.typ {
+	case typeInt:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not an %s", v, d.typ)
+		}
+		return float64(n), nil
+	case typeLong:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a %s", v, d.typ)
+		}
+		return float64(n), nil
+	default:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsNaN(n) {
+			return 0, fmt.Errorf("value %q is not a %s", v, d.typ)
+		}
+		return n, nil
+	}
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
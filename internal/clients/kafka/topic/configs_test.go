@@ -0,0 +1,97 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	strPtr := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		config       map[string]*string
+		allowUnknown bool
+		known        map[string]*string
+		wantErr      []string
+	}{
+		"Valid": {
+			config: map[string]*string{
+				configKeyRetentionMs:             strPtr("-1"),
+				"cleanup.policy":                 strPtr("compact, delete"),
+				"compression.type":               strPtr("zstd"),
+				"min.cleanable.dirty.ratio":      strPtr("0.5"),
+				"unclean.leader.election.enable": strPtr("TRUE"),
+				"segment.bytes":                  nil,
+			},
+		},
+		"UnknownKey": {
+			config:  map[string]*string{"retention.msx": strPtr("1000")},
+			wantErr: []string{`unknown config "retention.msx"`},
+		},
+		"UnknownKeyReportedByBroker": {
+			config: map[string]*string{"confluent.placement.constraints": strPtr("{}")},
+			known:  map[string]*string{"confluent.placement.constraints": nil},
+		},
+		"UnknownKeyAllowed": {
+			config:       map[string]*string{"confluent.placement.constraints": strPtr("{}")},
+			allowUnknown: true,
+		},
+		"NotANumber": {
+			config:  map[string]*string{configKeyRetentionMs: strPtr("7d")},
+			wantErr: []string{`retention.ms: value "7d" is not a long`},
+		},
+		"IntOverflow": {
+			config:  map[string]*string{"max.message.bytes": strPtr("3000000000")},
+			wantErr: []string{`max.message.bytes: value "3000000000" is not an int`},
+		},
+		"BelowMinimum": {
+			config:  map[string]*string{"min.insync.replicas": strPtr("0")},
+			wantErr: []string{"min.insync.replicas: value 0 is less than the minimum 1"},
+		},
+		"AboveMaximum": {
+			config:  map[string]*string{"min.cleanable.dirty.ratio": strPtr("1.5")},
+			wantErr: []string{"min.cleanable.dirty.ratio: value 1.5 is greater than the maximum 1"},
+		},
+		"InvalidEnum": {
+			config:  map[string]*string{"compression.type": strPtr("brotli")},
+			wantErr: []string{`compression.type: value "brotli" is not one of`},
+		},
+		"InvalidListElement": {
+			config:  map[string]*string{"cleanup.policy": strPtr("compact,archive")},
+			wantErr: []string{`cleanup.policy: element "archive" is not one of compact, delete`},
+		},
+		"InvalidBoolean": {
+			config:  map[string]*string{"preallocate": strPtr("yes")},
+			wantErr: []string{`preallocate: value "yes" is not a boolean`},
+		},
+		"AllErrorsReported": {
+			config: map[string]*string{
+				"preallocate":   strPtr("yes"),
+				"retention.msx": strPtr("1000"),
+			},
+			wantErr: []string{errInvalidTopicConfig, "preallocate", "retention.msx"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			in := &v1alpha1.TopicParameters{Config: tc.config, AllowUnknownConfig: tc.allowUnknown}
+			err := ValidateConfig(in, tc.known)
+			if len(tc.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tc.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
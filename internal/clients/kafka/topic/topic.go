@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
 )
@@ -13,11 +17,25 @@ import (
 // Topic is a holistic representation of a Kafka Topic with all configurable
 // fields
 type Topic struct {
-	Name              string
+	Name string
+	// ReplicationFactor and Partitions are left unchanged by Update when
+	// zero, and Create uses the broker defaults for them.
 	ReplicationFactor int16
 	Partitions        int32
 	ID                string
 	Config            map[string]*string
+	// Overridden holds the config keys that are set on the topic itself
+	// rather than inherited from the broker.
+	Overridden map[string]bool
+	// DeleteConfig are config keys whose topic overrides are removed by
+	// Update so that they fall back to the broker default.
+	DeleteConfig []string
+	// Layout holds the leader and replicas of every partition, sorted by
+	// partition number.
+	Layout []kadm.PartitionDetail
+	// Assignment maps each partition to the broker IDs holding its replicas,
+	// with the preferred leader first.
+	Assignment map[int32][]int32
 }
 
 const (
@@ -32,6 +50,10 @@ const (
 	errCannotDeleteTopic          = "cannot delete topic"
 	errCannotGetTopic             = "cannot get topic"
 	errCannotUpdateTopicConfigs   = "cannot update topic configs"
+	errCannotListBrokers          = "cannot list brokers"
+	errCannotReassignPartitions   = "cannot reassign topic partitions"
+	errCannotListReassignments    = "cannot list partition reassignments"
+	errNotEnoughBrokers           = "not enough brokers for replication factor"
 
 	// ErrTopicDoesNotExist indicates that the topic of a given name doesn't exist in the external Kafka cluster
 	ErrTopicDoesNotExist = "topic does not exist"
@@ -63,6 +85,11 @@ func Get(ctx context.Context, client *kadm.Client, name string) (*Topic, error)
 		ts.ReplicationFactor = int16(len(t.Partitions[0].Replicas))
 	}
 	ts.ID = t.ID.String()
+	ts.Assignment = make(map[int32][]int32, len(t.Partitions))
+	for _, p := range t.Partitions {
+		ts.Assignment[p.Partition] = p.Replicas
+	}
+	ts.Layout = t.Partitions.Sorted()
 
 	rc, err := tc.On(name, nil)
 	if err != nil {
@@ -72,8 +99,12 @@ func Get(ctx context.Context, client *kadm.Client, name string) (*Topic, error)
 		return nil, fmt.Errorf(errErrorInTopicDescribeResult+": %w", rc.Err)
 	}
 	ts.Config = make(map[string]*string, len(rc.Configs))
+	ts.Overridden = make(map[string]bool)
 	for _, value := range rc.Configs {
 		ts.Config[value.Key] = value.Value
+		if value.Source == kmsg.ConfigSourceDynamicTopicConfig {
+			ts.Overridden[value.Key] = true
+		}
 	}
 	return &ts, nil
 }
@@ -85,7 +116,14 @@ func Create(ctx context.Context, client *kadm.Client, topic *Topic) error {
 		return nil
 	}
 
-	resp, err := client.CreateTopics(ctx, topic.Partitions, topic.ReplicationFactor, topic.Config, topic.Name)
+	partitions, rf := topic.Partitions, topic.ReplicationFactor
+	if partitions <= 0 {
+		partitions = -1
+	}
+	if rf <= 0 {
+		rf = -1
+	}
+	resp, err := client.CreateTopics(ctx, partitions, rf, topic.Config, topic.Name)
 	if err != nil {
 		return err
 	}
@@ -119,29 +157,69 @@ func Delete(ctx context.Context, client *kadm.Client, name string) error {
 	return nil
 }
 
-// Update determines if a Topic Partition or a Topic Admin Config update needs to be called and routes properly
-func Update(ctx context.Context, client *kadm.Client, desired *Topic) error {
+// Parts of a topic that Update changes.
+const (
+	PartPartitions        = "Partitions"
+	PartReplicationFactor = "ReplicationFactor"
+	PartConfig            = "Config"
+)
+
+// UpdateResult reports the parts of a topic that an Update changed and the
+// parts it failed to change, in the order they were applied.
+type UpdateResult struct {
+	Applied []string
+	Failed  []string
+}
+
+// Update computes every change needed to bring the topic to the desired
+// state and applies them in one pass: partitions are added first, then
+// configs are altered and finally a replication factor change is started.
+// A failing change does not prevent the others from being applied; all
+// failures are joined in the returned error.
+func Update(ctx context.Context, client *kadm.Client, desired *Topic) (UpdateResult, error) {
 	existing, err := Get(ctx, client, desired.Name)
 	if err != nil {
-		return fmt.Errorf("%s: %w", errCannotGetTopic, err)
+		return UpdateResult{}, fmt.Errorf("%s: %w", errCannotGetTopic, err)
 	}
 	if existing == nil {
-		return errors.New(ErrTopicDoesNotExist)
+		return UpdateResult{}, errors.New(ErrTopicDoesNotExist)
+	}
+
+	var (
+		res  UpdateResult
+		errs []error
+	)
+	apply := func(part string, err error) {
+		if err != nil {
+			res.Failed = append(res.Failed, part)
+			errs = append(errs, err)
+			return
+		}
+		res.Applied = append(res.Applied, part)
 	}
 
-	if desired.Partitions != existing.Partitions {
-		return updatePartitions(ctx, client, desired, existing)
+	if desired.Partitions > 0 && desired.Partitions != existing.Partitions {
+		err := updatePartitions(ctx, client, desired, existing)
+		apply(PartPartitions, err)
+
+		// New partitions are created with the current replication factor,
+		// so a reassignment must be planned from the refreshed topic.
+		if err == nil && desired.ReplicationFactor > 0 && desired.ReplicationFactor != existing.ReplicationFactor {
+			if refreshed, err := Get(ctx, client, desired.Name); err == nil {
+				existing = refreshed
+			}
+		}
 	}
 
-	if desired.ReplicationFactor != existing.ReplicationFactor {
-		return UpdateReplicationFactor()
+	if changes := configChanges(desired, existing); len(changes) > 0 {
+		apply(PartConfig, updateConfigs(ctx, client, desired.Name, changes))
 	}
 
-	if desired.Config != nil {
-		return updateConfigs(ctx, client, desired, existing)
+	if desired.ReplicationFactor > 0 && desired.ReplicationFactor != existing.ReplicationFactor {
+		apply(PartReplicationFactor, updateReplicationFactor(ctx, client, desired, existing))
 	}
 
-	return nil
+	return res, errors.Join(errs...)
 }
 
 // updatePartitions updates a topic Partition count in Kafka, reusing the already-fetched existing topic.
@@ -164,13 +242,167 @@ func updatePartitions(ctx context.Context, client *kadm.Client, desired *Topic,
 	return nil
 }
 
-// UpdateReplicationFactor is not supported in Kafka. A user is given an error message
-func UpdateReplicationFactor() error {
-	return errors.New("updating replication factor is not supported")
+// updateReplicationFactor starts a partition reassignment that moves every
+// partition of the topic to the desired replication factor. Reassignments are
+// asynchronous in Kafka, so nothing is submitted while a previous one is still
+// in progress; callers use ReassignmentInProgress to learn when it completes.
+func updateReplicationFactor(ctx context.Context, client *kadm.Client, desired *Topic, existing *Topic) error {
+	inProgress, err := ReassignmentInProgress(ctx, client, existing)
+	if err != nil {
+		return err
+	}
+	if inProgress {
+		return nil
+	}
+
+	brokers, err := client.ListBrokers(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotListBrokers, err)
+	}
+
+	req, err := planReassignment(existing, brokers, int(desired.ReplicationFactor))
+	if err != nil {
+		return err
+	}
+	if len(req) == 0 {
+		return nil
+	}
+
+	resp, err := client.AlterPartitionAssignments(ctx, req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotReassignPartitions, err)
+	}
+	if err := resp.Error(); err != nil {
+		return fmt.Errorf("%s: %w", errCannotReassignPartitions, err)
+	}
+	return nil
+}
+
+// ReassignmentInProgress reports whether any partition of the topic still has
+// replicas being added or removed by a partition reassignment.
+func ReassignmentInProgress(ctx context.Context, client *kadm.Client, t *Topic) (bool, error) {
+	s := make(kadm.TopicsSet)
+	for p := range t.Assignment {
+		s.Add(t.Name, p)
+	}
+
+	resp, err := client.ListPartitionReassignments(ctx, s)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", errCannotListReassignments, err)
+	}
+
+	inProgress := false
+	resp.Each(func(r kadm.ListPartitionReassignmentsResponse) {
+		if len(r.AddingReplicas) > 0 || len(r.RemovingReplicas) > 0 {
+			inProgress = true
+		}
+	})
+	return inProgress, nil
 }
 
-// updateConfigs updates topic config keys that differ, batching all changes into a single Kafka call.
-func updateConfigs(ctx context.Context, client *kadm.Client, desired *Topic, existing *Topic) error {
+// planReassignment computes the replica assignment that brings every partition
+// of the topic to the given replication factor. Existing replicas are kept in
+// place and the preferred leader never moves: surplus replicas are dropped from
+// the tail of the list, and new replicas go to the brokers holding the fewest
+// replicas of the topic, preferring racks the partition does not use yet.
+// Partitions that already have the desired replication factor are left out.
+func planReassignment(t *Topic, brokers kadm.BrokerDetails, rf int) (kadm.AlterPartitionAssignmentsReq, error) {
+	if rf > len(brokers) {
+		return nil, fmt.Errorf("%s: %d requested, %d available", errNotEnoughBrokers, rf, len(brokers))
+	}
+
+	racks := make(map[int32]string, len(brokers))
+	load := make(map[int32]int, len(brokers))
+	for _, b := range brokers {
+		if b.Rack != nil {
+			racks[b.NodeID] = *b.Rack
+		}
+		load[b.NodeID] = 0
+	}
+	for _, replicas := range t.Assignment {
+		for _, r := range replicas {
+			if _, ok := load[r]; ok {
+				load[r]++
+			}
+		}
+	}
+
+	partitions := make([]int32, 0, len(t.Assignment))
+	for p := range t.Assignment {
+		partitions = append(partitions, p)
+	}
+	slices.Sort(partitions)
+
+	var req kadm.AlterPartitionAssignmentsReq
+	for _, p := range partitions {
+		current := t.Assignment[p]
+		if len(current) == rf {
+			continue
+		}
+
+		replicas := slices.Clone(current)
+		if len(replicas) > rf {
+			for _, r := range replicas[rf:] {
+				load[r]--
+			}
+			replicas = replicas[:rf]
+		}
+		for len(replicas) < rf {
+			b := pickBroker(brokers, replicas, racks, load)
+			replicas = append(replicas, b)
+			load[b]++
+		}
+		req.Assign(t.Name, p, replicas)
+	}
+	return req, nil
+}
+
+// pickBroker returns the broker that should receive the next replica of a
+// partition currently placed on the given replicas. The caller guarantees that
+// at least one broker does not hold a replica yet.
+func pickBroker(brokers kadm.BrokerDetails, replicas []int32, racks map[int32]string, load map[int32]int) int32 {
+	usedRacks := make(map[string]bool, len(replicas))
+	for _, r := range replicas {
+		if rack := racks[r]; rack != "" {
+			usedRacks[rack] = true
+		}
+	}
+
+	candidates := make([]int32, 0, len(brokers))
+	for _, b := range brokers {
+		if !slices.Contains(replicas, b.NodeID) {
+			candidates = append(candidates, b.NodeID)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ui, uj := usedRacks[racks[ci]], usedRacks[racks[cj]]; ui != uj {
+			return uj
+		}
+		if load[ci] != load[cj] {
+			return load[ci] < load[cj]
+		}
+		return ci < cj
+	})
+	return candidates[0]
+}
+
+// updateConfigs applies the config changes to the topic in a single Kafka
+// call.
+func updateConfigs(ctx context.Context, client *kadm.Client, name string, changes []kadm.AlterConfig) error {
+	r, err := client.AlterTopicConfigs(ctx, changes, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotUpdateTopicConfigs, err)
+	}
+	if len(r) > 0 && r[0].Err != nil {
+		return fmt.Errorf("%s: %w", errCannotUpdateTopicConfigs, r[0].Err)
+	}
+	return nil
+}
+
+// configChanges returns the config changes that bring the existing topic to
+// the desired configs.
+func configChanges(desired *Topic, existing *Topic) []kadm.AlterConfig {
 	var changes []kadm.AlterConfig
 	for key, value := range desired.Config {
 		if stringValue(value) != stringValue(existing.Config[key]) {
@@ -181,17 +413,16 @@ func updateConfigs(ctx context.Context, client *kadm.Client, desired *Topic, exi
 			})
 		}
 	}
-	if len(changes) == 0 {
-		return nil
-	}
-	r, err := client.AlterTopicConfigs(ctx, changes, desired.Name)
-	if err != nil {
-		return fmt.Errorf("%s: %w", errCannotUpdateTopicConfigs, err)
-	}
-	if len(r) > 0 && r[0].Err != nil {
-		return fmt.Errorf("%s: %w", errCannotUpdateTopicConfigs, r[0].Err)
+	for _, key := range desired.DeleteConfig {
+		if _, ok := desired.Config[key]; ok || !existing.Overridden[key] {
+			continue
+		}
+		changes = append(changes, kadm.AlterConfig{
+			Op:   kadm.DeleteConfig,
+			Name: key,
+		})
 	}
-	return nil
+	return changes
 }
 
 // Generate is used to convert Crossplane TopicParameters to Kafka's Topic.
@@ -214,22 +445,41 @@ func Generate(name string, params *v1alpha1.TopicParameters) *Topic {
 
 // ToObservation converts a Kafka Topic to a TopicObservation.
 func (t *Topic) ToObservation() v1alpha1.TopicObservation {
-	return v1alpha1.TopicObservation{
+	o := v1alpha1.TopicObservation{
 		ID:                t.ID,
 		ReplicationFactor: int(t.ReplicationFactor),
 		Partitions:        int(t.Partitions),
 		Config:            t.Config,
 	}
+	for _, d := range t.Layout {
+		p := v1alpha1.TopicPartition{
+			Partition:       d.Partition,
+			Leader:          d.Leader,
+			Replicas:        d.Replicas,
+			ISR:             d.ISR,
+			OfflineReplicas: d.OfflineReplicas,
+			UnderReplicated: len(d.ISR) < len(d.Replicas),
+		}
+		if p.UnderReplicated {
+			o.UnderReplicatedPartitions++
+		}
+		if p.Leader < 0 {
+			o.OfflinePartitions++
+		}
+		o.PartitionLayout = append(o.PartitionLayout, p)
+	}
+	return o
 }
 
 // IsUpToDate returns true if the supplied Kubernetes resource matches the
 // supplied Kafka Topic. Spec config keys not present in observed or with
-// different values trigger an update. Broker defaults not in spec are ignored.
+// different values trigger an update. Broker defaults not in spec are ignored,
+// as are unset partitions and replication factor.
 func IsUpToDate(in *v1alpha1.TopicParameters, observed *Topic) bool {
-	if in.Partitions != int(observed.Partitions) {
+	if in.Partitions != 0 && in.Partitions != int(observed.Partitions) {
 		return false
 	}
-	if in.ReplicationFactor != int(observed.ReplicationFactor) {
+	if in.ReplicationFactor != 0 && in.ReplicationFactor != int(observed.ReplicationFactor) {
 		return false
 	}
 	for k, v := range in.Config {
@@ -241,6 +491,91 @@ func IsUpToDate(in *v1alpha1.TopicParameters, observed *Topic) bool {
 	return true
 }
 
+// LateInitialize fills the unset partitions, replication factor and config of
+// the parameters from the observed topic. Only the configs set on the topic
+// itself are copied; configs inherited from the broker are left out. It
+// returns true if any parameter was filled.
+func LateInitialize(in *v1alpha1.TopicParameters, observed *Topic) bool {
+	li := false
+	if in.Partitions == 0 && observed.Partitions > 0 {
+		in.Partitions = int(observed.Partitions)
+		li = true
+	}
+	if in.ReplicationFactor == 0 && observed.ReplicationFactor > 0 {
+		in.ReplicationFactor = int(observed.ReplicationFactor)
+		li = true
+	}
+	if in.Config == nil {
+		for k := range observed.Overridden {
+			v := observed.Config[k]
+			if v == nil {
+				// Sensitive configs are not disclosed by the broker.
+				continue
+			}
+			if in.Config == nil {
+				in.Config = make(map[string]*string, len(observed.Overridden))
+			}
+			value := *v
+			in.Config[k] = &value
+			li = true
+		}
+	}
+	return li
+}
+
+// AnnotationKeyManagedConfigKeys is the annotation of a Topic that records the
+// config keys the provider has set on the topic, separated by commas. Keys that
+// are removed from the spec are deleted from the topic so that it falls back to
+// the broker default. It is kept in an annotation rather than in the status so
+// that it survives a backup and restore of the Topic.
+const AnnotationKeyManagedConfigKeys = "kafka.crossplane.io/managed-config-keys"
+
+// ParseConfigKeys returns the config keys of a managed config keys
+// annotation.
+func ParseConfigKeys(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// FormatConfigKeys returns the managed config keys annotation of the keys. The
+// keys are sorted and deduplicated.
+func FormatConfigKeys(keys []string) string {
+	keys = slices.Clone(keys)
+	sort.Strings(keys)
+	return strings.Join(slices.Compact(keys), ",")
+}
+
+// ManagedConfigKeys returns the config keys managed for the topic: the keys
+// in the spec, and the previously managed keys that are still overridden on
+// the observed topic. The result is sorted.
+func ManagedConfigKeys(in *v1alpha1.TopicParameters, previous []string, observed *Topic) []string {
+	keys := make([]string, 0, len(in.Config)+len(previous))
+	for k := range in.Config {
+		keys = append(keys, k)
+	}
+	for _, k := range previous {
+		if _, ok := in.Config[k]; !ok && observed.Overridden[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return slices.Compact(keys)
+}
+
+// StaleConfigKeys returns the managed config keys that are no longer in the
+// spec, in the order they are managed.
+func StaleConfigKeys(in *v1alpha1.TopicParameters, managed []string) []string {
+	var stale []string
+	for _, k := range managed {
+		if _, ok := in.Config[k]; !ok {
+			stale = append(stale, k)
+		}
+	}
+	return stale
+}
+
 func stringValue(p *string) string {
 	if p == nil {
 		return ""
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -27,7 +28,7 @@ func TestCreate(t *testing.T) {
 
 	type args struct {
 		ctx    context.Context
-		client *kadm.Client
+		client *kafka.Client
 		topic  *Topic
 	}
 	{
@@ -86,7 +87,7 @@ func TestCreate(t *testing.T) {
 
 		for _, tt := range cases {
 			t.Run(tt.name, func(t *testing.T) {
-				if err := Create(tt.args.ctx, tt.args.client, tt.args.topic); (err != nil) != tt.wantErr {
+				if err := Create(tt.args.ctx, tt.args.client.Client, tt.args.topic); (err != nil) != tt.wantErr {
 					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 				}
 			})
@@ -99,7 +100,7 @@ func TestGet(t *testing.T) {
 
 	type args struct {
 		ctx    context.Context
-		client *kadm.Client
+		client *kafka.Client
 		name   string
 	}
 	cases := map[string]struct {
@@ -142,7 +143,7 @@ func TestGet(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Get(tt.args.ctx, tt.args.client, tt.args.name)
+			got, err := Get(tt.args.ctx, tt.args.client.Client, tt.args.name)
 			fmt.Println(err)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
@@ -183,7 +184,7 @@ func TestGetAtProviderFields(t *testing.T) {
 		t.Fatalf("failed to create admin client: %v", err)
 	}
 
-	got, err := Get(ctx, newAc, "pre-existing")
+	got, err := Get(ctx, newAc.Client, "pre-existing")
 	if err != nil {
 		t.Fatalf("Get() returned error: %v", err)
 	}
@@ -401,6 +402,14 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			want: true,
 		},
+		"UnsetPartitionsAndReplicationFactor": {
+			in: &v1alpha1.TopicParameters{},
+			observed: &Topic{
+				ReplicationFactor: 3,
+				Partitions:        6,
+			},
+			want: true,
+		},
 	}
 	for name, tt := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -420,7 +429,7 @@ func TestCreateDuplicateTopic(t *testing.T) {
 
 	type args struct {
 		ctx    context.Context
-		client *kadm.Client
+		client *kafka.Client
 		topic  *Topic
 	}
 	{
@@ -491,7 +500,7 @@ func TestCreateDuplicateTopic(t *testing.T) {
 
 		for _, tt := range cases {
 			t.Run(tt.name, func(t *testing.T) {
-				if err := Create(tt.args.ctx, tt.args.client, tt.args.topic); (err != nil) != tt.wantErr {
+				if err := Create(tt.args.ctx, tt.args.client.Client, tt.args.topic); (err != nil) != tt.wantErr {
 					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 				}
 			})
@@ -504,7 +513,7 @@ func TestDelete(t *testing.T) {
 
 	type args struct {
 		ctx    context.Context
-		client *kadm.Client
+		client *kafka.Client
 		name   string
 	}
 	cases := map[string]struct {
@@ -560,7 +569,7 @@ func TestDelete(t *testing.T) {
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := Delete(tt.args.ctx, tt.args.client, tt.args.name); (err != nil) != tt.wantErr {
+			if err := Delete(tt.args.ctx, tt.args.client.Client, tt.args.name); (err != nil) != tt.wantErr {
 				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -588,7 +597,7 @@ func TestPreExistingTopicReconcile(t *testing.T) {
 	meta.SetExternalName(cr, "pre-existing")
 
 	// Create on an already-existing topic must succeed (idempotent)
-	err = Create(ctx, client, &Topic{
+	err = Create(ctx, client.Client, &Topic{
 		Name:              meta.GetExternalName(cr),
 		ReplicationFactor: 1,
 		Partitions:        1,
@@ -596,7 +605,7 @@ func TestPreExistingTopicReconcile(t *testing.T) {
 	require.NoError(t, err, "Create on pre-existing topic should be idempotent")
 
 	// Get must return all fields needed for status.atProvider
-	got, err := Get(ctx, client, meta.GetExternalName(cr))
+	got, err := Get(ctx, client.Client, meta.GetExternalName(cr))
 	require.NoError(t, err, "Get on pre-existing topic should succeed")
 
 	// Populate status.atProvider the same way the controller does.
@@ -628,11 +637,11 @@ func TestPreExistingTopicUpdateConfig(t *testing.T) {
 	const preExistingTopic = "pre-existing"
 
 	// Read original config to restore after test
-	original, err := Get(ctx, client, preExistingTopic)
+	original, err := Get(ctx, client.Client, preExistingTopic)
 	require.NoError(t, err)
 
 	newRetention := "172800000"
-	err = Update(ctx, client, &Topic{
+	_, err = Update(ctx, client.Client, &Topic{
 		Name:              preExistingTopic,
 		ReplicationFactor: original.ReplicationFactor,
 		Partitions:        original.Partitions,
@@ -641,7 +650,7 @@ func TestPreExistingTopicUpdateConfig(t *testing.T) {
 	require.NoError(t, err, "Update config on pre-existing topic should succeed")
 
 	// Get must reflect the updated config in status.atProvider fields
-	got, err := Get(ctx, client, preExistingTopic)
+	got, err := Get(ctx, client.Client, preExistingTopic)
 	require.NoError(t, err)
 
 	assert.Equal(t, preExistingTopic, got.Name)
@@ -655,7 +664,7 @@ func TestPreExistingTopicUpdateConfig(t *testing.T) {
 	// Restore original value
 	t.Cleanup(func() {
 		originalRetention := original.Config[configKeyRetentionMs]
-		_ = Update(ctx, client, &Topic{
+		_, _ = Update(ctx, client.Client, &Topic{
 			Name:              preExistingTopic,
 			ReplicationFactor: original.ReplicationFactor,
 			Partitions:        original.Partitions,
@@ -695,3 +704,326 @@ func TestToObservation(t *testing.T) {
 	assert.Equal(t, int(tpc.Partitions), got.Partitions)
 	assert.Equal(t, tpc.Config, got.Config)
 }
+
+func TestPlanReassignment(t *testing.T) {
+	t.Parallel()
+
+	rack := func(s string) *string { return &s }
+	threeBrokers := kadm.BrokerDetails{{NodeID: 1}, {NodeID: 2}, {NodeID: 3}}
+
+	cases := map[string]struct {
+		reason  string
+		topic   *Topic
+		brokers kadm.BrokerDetails
+		rf      int
+		want    kadm.AlterPartitionAssignmentsReq
+		wantErr bool
+	}{
+		"IncreaseSpreadsNewReplicas": {
+			reason: "New replicas should go to the least loaded brokers while keeping existing replicas in place",
+			topic: &Topic{
+				Name:       "t",
+				Assignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}, 2: {3, 1}},
+			},
+			brokers: threeBrokers,
+			rf:      3,
+			want: kadm.AlterPartitionAssignmentsReq{
+				"t": {0: {1, 2, 3}, 1: {2, 3, 1}, 2: {3, 1, 2}},
+			},
+		},
+		"DecreaseKeepsPreferredLeader": {
+			reason: "Surplus replicas should be dropped from the tail so the preferred leader stays first",
+			topic: &Topic{
+				Name:       "t",
+				Assignment: map[int32][]int32{0: {3, 1, 2}, 1: {1, 2, 3}},
+			},
+			brokers: threeBrokers,
+			rf:      1,
+			want: kadm.AlterPartitionAssignmentsReq{
+				"t": {0: {3}, 1: {1}},
+			},
+		},
+		"PrefersUnusedRack": {
+			reason: "A broker in a rack the partition does not use yet should be preferred over a less loaded one",
+			topic: &Topic{
+				Name:       "t",
+				Assignment: map[int32][]int32{0: {1}},
+			},
+			brokers: kadm.BrokerDetails{
+				{NodeID: 1, Rack: rack("a")},
+				{NodeID: 2, Rack: rack("a")},
+				{NodeID: 3, Rack: rack("b")},
+			},
+			rf: 2,
+			want: kadm.AlterPartitionAssignmentsReq{
+				"t": {0: {1, 3}},
+			},
+		},
+		"SkipsPartitionsAlreadyAtReplicationFactor": {
+			reason: "Partitions that already have the desired replication factor should not be reassigned",
+			topic: &Topic{
+				Name:       "t",
+				Assignment: map[int32][]int32{0: {1, 2}, 1: {2}},
+			},
+			brokers: threeBrokers,
+			rf:      2,
+			want: kadm.AlterPartitionAssignmentsReq{
+				"t": {1: {2, 3}},
+			},
+		},
+		"NotEnoughBrokers": {
+			reason: "A replication factor above the number of brokers should be rejected",
+			topic: &Topic{
+				Name:       "t",
+				Assignment: map[int32][]int32{0: {1}},
+			},
+			brokers: threeBrokers,
+			rf:      4,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := planReassignment(tc.topic, tc.brokers, tc.rf)
+			if tc.wantErr {
+				require.Error(t, err, tc.reason)
+				return
+			}
+			require.NoError(t, err, tc.reason)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nplanReassignment(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConfigChanges(t *testing.T) {
+	t.Parallel()
+
+	strPtr := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		desired  *Topic
+		existing *Topic
+		want     []kadm.AlterConfig
+	}{
+		"SetChangedKey": {
+			desired: &Topic{Config: map[string]*string{configKeyRetentionMs: strPtr("1000")}},
+			existing: &Topic{
+				Config: map[string]*string{configKeyRetentionMs: strPtr("604800000")},
+			},
+			want: []kadm.AlterConfig{{Op: kadm.SetConfig, Name: configKeyRetentionMs, Value: strPtr("1000")}},
+		},
+		"DeleteRemovedOverride": {
+			desired: &Topic{DeleteConfig: []string{configKeyRetentionMs}},
+			existing: &Topic{
+				Config:     map[string]*string{configKeyRetentionMs: strPtr("1000")},
+				Overridden: map[string]bool{configKeyRetentionMs: true},
+			},
+			want: []kadm.AlterConfig{{Op: kadm.DeleteConfig, Name: configKeyRetentionMs}},
+		},
+		"SkipDeleteOfInheritedValue": {
+			desired: &Topic{DeleteConfig: []string{configKeyRetentionMs}},
+			existing: &Topic{
+				Config: map[string]*string{configKeyRetentionMs: strPtr("604800000")},
+			},
+		},
+		"SkipDeleteOfKeyInSpec": {
+			desired: &Topic{
+				Config:       map[string]*string{configKeyRetentionMs: strPtr("1000")},
+				DeleteConfig: []string{configKeyRetentionMs},
+			},
+			existing: &Topic{
+				Config:     map[string]*string{configKeyRetentionMs: strPtr("1000")},
+				Overridden: map[string]bool{configKeyRetentionMs: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.want, configChanges(tc.desired, tc.existing)); diff != "" {
+				t.Errorf("configChanges(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	t.Parallel()
+
+	strPtr := func(s string) *string { return &s }
+
+	observed := &Topic{
+		ReplicationFactor: 3,
+		Partitions:        6,
+		Config: map[string]*string{
+			configKeyRetentionMs:  strPtr("86400000"),
+			"cleanup.policy":      strPtr("delete"),
+			"sasl.jaas.config":    nil,
+			"min.insync.replicas": strPtr("2"),
+		},
+		Overridden: map[string]bool{configKeyRetentionMs: true, "sasl.jaas.config": true, "min.insync.replicas": true},
+	}
+
+	cases := map[string]struct {
+		in     v1alpha1.TopicParameters
+		want   v1alpha1.TopicParameters
+		wantLI bool
+	}{
+		"Unset": {
+			want: v1alpha1.TopicParameters{
+				ReplicationFactor: 3,
+				Partitions:        6,
+				Config: map[string]*string{
+					configKeyRetentionMs:  strPtr("86400000"),
+					"min.insync.replicas": strPtr("2"),
+				},
+			},
+			wantLI: true,
+		},
+		"PartiallySet": {
+			in: v1alpha1.TopicParameters{
+				Partitions: 12,
+				Config:     map[string]*string{"cleanup.policy": strPtr("compact")},
+			},
+			want: v1alpha1.TopicParameters{
+				ReplicationFactor: 3,
+				Partitions:        12,
+				Config:            map[string]*string{"cleanup.policy": strPtr("compact")},
+			},
+			wantLI: true,
+		},
+		"AllSet": {
+			in: v1alpha1.TopicParameters{
+				ReplicationFactor: 1,
+				Partitions:        1,
+				Config:            map[string]*string{},
+			},
+			want: v1alpha1.TopicParameters{
+				ReplicationFactor: 1,
+				Partitions:        1,
+				Config:            map[string]*string{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := tc.in
+			li := LateInitialize(&got, observed)
+			assert.Equal(t, tc.wantLI, li)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManagedConfigKeys(t *testing.T) {
+	t.Parallel()
+
+	strPtr := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		in        v1alpha1.TopicParameters
+		previous  []string
+		observed  *Topic
+		want      []string
+		wantStale []string
+	}{
+		"SpecKeys": {
+			in: v1alpha1.TopicParameters{Config: map[string]*string{
+				configKeyRetentionMs: strPtr("1000"),
+				"cleanup.policy":     strPtr("compact"),
+			}},
+			observed: &Topic{},
+			want:     []string{"cleanup.policy", configKeyRetentionMs},
+		},
+		"RemovedKeyStillOverridden": {
+			in:        v1alpha1.TopicParameters{Config: map[string]*string{"cleanup.policy": strPtr("compact")}},
+			previous:  []string{"cleanup.policy", configKeyRetentionMs},
+			observed:  &Topic{Overridden: map[string]bool{"cleanup.policy": true, configKeyRetentionMs: true}},
+			want:      []string{"cleanup.policy", configKeyRetentionMs},
+			wantStale: []string{configKeyRetentionMs},
+		},
+		"RemovedKeyDeleted": {
+			in:       v1alpha1.TopicParameters{Config: map[string]*string{"cleanup.policy": strPtr("compact")}},
+			previous: []string{"cleanup.policy", configKeyRetentionMs},
+			observed: &Topic{Overridden: map[string]bool{"cleanup.policy": true}},
+			want:     []string{"cleanup.policy"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := ManagedConfigKeys(&tc.in, tc.previous, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ManagedConfigKeys(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStale, StaleConfigKeys(&tc.in, got)); diff != "" {
+				t.Errorf("StaleConfigKeys(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigKeysAnnotation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		keys []string
+		want string
+	}{
+		"None": {},
+		"SortedAndDeduplicated": {
+			keys: []string{configKeyRetentionMs, "cleanup.policy", configKeyRetentionMs},
+			want: "cleanup.policy," + configKeyRetentionMs,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatConfigKeys(tc.keys)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FormatConfigKeys(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(got, strings.Join(ParseConfigKeys(got), ",")); diff != "" {
+				t.Errorf("ParseConfigKeys(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToObservationLayout(t *testing.T) {
+	t.Parallel()
+
+	tpc := &Topic{
+		Partitions: 3,
+		Layout: []kadm.PartitionDetail{
+			{Partition: 0, Leader: 1, Replicas: []int32{1, 2}, ISR: []int32{1, 2}},
+			{Partition: 1, Leader: 2, Replicas: []int32{2, 3}, ISR: []int32{2}, OfflineReplicas: []int32{3}},
+			{Partition: 2, Leader: -1, Replicas: []int32{3}, OfflineReplicas: []int32{3}},
+		},
+	}
+
+	got := tpc.ToObservation()
+
+	want := []v1alpha1.TopicPartition{
+		{Partition: 0, Leader: 1, Replicas: []int32{1, 2}, ISR: []int32{1, 2}},
+		{Partition: 1, Leader: 2, Replicas: []int32{2, 3}, ISR: []int32{2}, OfflineReplicas: []int32{3}, UnderReplicated: true},
+		{Partition: 2, Leader: -1, Replicas: []int32{3}, OfflineReplicas: []int32{3}, UnderReplicated: true},
+	}
+	if diff := cmp.Diff(want, got.PartitionLayout); diff != "" {
+		t.Errorf("ToObservation().PartitionLayout: -want, +got:\n%s", diff)
+	}
+	assert.Equal(t, 2, got.UnderReplicatedPartitions)
+	assert.Equal(t, 1, got.OfflinePartitions)
+}
@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// ClientTuning tunes the connections and requests of the Kafka client. Zero
+// values leave the franz-go defaults in place.
+type ClientTuning struct {
+	DialTimeout     time.Duration
+	RequestTimeout  time.Duration
+	RequestRetries  *int
+	RetryBackoff    time.Duration
+	MetadataMaxAge  time.Duration
+	ConnIdleTimeout time.Duration
+	ClientID        string
+	Rack            string
+}
+
+// ClientDefaults tune the clients of ProviderConfigs that do not override
+// them in their client settings. Set before starting controllers.
+var ClientDefaults = ClientTuning{DialTimeout: 10 * time.Second}
+
+// override returns the tuning with the fields set in the client settings
+// replaced.
+func (t ClientTuning) override(s *ClientSettings) (ClientTuning, error) {
+	if s == nil {
+		return t, nil
+	}
+	for _, d := range []struct {
+		name  string
+		value string
+		into  *time.Duration
+	}{
+		{name: "dialTimeout", value: s.DialTimeout, into: &t.DialTimeout},
+		{name: "requestTimeout", value: s.RequestTimeout, into: &t.RequestTimeout},
+		{name: "retryBackoff", value: s.RetryBackoff, into: &t.RetryBackoff},
+		{name: "metadataMaxAge", value: s.MetadataMaxAge, into: &t.MetadataMaxAge},
+		{name: "connIdleTimeout", value: s.ConnIdleTimeout, into: &t.ConnIdleTimeout},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil || v <= 0 {
+			return ClientTuning{}, fmt.Errorf("%s: %s %q: must be a positive duration", errInvalidClientSettings, d.name, d.value)
+		}
+		*d.into = v
+	}
+	if s.RequestRetries != nil {
+		if *s.RequestRetries < 0 {
+			return ClientTuning{}, fmt.Errorf("%s: requestRetries %d: must be >= 0", errInvalidClientSettings, *s.RequestRetries)
+		}
+		t.RequestRetries = s.RequestRetries
+	}
+	if s.ClientID != "" {
+		t.ClientID = s.ClientID
+	}
+	if s.Rack != "" {
+		t.Rack = s.Rack
+	}
+	return t, nil
+}
+
+// opts returns the franz-go client options of the tuning.
+func (t ClientTuning) opts() []kgo.Opt {
+	var opts []kgo.Opt
+	if t.DialTimeout > 0 {
+		opts = append(opts, kgo.DialTimeout(t.DialTimeout))
+	}
+	if t.RequestTimeout > 0 {
+		opts = append(opts, kgo.RequestTimeoutOverhead(t.RequestTimeout))
+	}
+	if t.RequestRetries != nil {
+		opts = append(opts, kgo.RequestRetries(*t.RequestRetries))
+	}
+	if t.RetryBackoff > 0 {
+		backoff := t.RetryBackoff
+		opts = append(opts, kgo.RetryBackoffFn(func(int) time.Duration { return backoff }))
+	}
+	if t.MetadataMaxAge > 0 {
+		opts = append(opts, kgo.MetadataMaxAge(t.MetadataMaxAge))
+	}
+	if t.ConnIdleTimeout > 0 {
+		opts = append(opts, kgo.ConnIdleTimeout(t.ConnIdleTimeout))
+	}
+	if t.ClientID != "" {
+		opts = append(opts, kgo.ClientID(t.ClientID))
+	}
+	if t.Rack != "" {
+		opts = append(opts, kgo.Rack(t.Rack))
+	}
+	return opts
+}
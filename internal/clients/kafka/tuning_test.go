@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestClientTuningOverride(t *testing.T) {
+	defaults := ClientTuning{
+		DialTimeout:    30 * time.Second,
+		RequestRetries: ptr(20),
+		ClientID:       "kgo",
+	}
+
+	cases := map[string]struct {
+		settings *ClientSettings
+		want     ClientTuning
+		wantErr  string
+	}{
+		"NoSettings": {
+			want: defaults,
+		},
+		"Overridden": {
+			settings: &ClientSettings{
+				DialTimeout:     "5s",
+				RequestTimeout:  "15s",
+				RequestRetries:  ptr(0),
+				RetryBackoff:    "500ms",
+				MetadataMaxAge:  "1m",
+				ConnIdleTimeout: "45s",
+				ClientID:        "provider-kafka",
+				Rack:            "eu-west-1a",
+			},
+			want: ClientTuning{
+				DialTimeout:     5 * time.Second,
+				RequestTimeout:  15 * time.Second,
+				RequestRetries:  ptr(0),
+				RetryBackoff:    500 * time.Millisecond,
+				MetadataMaxAge:  time.Minute,
+				ConnIdleTimeout: 45 * time.Second,
+				ClientID:        "provider-kafka",
+				Rack:            "eu-west-1a",
+			},
+		},
+		"PartiallyOverridden": {
+			settings: &ClientSettings{MetadataMaxAge: "1m"},
+			want: ClientTuning{
+				DialTimeout:    30 * time.Second,
+				RequestRetries: ptr(20),
+				MetadataMaxAge: time.Minute,
+				ClientID:       "kgo",
+			},
+		},
+		"InvalidDuration": {
+			settings: &ClientSettings{DialTimeout: "5 seconds"},
+			wantErr:  `dialTimeout "5 seconds"`,
+		},
+		"NegativeDuration": {
+			settings: &ClientSettings{RetryBackoff: "-1s"},
+			wantErr:  `retryBackoff "-1s"`,
+		},
+		"NegativeRetries": {
+			settings: &ClientSettings{RequestRetries: ptr(-1)},
+			wantErr:  "requestRetries -1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := defaults.override(tc.settings)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, errInvalidClientSettings)
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("override(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientTuningOpts(t *testing.T) {
+	assert.Empty(t, ClientTuning{}.opts(), "zero tuning must keep the franz-go defaults")
+
+	all := ClientTuning{
+		DialTimeout:     time.Second,
+		RequestTimeout:  time.Second,
+		RequestRetries:  ptr(0),
+		RetryBackoff:    time.Second,
+		MetadataMaxAge:  time.Second,
+		ConnIdleTimeout: time.Second,
+		ClientID:        "id",
+		Rack:            "rack",
+	}
+	assert.Len(t, all.opts(), 8)
+}
@@ -0,0 +1,193 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// adminClient is the subset of kadm.Client methods used by this package.
+// *kadm.Client satisfies this interface without any changes to callers.
+type adminClient interface {
+	DescribeUserSCRAMs(ctx context.Context, users ...string) (kadm.DescribedUserSCRAMs, error)
+	AlterUserSCRAMs(ctx context.Context, del []kadm.DeleteSCRAM, upsert []kadm.UpsertSCRAM) (kadm.AlteredUserSCRAMs, error)
+}
+
+// User is a holistic representation of a Kafka SCRAM user.
+type User struct {
+	Name       string
+	Mechanism  kadm.ScramMechanism
+	Iterations int32
+	Password   string
+}
+
+// Connection detail keys published for a User.
+const (
+	ConnectionKeyUsername  = "username"
+	ConnectionKeyPassword  = "password"
+	ConnectionKeyBrokers   = "brokers"
+	ConnectionKeyMechanism = "mechanism"
+)
+
+// AnnotationKeyPasswordSecretVersion is the annotation of a User that records
+// the resource version of the password Secret that was last applied to Kafka.
+// Kafka never returns the stored password, so a password change is detected
+// through it.
+const AnnotationKeyPasswordSecretVersion = "kafka.crossplane.io/password-secret-version"
+
+const (
+	// MechanismScramSha256 is the SCRAM-SHA-256 mechanism name.
+	MechanismScramSha256 = "SCRAM-SHA-256"
+	// MechanismScramSha512 is the SCRAM-SHA-512 mechanism name.
+	MechanismScramSha512 = "SCRAM-SHA-512"
+
+	// DefaultMechanism is used when no mechanism is configured.
+	DefaultMechanism = MechanismScramSha512
+	// DefaultIterations is used when no iteration count is configured.
+	DefaultIterations int32 = 4096
+)
+
+const (
+	errCannotDescribeUser = "cannot describe user SCRAM credentials"
+	errCannotAlterUser    = "cannot alter user SCRAM credentials"
+	errNoAlterResponse    = "no alter response for user"
+	errUnknownMechanism   = "unknown SCRAM mechanism"
+
+	// ErrUserDoesNotExist indicates that the user of a given name has no
+	// SCRAM credentials in the external Kafka cluster
+	ErrUserDoesNotExist = "user does not exist"
+)
+
+// Credentials returns the SCRAM credentials Kafka has for the named user.
+func Credentials(ctx context.Context, client adminClient, name string) ([]kadm.CredInfo, error) {
+	resp, err := client.DescribeUserSCRAMs(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribeUser, err)
+	}
+	u, ok := resp[name]
+	if !ok || errors.Is(u.Err, kerr.ResourceNotFound) || (u.Err == nil && len(u.CredInfos) == 0) {
+		return nil, errors.New(ErrUserDoesNotExist)
+	}
+	if u.Err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribeUser, u.Err)
+	}
+	return u.CredInfos, nil
+}
+
+// Upsert creates or updates the SCRAM credential of the user and removes
+// credentials stored with any other mechanism.
+func Upsert(ctx context.Context, client adminClient, user *User, existing []kadm.CredInfo) error {
+	if err := alter(ctx, client, nil, []kadm.UpsertSCRAM{{
+		User:       user.Name,
+		Mechanism:  user.Mechanism,
+		Iterations: user.Iterations,
+		Password:   user.Password,
+	}}); err != nil {
+		return err
+	}
+
+	// Kafka rejects requests that name a user in both upsertions and
+	// deletions, so stale mechanisms are removed in a second request.
+	var del []kadm.DeleteSCRAM
+	for _, c := range existing {
+		if c.Mechanism != user.Mechanism {
+			del = append(del, kadm.DeleteSCRAM{User: user.Name, Mechanism: c.Mechanism})
+		}
+	}
+	if len(del) == 0 {
+		return nil
+	}
+	return alter(ctx, client, del, nil)
+}
+
+// Delete deletes all SCRAM credentials of the user from the Kafka side.
+func Delete(ctx context.Context, client adminClient, name string, existing []kadm.CredInfo) error {
+	if len(existing) == 0 {
+		return nil
+	}
+	del := make([]kadm.DeleteSCRAM, 0, len(existing))
+	for _, c := range existing {
+		del = append(del, kadm.DeleteSCRAM{User: name, Mechanism: c.Mechanism})
+	}
+	err := alter(ctx, client, del, nil)
+	if err != nil && errors.Is(err, kerr.ResourceNotFound) {
+		return nil
+	}
+	return err
+}
+
+func alter(ctx context.Context, client adminClient, del []kadm.DeleteSCRAM, upsert []kadm.UpsertSCRAM) error {
+	resp, err := client.AlterUserSCRAMs(ctx, del, upsert)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errCannotAlterUser, err)
+	}
+	if len(resp) == 0 {
+		return errors.New(errNoAlterResponse)
+	}
+	if err := resp.Error(); err != nil {
+		return fmt.Errorf("%s: %w", errCannotAlterUser, err)
+	}
+	return nil
+}
+
+// ParseMechanism converts a SCRAM mechanism name to its kadm representation.
+// An empty name selects DefaultMechanism.
+func ParseMechanism(name string) (kadm.ScramMechanism, error) {
+	if name == "" {
+		name = DefaultMechanism
+	}
+	switch strings.ToUpper(name) {
+	case MechanismScramSha256:
+		return kadm.ScramSha256, nil
+	case MechanismScramSha512:
+		return kadm.ScramSha512, nil
+	default:
+		return 0, fmt.Errorf("%s: %q", errUnknownMechanism, name)
+	}
+}
+
+// Generate is used to convert Crossplane UserParameters to a Kafka User.
+func Generate(name, password string, params *v1alpha1.UserParameters) (*User, error) {
+	m, err := ParseMechanism(params.Mechanism)
+	if err != nil {
+		return nil, err
+	}
+	iterations := params.Iterations
+	if iterations == 0 {
+		iterations = DefaultIterations
+	}
+	return &User{
+		Name:       name,
+		Mechanism:  m,
+		Iterations: iterations,
+		Password:   password,
+	}, nil
+}
+
+// ToObservation converts the described credentials of a user to a
+// UserObservation.
+func ToObservation(creds []kadm.CredInfo) v1alpha1.UserObservation {
+	o := v1alpha1.UserObservation{}
+	for _, c := range creds {
+		o.Credentials = append(o.Credentials, v1alpha1.UserCredential{
+			Mechanism:  c.Mechanism.String(),
+			Iterations: c.Iterations,
+		})
+	}
+	return o
+}
+
+// IsUpToDate returns true if Kafka holds exactly one credential for the user
+// and it matches the desired mechanism and iteration count.
+func IsUpToDate(desired *User, observed []kadm.CredInfo) bool {
+	if len(observed) != 1 {
+		return false
+	}
+	return observed[0].Mechanism == desired.Mechanism && observed[0].Iterations == desired.Iterations
+}
@@ -0,0 +1,268 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const testUserName = "alice"
+
+// alterCall records the arguments of a single AlterUserSCRAMs call.
+type alterCall struct {
+	del    []kadm.DeleteSCRAM
+	upsert []kadm.UpsertSCRAM
+}
+
+// fakeUserAdmin is an in-process implementation of adminClient for unit tests.
+type fakeUserAdmin struct {
+	describeResults kadm.DescribedUserSCRAMs
+	describeErr     error
+	alterErr        error
+	alterResultErr  error
+
+	calls []alterCall
+}
+
+func (f *fakeUserAdmin) DescribeUserSCRAMs(_ context.Context, _ ...string) (kadm.DescribedUserSCRAMs, error) {
+	return f.describeResults, f.describeErr
+}
+
+func (f *fakeUserAdmin) AlterUserSCRAMs(_ context.Context, del []kadm.DeleteSCRAM, upsert []kadm.UpsertSCRAM) (kadm.AlteredUserSCRAMs, error) {
+	f.calls = append(f.calls, alterCall{del: del, upsert: upsert})
+	if f.alterErr != nil {
+		return nil, f.alterErr
+	}
+	return kadm.AlteredUserSCRAMs{testUserName: {User: testUserName, Err: f.alterResultErr}}, nil
+}
+
+func TestCredentials(t *testing.T) {
+	sha512 := kadm.CredInfo{Mechanism: kadm.ScramSha512, Iterations: 4096}
+
+	cases := map[string]struct {
+		cl      *fakeUserAdmin
+		want    []kadm.CredInfo
+		wantErr string
+	}{
+		"Found": {
+			cl: &fakeUserAdmin{describeResults: kadm.DescribedUserSCRAMs{
+				testUserName: {User: testUserName, CredInfos: []kadm.CredInfo{sha512}},
+			}},
+			want: []kadm.CredInfo{sha512},
+		},
+		"ResourceNotFound": {
+			cl: &fakeUserAdmin{describeResults: kadm.DescribedUserSCRAMs{
+				testUserName: {User: testUserName, Err: kerr.ResourceNotFound},
+			}},
+			wantErr: ErrUserDoesNotExist,
+		},
+		"MissingFromResponse": {
+			cl:      &fakeUserAdmin{describeResults: kadm.DescribedUserSCRAMs{}},
+			wantErr: ErrUserDoesNotExist,
+		},
+		"BrokerError": {
+			cl: &fakeUserAdmin{describeResults: kadm.DescribedUserSCRAMs{
+				testUserName: {User: testUserName, Err: kerr.ClusterAuthorizationFailed},
+			}},
+			wantErr: errCannotDescribeUser,
+		},
+		"RequestError": {
+			cl:      &fakeUserAdmin{describeErr: errors.New("boom")},
+			wantErr: errCannotDescribeUser,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Credentials(context.Background(), tc.cl, testUserName)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Credentials(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	desired := &User{Name: testUserName, Mechanism: kadm.ScramSha256, Iterations: 8192, Password: "secret"}
+	upsert := []kadm.UpsertSCRAM{{User: testUserName, Mechanism: kadm.ScramSha256, Iterations: 8192, Password: "secret"}}
+
+	cases := map[string]struct {
+		existing  []kadm.CredInfo
+		alterErr  error
+		want      []alterCall
+		wantError bool
+	}{
+		"Create": {
+			want: []alterCall{{upsert: upsert}},
+		},
+		"SameMechanism": {
+			existing: []kadm.CredInfo{{Mechanism: kadm.ScramSha256, Iterations: 4096}},
+			want:     []alterCall{{upsert: upsert}},
+		},
+		"MechanismChangedRemovesOld": {
+			existing: []kadm.CredInfo{{Mechanism: kadm.ScramSha512, Iterations: 4096}},
+			want: []alterCall{
+				{upsert: upsert},
+				{del: []kadm.DeleteSCRAM{{User: testUserName, Mechanism: kadm.ScramSha512}}},
+			},
+		},
+		"BrokerError": {
+			alterErr:  kerr.ClusterAuthorizationFailed,
+			want:      []alterCall{{upsert: upsert}},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeUserAdmin{alterResultErr: tc.alterErr}
+			err := Upsert(context.Background(), cl, desired, tc.existing)
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if diff := cmp.Diff(tc.want, cl.calls, cmp.AllowUnexported(alterCall{})); diff != "" {
+				t.Errorf("Upsert(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	existing := []kadm.CredInfo{
+		{Mechanism: kadm.ScramSha256, Iterations: 4096},
+		{Mechanism: kadm.ScramSha512, Iterations: 4096},
+	}
+
+	cases := map[string]struct {
+		existing  []kadm.CredInfo
+		alterErr  error
+		want      []alterCall
+		wantError bool
+	}{
+		"DeletesAllMechanisms": {
+			existing: existing,
+			want: []alterCall{{del: []kadm.DeleteSCRAM{
+				{User: testUserName, Mechanism: kadm.ScramSha256},
+				{User: testUserName, Mechanism: kadm.ScramSha512},
+			}}},
+		},
+		"NoCredentials": {},
+		"AlreadyGone": {
+			existing: existing[:1],
+			alterErr: kerr.ResourceNotFound,
+			want:     []alterCall{{del: []kadm.DeleteSCRAM{{User: testUserName, Mechanism: kadm.ScramSha256}}}},
+		},
+		"BrokerError": {
+			existing:  existing[:1],
+			alterErr:  kerr.ClusterAuthorizationFailed,
+			want:      []alterCall{{del: []kadm.DeleteSCRAM{{User: testUserName, Mechanism: kadm.ScramSha256}}}},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeUserAdmin{alterResultErr: tc.alterErr}
+			err := Delete(context.Background(), cl, testUserName, tc.existing)
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if diff := cmp.Diff(tc.want, cl.calls, cmp.AllowUnexported(alterCall{})); diff != "" {
+				t.Errorf("Delete(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	cases := map[string]struct {
+		params  v1alpha1.UserParameters
+		want    *User
+		wantErr bool
+	}{
+		"Defaults": {
+			params: v1alpha1.UserParameters{},
+			want:   &User{Name: testUserName, Mechanism: kadm.ScramSha512, Iterations: DefaultIterations, Password: "pw"},
+		},
+		"Sha256": {
+			params: v1alpha1.UserParameters{Mechanism: MechanismScramSha256, Iterations: 8192},
+			want:   &User{Name: testUserName, Mechanism: kadm.ScramSha256, Iterations: 8192, Password: "pw"},
+		},
+		"UnknownMechanism": {
+			params:  v1alpha1.UserParameters{Mechanism: "PLAIN"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Generate(testUserName, "pw", &tc.params)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Generate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	desired := &User{Name: testUserName, Mechanism: kadm.ScramSha512, Iterations: 4096}
+
+	cases := map[string]struct {
+		observed []kadm.CredInfo
+		want     bool
+	}{
+		"UpToDate": {
+			observed: []kadm.CredInfo{{Mechanism: kadm.ScramSha512, Iterations: 4096}},
+			want:     true,
+		},
+		"IterationsDiffer": {
+			observed: []kadm.CredInfo{{Mechanism: kadm.ScramSha512, Iterations: 8192}},
+		},
+		"MechanismDiffers": {
+			observed: []kadm.CredInfo{{Mechanism: kadm.ScramSha256, Iterations: 4096}},
+		},
+		"ExtraMechanism": {
+			observed: []kadm.CredInfo{
+				{Mechanism: kadm.ScramSha256, Iterations: 4096},
+				{Mechanism: kadm.ScramSha512, Iterations: 4096},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsUpToDate(desired, tc.observed))
+		})
+	}
+}
+
+func TestToObservation(t *testing.T) {
+	got := ToObservation([]kadm.CredInfo{{Mechanism: kadm.ScramSha256, Iterations: 8192}})
+	want := v1alpha1.UserObservation{Credentials: []v1alpha1.UserCredential{{Mechanism: MechanismScramSha256, Iterations: 8192}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToObservation(...): -want, +got:\n%s", diff)
+	}
+}
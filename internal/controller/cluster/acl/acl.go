@@ -32,14 +32,16 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
-	"github.com/twmb/franz-go/pkg/kadm"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	commonv1alpha2 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
 	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/access"
 	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
 )
 
@@ -48,18 +50,23 @@ const (
 	errTrackPCUsage         = "cannot track ProviderConfig usage"
 	errGetPC                = "cannot get ProviderConfig"
 	errGetCreds             = "cannot get credentials"
+	errACLDrift             = "ACL in Kafka differs from the spec"
 	errListACL              = "cannot List ACLs"
 	errNewClient            = "cannot create new Service"
-	errUpdateNotSupported   = "updates are not supported"
+	errCreateNewBinding     = "cannot create the new ACL bindings"
+	errDeleteOldBinding     = "cannot delete the old ACL bindings"
+	errNewBindingNotFound   = "a new ACL binding is not in Kafka yet"
+	errUpdateExternalName   = "cannot update the external name to the new ACL bindings"
+	errVerifyNewBinding     = "cannot verify the new ACL bindings"
 )
 
 // Setup adds a controller that reconciles AccessControlList managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	name := managed.ControllerName(v1alpha1.AccessControlListGroupKind)
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha2.AccessControlListGroupKind)
 
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnector(&connector{
-			cache:        &kafka.ClientCache{},
+			cache:        clients,
 			kube:         mgr.GetClient(),
 			usage:        resource.NewLegacyProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newServiceFn: kafka.NewAdminClient,
@@ -84,30 +91,30 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
 		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
-			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.AccessControlListList{}, o.MetricOptions.PollStateMetricInterval,
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha2.AccessControlListList{}, o.MetricOptions.PollStateMetricInterval,
 		)
 		if err := mgr.Add(stateMetricsRecorder); err != nil {
-			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.AccessControlListList: %w", err)
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha2.AccessControlListList: %w", err)
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.AccessControlListGroupVersionKind), opts...)
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha2.AccessControlListGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1alpha1.AccessControlList{}).
+		For(&v1alpha2.AccessControlList{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
 // SetupGated adds a controller that reconciles MyType managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
 	o.Gate.Register(func() {
-		if err := Setup(mgr, o); err != nil {
+		if err := Setup(mgr, o, clients); err != nil {
 			panic(fmt.Errorf("cannot setup AccessControlList controller: %w", err))
 		}
-	}, v1alpha1.AccessControlListGroupVersionKind)
+	}, v1alpha2.AccessControlListGroupVersionKind)
 	return nil
 }
 
@@ -116,7 +123,7 @@ type connector struct {
 	cache        *kafka.ClientCache
 	kube         client.Client
 	log          logging.Logger
-	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kadm.Client, error)
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
 	usage        *resource.LegacyProviderConfigUsageTracker
 }
 
@@ -126,7 +133,7 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.AccessControlList)
+	cr, ok := mg.(*v1alpha2.AccessControlList)
 	if !ok {
 		return nil, errors.New(errNotAccessControlList)
 	}
@@ -148,18 +155,26 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
 	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, pc.Spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
 
-	svc, err := c.cache.GetOrCreate(data, func() (*kadm.Client, error) {
+	key := kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Name: pc.Name}
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
 		return c.newServiceFn(ctx, data, c.kube)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errNewClient, err)
 	}
 
-	return &external{kafkaClient: svc, log: c.log}, nil
+	return &external{kafkaClient: svc, release: release, config: key, kube: c.kube, annotations: managed.NewRetryingCriticalAnnotationUpdater(c.kube), log: c.log}, nil
 }
 
 func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
 	c.kafkaClient = nil
 	return nil
 }
@@ -167,12 +182,16 @@ func (c *external) Disconnect(_ context.Context) error {
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	kafkaClient *kadm.Client
+	kafkaClient *kafka.Client
+	release     func()
+	config      kafka.ClientKey
+	kube        client.Client
+	annotations managed.CriticalAnnotationUpdater
 	log         logging.Logger
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*v1alpha1.AccessControlList)
+	cr, ok := mg.(*v1alpha2.AccessControlList)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotAccessControlList)
 	}
@@ -183,43 +202,59 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	extName, err := acl.ConvertFromJSON(meta.GetExternalName(cr))
+	current, err := acl.ConvertAllFromJSON(ext)
 	if err != nil {
 		return managed.ExternalObservation{}, fmt.Errorf("could not convert external name from JSON: %w", err)
 	}
-	if extName == nil {
-		return managed.ExternalObservation{}, fmt.Errorf("could not convert external name from JSON: nil result")
+	if meta.WasDeleted(cr) {
+		// Delete keeps the bindings that other resources declare, so that
+		// only the other bindings have to be gone.
+		if current, err = c.deletable(ctx, cr, current); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if len(current) == 0 {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+	observed, err := acl.ListAll(ctx, c.kafkaClient, current)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errListACL, err)
 	}
-	generated := acl.Generate(&cr.Spec.ForProvider)
-	compare := acl.CompareAcls(*extName, *generated)
-	diff := acl.Diff(*extName, *generated)
 
-	if !compare {
-		err := strings.Join(diff, " ")
+	cr.Status.AtProvider = observation(current, observed)
+	if len(cr.Status.AtProvider.MissingBindings) == len(current) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.Status.SetConditions(xpv2.Available())
+
+	// A spec that no longer matches the bindings of the external name is
+	// applied by replacing the bindings in Update, which also creates the
+	// bindings that are missing.
+	if !acl.SameBindings(current, acl.Expand(&cr.Spec.ForProvider)) || len(cr.Status.AtProvider.MissingBindings) > 0 {
 		return managed.ExternalObservation{
 			ResourceExists:   true,
 			ResourceUpToDate: false,
-		}, errors.New(err)
+		}, nil
 	}
 
-	ae, err := acl.List(ctx, c.kafkaClient, extName)
-	if err != nil {
-		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errListACL, err)
+	// A binding Kafka holds only differs from the spec if the spec matches
+	// other bindings, such as with the Any operation.
+	var drift []string
+	for i := range current {
+		for _, d := range acl.Drift(*current[i], *observed[i]) {
+			if len(current) > 1 {
+				d = acl.Describe(current[i]) + ": " + d
+			}
+			drift = append(drift, d)
+		}
 	}
-
-	if ae == nil {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+	if len(drift) > 0 {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+		}, fmt.Errorf("%s: %s", errACLDrift, strings.Join(drift, ", "))
 	}
 
-	cr.Status.AtProvider.ResourceName = ae.ResourceName
-	cr.Status.AtProvider.ResourceType = ae.ResourceType
-	cr.Status.AtProvider.ResourcePrincipal = ae.ResourcePrincipal
-	cr.Status.AtProvider.ResourceHost = ae.ResourceHost
-	cr.Status.AtProvider.ResourceOperation = ae.ResourceOperation
-	cr.Status.AtProvider.ResourcePermissionType = ae.ResourcePermissionType
-	cr.Status.AtProvider.ResourcePatternTypeFilter = ae.ResourcePatternTypeFilter
-	cr.Status.SetConditions(xpv2.Available())
-
 	return managed.ExternalObservation{
 		ResourceExists:          true,
 		ResourceUpToDate:        true,
@@ -227,33 +262,152 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// observation returns the observed fields of the ACL bindings held by Kafka
+// for the bindings of the AccessControlList, as returned by ListAll.
+func observation(bindings, observed []*acl.AccessControlList) commonv1alpha2.AccessControlListObservation {
+	o := commonv1alpha2.AccessControlListObservation{Bindings: len(bindings)}
+	for i, ae := range observed {
+		if ae == nil {
+			o.MissingBindings = append(o.MissingBindings, acl.Describe(bindings[i]))
+		}
+	}
+	if len(observed) == 1 && observed[0] != nil {
+		ae := observed[0]
+		o.ResourceName = ae.ResourceName
+		o.ResourceType = ae.ResourceType
+		o.ResourcePrincipal = ae.ResourcePrincipal
+		o.ResourceHost = ae.ResourceHost
+		o.ResourceOperation = ae.ResourceOperation
+		o.ResourcePermissionType = ae.ResourcePermissionType
+		o.ResourcePatternTypeFilter = ae.ResourcePatternTypeFilter
+	}
+	return o
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*v1alpha1.AccessControlList)
+	cr, ok := mg.(*v1alpha2.AccessControlList)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotAccessControlList)
 	}
 
-	generated := acl.Generate(&cr.Spec.ForProvider)
-	extName, err := acl.ConvertToJSON(generated)
+	desired := acl.Expand(&cr.Spec.ForProvider)
+	extName, err := acl.ConvertAllToJSON(desired)
 	if err != nil {
 		return managed.ExternalCreation{}, fmt.Errorf("could not convert external name to JSON: %w", err)
 	}
 	// Always set the external name to the JSON form to ensure it's valid,
 	// even if it was previously set to a non-JSON value (e.g., by default initializers).
 	meta.SetExternalName(cr, extName)
-	return managed.ExternalCreation{}, acl.Create(ctx, c.kafkaClient, generated)
+	return managed.ExternalCreation{}, acl.Create(ctx, c.kafkaClient, desired...)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	return managed.ExternalUpdate{}, errors.New(errUpdateNotSupported)
+	cr, ok := mg.(*v1alpha2.AccessControlList)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAccessControlList)
+	}
+
+	current, err := acl.ConvertAllFromJSON(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("could not convert external name from JSON: %w", err)
+	}
+	desired := acl.Expand(&cr.Spec.ForProvider)
+
+	err = c.replace(ctx, cr, current, desired)
+	if !acl.SameBindings(current, desired) {
+		setReplacedCondition(cr, acl.ChangedBindingFields(current, desired), err)
+	}
+	return managed.ExternalUpdate{}, err
+}
+
+// replace replaces the current bindings of the AccessControlList with the
+// desired ones. The desired bindings are created and verified before the
+// current ones that are not desired are deleted, so that the principal keeps
+// the access it is granted in between. A replace that fails part way is
+// completed by the next Update, or by Create once the current bindings are
+// gone.
+func (c *external) replace(ctx context.Context, cr *v1alpha2.AccessControlList, current, desired []*acl.AccessControlList) error {
+	if err := acl.Create(ctx, c.kafkaClient, desired...); err != nil {
+		return fmt.Errorf("%s: %w", errCreateNewBinding, err)
+	}
+	observed, err := acl.ListAll(ctx, c.kafkaClient, desired)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errVerifyNewBinding, err)
+	}
+	for i, ae := range observed {
+		if ae == nil || *ae != *desired[i] {
+			return fmt.Errorf("%s: %s", errNewBindingNotFound, acl.Describe(desired[i]))
+		}
+	}
+
+	removed, err := c.deletable(ctx, cr, acl.Subtract(current, desired))
+	if err != nil {
+		return err
+	}
+	if err := acl.Delete(ctx, c.kafkaClient, removed...); err != nil {
+		return fmt.Errorf("%s: %w", errDeleteOldBinding, err)
+	}
+
+	extName, err := acl.ConvertAllToJSON(desired)
+	if err != nil {
+		return fmt.Errorf("could not convert external name to JSON: %w", err)
+	}
+	if extName != meta.GetExternalName(cr) {
+		// The managed reconciler only persists the status after an update, and
+		// updating the object resets the status to the persisted one.
+		status := cr.Status.DeepCopy()
+		meta.SetExternalName(cr, extName)
+		err = c.annotations.UpdateCriticalAnnotations(ctx, cr)
+		cr.Status = *status
+		if err != nil {
+			return fmt.Errorf("%s: %w", errUpdateExternalName, err)
+		}
+	}
+
+	cr.Status.AtProvider = observation(desired, observed)
+	return nil
+}
+
+// setReplacedCondition reports the outcome of replacing the bindings of the
+// AccessControlList to change the changed fields.
+func setReplacedCondition(cr *v1alpha2.AccessControlList, changed []string, err error) {
+	if err != nil {
+		cr.Status.SetConditions(common.ReplaceFailed(err))
+		return
+	}
+	cr.Status.SetConditions(common.Replaced(changed))
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	cr, ok := mg.(*v1alpha1.AccessControlList)
-	cr.Status.SetConditions(xpv2.Deleting())
+	cr, ok := mg.(*v1alpha2.AccessControlList)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotAccessControlList)
 	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	// The external name identifies the bindings while a changed spec has not
+	// replaced them yet.
+	current := acl.Expand(&cr.Spec.ForProvider)
+	if a, err := acl.ConvertAllFromJSON(meta.GetExternalName(cr)); err == nil {
+		current = a
+	}
+	current, err := c.deletable(ctx, cr, current)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	return managed.ExternalDelete{}, acl.Delete(ctx, c.kafkaClient, current...)
+}
 
-	return managed.ExternalDelete{}, acl.Delete(ctx, c.kafkaClient, acl.Generate(&cr.Spec.ForProvider))
+// deletable returns the bindings that no other AccessControlList or
+// KafkaAccess of its Kafka cluster declares, so that deleting them does not
+// revoke access that another resource grants.
+func (c *external) deletable(ctx context.Context, cr *v1alpha2.AccessControlList, bindings []*acl.AccessControlList) ([]*acl.AccessControlList, error) {
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+	declared, err := access.Declared(ctx, c.kube, c.config, cr)
+	if err != nil {
+		return nil, err
+	}
+	return acl.Deletable(bindings, declared), nil
 }
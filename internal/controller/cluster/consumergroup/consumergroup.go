@@ -0,0 +1,264 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/consumergroup/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/consumergroup"
+)
+
+const (
+	errDeleteGroup      = "cannot delete consumer group"
+	errGetCreds         = "cannot get credentials"
+	errGetGroup         = "cannot get consumer group from consumer group client"
+	errGetPC            = "cannot get ProviderConfig"
+	errNewClient        = "cannot create new Kafka client"
+	errNotConsumerGroup = "managed resource is not a ConsumerGroup custom resource"
+	errResetOffsets     = "cannot reset consumer group offsets"
+	errTrackPCUsage     = "cannot track ProviderConfig usage"
+
+	msgGroupNotExists = "consumer group does not exist in Kafka"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	cache        *kafka.ClientCache
+	kube         client.Client
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
+	usage        *resource.LegacyProviderConfigUsageTracker
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kafkaClient consumergroup.AdminClient
+	release     func()
+	log         logging.Logger
+}
+
+// Setup adds a controller that reconciles ConsumerGroup managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha1.ConsumerGroupGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			cache:        clients,
+			kube:         mgr.GetClient(),
+			usage:        resource.NewLegacyProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: kafka.NewAdminClient,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))), //nolint:staticcheck // crossplane-runtime doesn't support new events API yet
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.ConsumerGroupList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.ConsumerGroupList: %w", err)
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ConsumerGroupGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.ConsumerGroup{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// SetupGated adds a controller that reconciles ConsumerGroup managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	o.Gate.Register(func() {
+		if err := Setup(mgr, o, clients); err != nil {
+			panic(fmt.Errorf("cannot setup ConsumerGroup controller: %w", err))
+		}
+	}, v1alpha1.ConsumerGroupGroupVersionKind)
+	return nil
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ConsumerGroup)
+	if !ok {
+		return nil, errors.New(errNotConsumerGroup)
+	}
+
+	// Switch to LegacyManaged to support ProviderConfigUsage tracking
+	lmg := mg.(resource.LegacyManaged) //nolint:staticcheck
+
+	if err := c.usage.Track(ctx, lmg); err != nil {
+		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetPC, err)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, pc.Spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	key := kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Name: pc.Name}
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return c.newServiceFn(ctx, data, c.kube)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+
+	return &external{kafkaClient: svc, release: release, log: c.log}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
+	c.kafkaClient = nil
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ConsumerGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotConsumerGroup)
+	}
+
+	cg, err := consumergroup.Get(ctx, c.kafkaClient, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errGetGroup, err)
+	}
+	return observation(cr, cg), nil
+}
+
+// observation reports whether the consumer group of the ConsumerGroup exists
+// and is up to date, and records the observed group in its status.
+func observation(cr *v1alpha1.ConsumerGroup, cg *consumergroup.ConsumerGroup) managed.ExternalObservation {
+	// Consumer groups are created by their consumers, so the resource is
+	// reported as existing even before the group does. Once the resource is
+	// deleted, it only waits for the group to go away if it deletes it.
+	if meta.WasDeleted(cr) && (!cr.Spec.ForProvider.DeleteGroup || !cg.Exists()) {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+
+	applied := cr.Status.AtProvider.AppliedOffsetReset
+	cr.Status.AtProvider = cg.ToObservation()
+	cr.Status.AtProvider.AppliedOffsetReset = applied
+
+	if cg.Exists() {
+		cr.Status.SetConditions(xpv2.Available())
+	} else {
+		cr.Status.SetConditions(xpv2.Unavailable().WithMessage(msgGroupNotExists))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: consumergroup.IsUpToDate(&cr.Spec.ForProvider, applied),
+	}
+}
+
+// Create is never called because Observe always reports the group as
+// existing; consumer groups come into existence when consumers join them.
+func (c *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1alpha1.ConsumerGroup); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotConsumerGroup)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ConsumerGroup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotConsumerGroup)
+	}
+
+	reset := cr.Spec.ForProvider.OffsetReset
+	if reset == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if err := consumergroup.ResetOffsets(ctx, c.kafkaClient, meta.GetExternalName(cr), reset); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errResetOffsets, err)
+	}
+	cr.Status.AtProvider.AppliedOffsetReset = reset.DeepCopy()
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.ConsumerGroup)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotConsumerGroup)
+	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	if !cr.Spec.ForProvider.DeleteGroup {
+		return managed.ExternalDelete{}, nil
+	}
+	if err := consumergroup.Delete(ctx, c.kafkaClient, meta.GetExternalName(cr)); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errDeleteGroup, err)
+	}
+	return managed.ExternalDelete{}, nil
+}
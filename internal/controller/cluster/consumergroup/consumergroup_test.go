@@ -0,0 +1,206 @@
+package consumergroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/consumergroup/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/consumergroup"
+)
+
+func TestObserveWrongType(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		want   want
+	}{
+		"NotAConsumerGroup": {
+			reason: "Should return error when managed resource is not a ConsumerGroup",
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.New(errNotConsumerGroup),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{}
+			got, err := e.Observe(context.Background(), &fake.Managed{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// TestNoKafkaCalls verifies the operations that must not reach Kafka: an
+// Update without a requested offset reset and a Delete that leaves the
+// group in place. The external client has no Kafka client, so any call would
+// panic.
+func TestNoKafkaCalls(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		op     func(e *external, mg resource.Managed) error
+		mg     resource.Managed
+		err    error
+	}{
+		"UpdateWithoutReset": {
+			reason: "Update should do nothing when no offset reset is requested",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			mg: &v1alpha1.ConsumerGroup{},
+		},
+		"DeleteKeepsGroup": {
+			reason: "Delete should leave the group in place unless deleteGroup is set",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Delete(context.Background(), mg)
+				return err
+			},
+			mg: &v1alpha1.ConsumerGroup{},
+		},
+		"UpdateWrongType": {
+			reason: "Update should return error when managed resource is not a ConsumerGroup",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			mg:  &fake.Managed{},
+			err: errors.New(errNotConsumerGroup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.op(&external{}, tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\n-want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservation(t *testing.T) {
+	earliest := &common.ConsumerGroupOffsetReset{Strategy: consumergroup.StrategyEarliest, Revision: "1"}
+
+	type want struct {
+		o     managed.ExternalObservation
+		ready corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason   string
+		params   common.ConsumerGroupParameters
+		applied  *common.ConsumerGroupOffsetReset
+		deleted  bool
+		observed *consumergroup.ConsumerGroup
+		want     want
+	}{
+		"GroupExists": {
+			reason:   "A group without a requested offset reset should be up to date and available",
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateEmpty},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: corev1.ConditionTrue,
+			},
+		},
+		"GroupNotJoinedYet": {
+			reason:   "A group that consumers have not joined yet should exist but not be available",
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateDead},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: corev1.ConditionFalse,
+			},
+		},
+		"OffsetResetPending": {
+			reason:   "A group whose requested offset reset was not applied yet should not be up to date",
+			params:   common.ConsumerGroupParameters{OffsetReset: earliest},
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateEmpty},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				ready: corev1.ConditionTrue,
+			},
+		},
+		"OffsetResetApplied": {
+			reason:   "A group whose requested offset reset was applied should be up to date",
+			params:   common.ConsumerGroupParameters{OffsetReset: earliest},
+			applied:  earliest.DeepCopy(),
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateEmpty},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: corev1.ConditionTrue,
+			},
+		},
+		"DeletedKeepsGroup": {
+			reason:   "A deleted resource that does not delete its group should not wait for the group to go away",
+			deleted:  true,
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateEmpty},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				ready: corev1.ConditionUnknown,
+			},
+		},
+		"DeletedWaitsForGroup": {
+			reason:   "A deleted resource that deletes its group should wait for the group to go away",
+			params:   common.ConsumerGroupParameters{DeleteGroup: true},
+			deleted:  true,
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateEmpty},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: corev1.ConditionTrue,
+			},
+		},
+		"DeletedGroupGone": {
+			reason:   "A deleted resource whose group went away should not exist",
+			params:   common.ConsumerGroupParameters{DeleteGroup: true},
+			deleted:  true,
+			observed: &consumergroup.ConsumerGroup{State: consumergroup.StateDead},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				ready: corev1.ConditionUnknown,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.ConsumerGroup{}
+			cr.Spec.ForProvider = tc.params
+			cr.Status.AtProvider.AppliedOffsetReset = tc.applied
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			got := observation(cr, tc.observed)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nobservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			assert.Equal(t, tc.want.ready, cr.Status.GetCondition(xpv2.TypeReady).Status, tc.reason)
+			if diff := cmp.Diff(tc.applied, cr.Status.AtProvider.AppliedOffsetReset); diff != "" {
+				t.Errorf("\n%s\nobservation(...): -want applied offset reset, +got applied offset reset:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegationtoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/delegationtoken/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/delegationtoken"
+)
+
+const (
+	errCreateToken        = "cannot create delegation token"
+	errDescribeToken      = "cannot describe delegation token"
+	errExpireToken        = "cannot expire delegation token"
+	errGetCreds           = "cannot get credentials"
+	errGetPC              = "cannot get ProviderConfig"
+	errNewClient          = "cannot create new Kafka client"
+	errNotDelegationToken = "managed resource is not a DelegationToken custom resource"
+	errParseConfig        = "cannot parse ProviderConfig credentials"
+	errRenewToken         = "cannot renew delegation token"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	cache        *kafka.ClientCache
+	kube         client.Client
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
+	usage        *resource.LegacyProviderConfigUsageTracker
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kafkaClient delegationtoken.AdminClient
+	release     func()
+	brokers     []string
+	log         logging.Logger
+}
+
+// Setup adds a controller that reconciles DelegationToken managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha1.DelegationTokenGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			cache:        clients,
+			kube:         mgr.GetClient(),
+			usage:        resource.NewLegacyProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: kafka.NewAdminClient,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))), //nolint:staticcheck // crossplane-runtime doesn't support new events API yet
+		managed.WithInitializers(),
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.DelegationTokenList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.DelegationTokenList: %w", err)
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.DelegationTokenGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.DelegationToken{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// SetupGated adds a controller that reconciles DelegationToken managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	o.Gate.Register(func() {
+		if err := Setup(mgr, o, clients); err != nil {
+			panic(fmt.Errorf("cannot setup DelegationToken controller: %w", err))
+		}
+	}, v1alpha1.DelegationTokenGroupVersionKind)
+	return nil
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.DelegationToken)
+	if !ok {
+		return nil, errors.New(errNotDelegationToken)
+	}
+
+	// Switch to LegacyManaged to support ProviderConfigUsage tracking
+	lmg := mg.(resource.LegacyManaged) //nolint:staticcheck
+
+	if err := c.usage.Track(ctx, lmg); err != nil {
+		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetPC, err)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, pc.Spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	kc, err := kafka.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errParseConfig, err)
+	}
+
+	key := kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Name: pc.Name}
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return c.newServiceFn(ctx, data, c.kube)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+
+	return &external{kafkaClient: svc, release: release, brokers: kc.Brokers, log: c.log}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
+	c.kafkaClient = nil
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DelegationToken)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDelegationToken)
+	}
+
+	// The external name is the token ID that Kafka assigned on creation.
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	t, err := delegationtoken.Get(ctx, c.kafkaClient, cr.Spec.ForProvider.Owner, id)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errDescribeToken, err)
+	}
+
+	if t == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return c.observation(cr, t, time.Now()), nil
+}
+
+// observation reports whether the token of the DelegationToken exists and is
+// up to date at the time, and records it in its status.
+func (c *external) observation(cr *v1alpha1.DelegationToken, t *kadm.DelegationToken, now time.Time) managed.ExternalObservation {
+	// A token that expired, or can no longer be renewed before it does, is
+	// replaced by a new token. It is reported as existing while the resource
+	// is deleted, so that Delete expires it rather than Create replacing it.
+	renewBefore := delegationtoken.RenewBefore(&cr.Spec.ForProvider)
+	if !meta.WasDeleted(cr) && (delegationtoken.IsExpired(t, now) || delegationtoken.NeedsReplacement(t, now, renewBefore)) {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+
+	cr.Status.AtProvider = delegationtoken.ToObservation(t)
+	cr.Status.SetConditions(xpv2.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  !delegationtoken.NeedsRenewal(t, now, renewBefore),
+		ConnectionDetails: c.connectionDetails(t),
+	}
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DelegationToken)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDelegationToken)
+	}
+
+	// The external name is the token that is replaced, if any.
+	t, err := delegationtoken.Replace(ctx, c.kafkaClient, &cr.Spec.ForProvider, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf("%s: %w", errCreateToken, err)
+	}
+	meta.SetExternalName(cr, t.TokenID)
+	cr.Status.AtProvider = delegationtoken.ToObservation(&t)
+
+	return managed.ExternalCreation{ConnectionDetails: c.connectionDetails(&t)}, nil
+}
+
+// Update renews the token, which is the only change a token accepts.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DelegationToken)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDelegationToken)
+	}
+
+	t, err := delegationtoken.Get(ctx, c.kafkaClient, cr.Spec.ForProvider.Owner, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errDescribeToken, err)
+	}
+	if t == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	expiry, err := delegationtoken.Renew(ctx, c.kafkaClient, t.HMAC)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errRenewToken, err)
+	}
+	t.ExpiryTimestamp = expiry
+	cr.Status.AtProvider = delegationtoken.ToObservation(t)
+
+	return managed.ExternalUpdate{ConnectionDetails: c.connectionDetails(t)}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.DelegationToken)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotDelegationToken)
+	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	t, err := delegationtoken.Get(ctx, c.kafkaClient, cr.Spec.ForProvider.Owner, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errDescribeToken, err)
+	}
+	if t == nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	if err := delegationtoken.Expire(ctx, c.kafkaClient, t.HMAC); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errExpireToken, err)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) connectionDetails(t *kadm.DelegationToken) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		delegationtoken.ConnectionKeyTokenID: []byte(t.TokenID),
+		delegationtoken.ConnectionKeyHMAC:    []byte(delegationtoken.HMAC(t)),
+		delegationtoken.ConnectionKeyBrokers: []byte(strings.Join(c.brokers, ",")),
+	}
+}
@@ -20,35 +20,54 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/access"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/brokerconfig"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/config"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/consumergroup"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/delegationtoken"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/quota"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/topic"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/cluster/user"
 )
 
-// Setup creates all Kafka controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+// Setup creates all Kafka controllers with the supplied logger and Kafka client
+// cache and adds them to the supplied manager.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	for _, setup := range []func(ctrl.Manager, controller.Options, *kafka.ClientCache) error{
 		config.Setup,
 		topic.Setup,
 		acl.Setup,
+		user.Setup,
+		consumergroup.Setup,
+		brokerconfig.Setup,
+		quota.Setup,
+		access.Setup,
+		delegationtoken.Setup,
 	} {
-		if err := setup(mgr, o); err != nil {
+		if err := setup(mgr, o, clients); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// SetupGated creates all controllers with the supplied logger and adds them to
-// the supplied manager gated.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+// SetupGated creates all controllers with the supplied logger and Kafka client
+// cache and adds them to the supplied manager gated.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	for _, setup := range []func(ctrl.Manager, controller.Options, *kafka.ClientCache) error{
 		config.Setup,
 		topic.Setup,
 		acl.Setup,
+		user.Setup,
+		consumergroup.Setup,
+		brokerconfig.Setup,
+		quota.Setup,
+		access.Setup,
+		delegationtoken.Setup,
 	} {
-		if err := setup(mgr, o); err != nil {
+		if err := setup(mgr, o, clients); err != nil {
 			return err
 		}
 	}
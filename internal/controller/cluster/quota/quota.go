@@ -0,0 +1,276 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/quota/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/quota"
+)
+
+const (
+	errAlterQuota   = "cannot alter client quotas"
+	errDeleteQuota  = "cannot delete client quotas"
+	errGetCreds     = "cannot get credentials"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetQuota     = "cannot get client quotas from quota client"
+	errNewClient    = "cannot create new Kafka client"
+	errNotQuota     = "managed resource is not a Quota custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errUpToDate     = "cannot determine if client quotas are up to date"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	cache        *kafka.ClientCache
+	kube         client.Client
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
+	usage        *resource.LegacyProviderConfigUsageTracker
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kafkaClient quota.AdminClient
+	release     func()
+	log         logging.Logger
+}
+
+// Setup adds a controller that reconciles Quota managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha1.QuotaGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			cache:        clients,
+			kube:         mgr.GetClient(),
+			usage:        resource.NewLegacyProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: kafka.NewAdminClient,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))), //nolint:staticcheck // crossplane-runtime doesn't support new events API yet
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.QuotaList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.QuotaList: %w", err)
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.QuotaGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Quota{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// SetupGated adds a controller that reconciles Quota managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	o.Gate.Register(func() {
+		if err := Setup(mgr, o, clients); err != nil {
+			panic(fmt.Errorf("cannot setup Quota controller: %w", err))
+		}
+	}, v1alpha1.QuotaGroupVersionKind)
+	return nil
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Quota)
+	if !ok {
+		return nil, errors.New(errNotQuota)
+	}
+
+	// Switch to LegacyManaged to support ProviderConfigUsage tracking
+	lmg := mg.(resource.LegacyManaged) //nolint:staticcheck
+
+	if err := c.usage.Track(ctx, lmg); err != nil {
+		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetPC, err)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, pc.Spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	key := kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Name: pc.Name}
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return c.newServiceFn(ctx, data, c.kube)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+
+	return &external{kafkaClient: svc, release: release, log: c.log}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
+	c.kafkaClient = nil
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Quota)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotQuota)
+	}
+
+	q, err := c.get(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	return observation(cr, q)
+}
+
+// observation reports whether the quotas of the Quota exist and are up to
+// date, and records their observed values in its status.
+func observation(cr *v1alpha1.Quota, q *quota.Quota) (managed.ExternalObservation, error) {
+	if !q.Exists() {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = q.ToObservation()
+	cr.Status.SetConditions(xpv2.Available())
+
+	upToDate, err := quota.IsUpToDate(&cr.Spec.ForProvider, q)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errUpToDate, err)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Quota)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotQuota)
+	}
+	cr.Status.SetConditions(xpv2.Creating())
+
+	q, err := c.get(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := quota.Alter(ctx, c.kafkaClient, &cr.Spec.ForProvider, q); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf("%s: %w", errAlterQuota, err)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Quota)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotQuota)
+	}
+
+	q, err := c.get(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := quota.Alter(ctx, c.kafkaClient, &cr.Spec.ForProvider, q); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errAlterQuota, err)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.Quota)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotQuota)
+	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	q, err := c.get(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	if err := quota.Delete(ctx, c.kafkaClient, q); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errDeleteQuota, err)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+// get returns the current quotas of the entity described by the Quota.
+func (c *external) get(ctx context.Context, cr *v1alpha1.Quota) (*quota.Quota, error) {
+	e, err := quota.Entity(&cr.Spec.ForProvider)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetQuota, err)
+	}
+	q, err := quota.Get(ctx, c.kafkaClient, e)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetQuota, err)
+	}
+	return q, nil
+}
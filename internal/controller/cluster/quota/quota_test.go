@@ -0,0 +1,118 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/quota/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/quota"
+)
+
+func TestWrongType(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		op     func(e *external, mg resource.Managed) error
+	}{
+		"Observe": {
+			reason: "Observe should return error when managed resource is not a Quota",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Observe(context.Background(), mg)
+				return err
+			},
+		},
+		"Create": {
+			reason: "Create should return error when managed resource is not a Quota",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+		},
+		"Update": {
+			reason: "Update should return error when managed resource is not a Quota",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+		},
+		"Delete": {
+			reason: "Delete should return error when managed resource is not a Quota",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Delete(context.Background(), mg)
+				return err
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.op(&external{}, &fake.Managed{})
+			if diff := cmp.Diff(errors.New(errNotQuota), err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\n-want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservation(t *testing.T) {
+	rate := int64(1024)
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		values   common.QuotaValues
+		observed map[string]float64
+		want     want
+	}{
+		"NoQuotas": {
+			reason: "An entity without any managed quota should not exist",
+			values: common.QuotaValues{ProducerByteRate: &rate},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason:   "An entity whose quotas have the desired values should be up to date",
+			values:   common.QuotaValues{ProducerByteRate: &rate},
+			observed: map[string]float64{quota.KeyProducerByteRate: 1024},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"UndesiredQuotaSet": {
+			reason:   "An entity with a quota that is not desired should not be up to date",
+			values:   common.QuotaValues{ProducerByteRate: &rate},
+			observed: map[string]float64{quota.KeyProducerByteRate: 1024, quota.KeyConsumerByteRate: 4096},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Quota{}
+			cr.Spec.ForProvider.User = &common.QuotaEntityName{Name: "alice"}
+			cr.Spec.ForProvider.QuotaValues = tc.values
+
+			got, err := observation(cr, &quota.Quota{Values: tc.observed})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nobservation(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nobservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/cluster/topic/v1alpha1"
 	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
@@ -68,6 +70,8 @@ func TestObserveFirstReconcileNotUpToDate(t *testing.T) {
 	cases := map[string]struct {
 		reason       string
 		existingID   string
+		reassigning  bool
+		recorded     []string
 		spec         common.TopicParameters
 		observed     *topic.Topic
 		wantUpToDate bool
@@ -91,6 +95,7 @@ func TestObserveFirstReconcileNotUpToDate(t *testing.T) {
 		"PopulatedID_SpecMatchesObserved": {
 			reason:     "Subsequent reconcile (populated ID) with matching spec should be up-to-date",
 			existingID: testTopicID,
+			recorded:   []string{testRetentionMS},
 			spec: common.TopicParameters{
 				ReplicationFactor: 3,
 				Partitions:        6,
@@ -118,6 +123,54 @@ func TestObserveFirstReconcileNotUpToDate(t *testing.T) {
 			},
 			wantUpToDate: false,
 		},
+		"PopulatedID_StaleConfigKey": {
+			reason:     "A config key removed from the spec that is still managed should not be up-to-date",
+			existingID: testTopicID,
+			recorded:   []string{testRetentionMS},
+			spec: common.TopicParameters{
+				ReplicationFactor: 3,
+				Partitions:        6,
+			},
+			observed: &topic.Topic{
+				ID:                testTopicID,
+				ReplicationFactor: 3,
+				Partitions:        6,
+				Config:            map[string]*string{testRetentionMS: strPtr("86400000")},
+				Overridden:        map[string]bool{testRetentionMS: true},
+			},
+			wantUpToDate: false,
+		},
+		"PopulatedID_ConfigKeyNotRecorded": {
+			reason:     "A config key of the spec that is not recorded as managed yet should not be up-to-date",
+			existingID: testTopicID,
+			spec: common.TopicParameters{
+				ReplicationFactor: 3,
+				Partitions:        6,
+				Config:            map[string]*string{testRetentionMS: strPtr("86400000")},
+			},
+			observed: &topic.Topic{
+				ID:                testTopicID,
+				ReplicationFactor: 3,
+				Partitions:        6,
+				Config:            map[string]*string{testRetentionMS: strPtr("86400000")},
+			},
+			wantUpToDate: false,
+		},
+		"PopulatedID_ReassignmentInProgress": {
+			reason:      "A replication factor change that is still being reassigned should not be up-to-date",
+			existingID:  testTopicID,
+			reassigning: true,
+			spec: common.TopicParameters{
+				ReplicationFactor: 3,
+				Partitions:        6,
+			},
+			observed: &topic.Topic{
+				ID:                testTopicID,
+				ReplicationFactor: 3,
+				Partitions:        6,
+			},
+			wantUpToDate: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -127,10 +180,13 @@ func TestObserveFirstReconcileNotUpToDate(t *testing.T) {
 			cr := &v1alpha1.Topic{}
 			cr.Spec.ForProvider = tc.spec
 			cr.Status.AtProvider.ID = tc.existingID
+			cr.Status.AtProvider.ReassignmentInProgress = tc.reassigning
+			meta.AddAnnotations(cr, map[string]string{topic.AnnotationKeyManagedConfigKeys: topic.FormatConfigKeys(tc.recorded)})
 
 			statusPopulated := cr.Status.AtProvider.ID != ""
+			managedKeys := topic.ManagedConfigKeys(&cr.Spec.ForProvider, recordedConfigKeys(cr), tc.observed)
 
-			got := isResourceUpToDate(cr, statusPopulated, tc.observed)
+			got := isResourceUpToDate(cr, statusPopulated, managedKeys, tc.observed)
 
 			assert.Equal(t, tc.wantUpToDate, got, tc.reason)
 		})
@@ -194,3 +250,87 @@ func TestPopulateTopicAtProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestSetChangesCondition(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason      string
+		res         topic.UpdateResult
+		err         error
+		wantSet     bool
+		wantStatus  corev1.ConditionStatus
+		wantMessage string
+	}{
+		"AllApplied": {
+			reason:      "Every applied part should be listed in a true condition",
+			res:         topic.UpdateResult{Applied: []string{topic.PartPartitions, topic.PartConfig}},
+			wantSet:     true,
+			wantStatus:  corev1.ConditionTrue,
+			wantMessage: "applied: Partitions, Config",
+		},
+		"PartiallyFailed": {
+			reason:      "Applied and failed parts should be listed in a false condition",
+			res:         topic.UpdateResult{Applied: []string{topic.PartConfig}, Failed: []string{topic.PartReplicationFactor}},
+			err:         errBoom,
+			wantSet:     true,
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "applied: Config; failed: ReplicationFactor: boom",
+		},
+		"NothingAttempted": {
+			reason: "The condition should not be set when the update failed before planning any change",
+			err:    errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Topic{}
+			setChangesCondition(cr, tc.res, tc.err)
+
+			c := cr.Status.GetCondition(common.TypeChangesApplied)
+			if !tc.wantSet {
+				assert.Equal(t, corev1.ConditionUnknown, c.Status, tc.reason)
+				return
+			}
+			assert.Equal(t, tc.wantStatus, c.Status, tc.reason)
+			assert.Equal(t, tc.wantMessage, c.Message, tc.reason)
+		})
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		o           common.TopicObservation
+		wantStatus  corev1.ConditionStatus
+		wantMessage string
+	}{
+		"Healthy": {
+			reason:     "A topic whose partitions are all in sync should be available",
+			o:          common.TopicObservation{Partitions: 3},
+			wantStatus: corev1.ConditionTrue,
+		},
+		"UnderReplicated": {
+			reason:      "A topic with under-replicated partitions should not be available",
+			o:           common.TopicObservation{Partitions: 3, UnderReplicatedPartitions: 2},
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "0 partitions offline, 2 partitions under-replicated",
+		},
+		"Offline": {
+			reason:      "A topic with offline partitions should not be available",
+			o:           common.TopicObservation{Partitions: 3, OfflinePartitions: 1, UnderReplicatedPartitions: 1},
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "1 partitions offline, 1 partitions under-replicated",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := availability(tc.o)
+			assert.Equal(t, xpv2.TypeReady, c.Type, tc.reason)
+			assert.Equal(t, tc.wantStatus, c.Status, tc.reason)
+			assert.Equal(t, tc.wantMessage, c.Message, tc.reason)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/user/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/user"
+)
+
+const (
+	errDeleteUser   = "cannot delete user credentials"
+	errGenerateUser = "cannot generate user"
+	errGetCreds     = "cannot get credentials"
+	errGetPassword  = "cannot get password from Secret"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetUser      = "cannot get user credentials from user client"
+	errMissingKey   = "password key not found in Secret"
+	errNewClient    = "cannot create new Kafka client"
+	errNotUser      = "managed resource is not a User custom resource"
+	errParseConfig  = "cannot parse ProviderConfig credentials"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errUpdateAnno   = "cannot record the applied password Secret version"
+	errUpsertUser   = "cannot upsert user credentials"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	cache        *kafka.ClientCache
+	kube         client.Client
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
+	usage        *resource.LegacyProviderConfigUsageTracker
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kafkaClient *kafka.Client
+	release     func()
+	kube        client.Client
+	annotations managed.CriticalAnnotationUpdater
+	brokers     []string
+	log         logging.Logger
+}
+
+// Setup adds a controller that reconciles User managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha1.UserGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			cache:        clients,
+			kube:         mgr.GetClient(),
+			usage:        resource.NewLegacyProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: kafka.NewAdminClient,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))), //nolint:staticcheck // crossplane-runtime doesn't support new events API yet
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.UserList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.UserList: %w", err)
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.UserGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.User{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// SetupGated adds a controller that reconciles User managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	o.Gate.Register(func() {
+		if err := Setup(mgr, o, clients); err != nil {
+			panic(fmt.Errorf("cannot setup User controller: %w", err))
+		}
+	}, v1alpha1.UserGroupVersionKind)
+	return nil
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return nil, errors.New(errNotUser)
+	}
+
+	// Switch to LegacyManaged to support ProviderConfigUsage tracking
+	lmg := mg.(resource.LegacyManaged) //nolint:staticcheck
+
+	if err := c.usage.Track(ctx, lmg); err != nil {
+		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetPC, err)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, pc.Spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	kc, err := kafka.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errParseConfig, err)
+	}
+
+	key := kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Name: pc.Name}
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return c.newServiceFn(ctx, data, c.kube)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+
+	return &external{kafkaClient: svc, release: release, kube: c.kube, annotations: managed.NewRetryingCriticalAnnotationUpdater(c.kube), brokers: kc.Brokers, log: c.log}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
+	c.kafkaClient = nil
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotUser)
+	}
+
+	creds, err := user.Credentials(ctx, c.kafkaClient, meta.GetExternalName(cr))
+	if err != nil { // Discern whether the user doesn't exist or something went wrong
+		if strings.HasPrefix(err.Error(), user.ErrUserDoesNotExist) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errGetUser, err)
+	}
+
+	desired, version, err := c.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = user.ToObservation(creds)
+	cr.Status.SetConditions(xpv2.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  cr.GetAnnotations()[user.AnnotationKeyPasswordSecretVersion] == version && user.IsUpToDate(desired, creds),
+		ConnectionDetails: c.connectionDetails(desired),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotUser)
+	}
+
+	desired, version, err := c.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := user.Upsert(ctx, c.kafkaClient, desired, nil); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf("%s: %w", errUpsertUser, err)
+	}
+	// The managed reconciler persists the annotations after a create.
+	meta.AddAnnotations(cr, map[string]string{user.AnnotationKeyPasswordSecretVersion: version})
+
+	return managed.ExternalCreation{ConnectionDetails: c.connectionDetails(desired)}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotUser)
+	}
+
+	desired, version, err := c.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	existing, err := user.Credentials(ctx, c.kafkaClient, desired.Name)
+	if err != nil && !strings.HasPrefix(err.Error(), user.ErrUserDoesNotExist) {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errGetUser, err)
+	}
+
+	if err := user.Upsert(ctx, c.kafkaClient, desired, existing); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errUpsertUser, err)
+	}
+	if err := c.recordVersion(ctx, cr, version); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: c.connectionDetails(desired)}, nil
+}
+
+// recordVersion records the resource version of the password Secret that was
+// applied to Kafka in an annotation of the User.
+func (c *external) recordVersion(ctx context.Context, cr *v1alpha1.User, version string) error {
+	if cr.GetAnnotations()[user.AnnotationKeyPasswordSecretVersion] == version {
+		return nil
+	}
+	// The managed reconciler only persists the status after an update, and
+	// updating the object resets the status to the persisted one.
+	status := cr.Status.DeepCopy()
+	meta.AddAnnotations(cr, map[string]string{user.AnnotationKeyPasswordSecretVersion: version})
+	err := c.annotations.UpdateCriticalAnnotations(ctx, cr)
+	cr.Status = *status
+	if err != nil {
+		return fmt.Errorf("%s: %w", errUpdateAnno, err)
+	}
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotUser)
+	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	name := meta.GetExternalName(cr)
+	existing, err := user.Credentials(ctx, c.kafkaClient, name)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), user.ErrUserDoesNotExist) {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errGetUser, err)
+	}
+
+	if err := user.Delete(ctx, c.kafkaClient, name, existing); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errDeleteUser, err)
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+// desired returns the user described by the managed resource together with
+// the resource version of the Secret its password was read from.
+func (c *external) desired(ctx context.Context, cr *v1alpha1.User) (*user.User, string, error) {
+	password, version, err := c.password(ctx, cr)
+	if err != nil {
+		return nil, "", err
+	}
+	u, err := user.Generate(meta.GetExternalName(cr), password, &cr.Spec.ForProvider.UserParameters)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", errGenerateUser, err)
+	}
+	return u, version, nil
+}
+
+// password reads the user's password from the referenced Secret.
+func (c *external) password(ctx context.Context, cr *v1alpha1.User) (string, string, error) {
+	ref := cr.Spec.ForProvider.PasswordSecretRef
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", "", fmt.Errorf("%s: %w", errGetPassword, err)
+	}
+	pw, ok := s.Data[ref.Key]
+	if !ok {
+		return "", "", fmt.Errorf("%s: %s/%s[%s]", errMissingKey, ref.Namespace, ref.Name, ref.Key)
+	}
+	return string(pw), s.GetResourceVersion(), nil
+}
+
+func (c *external) connectionDetails(u *user.User) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		user.ConnectionKeyUsername:  []byte(u.Name),
+		user.ConnectionKeyPassword:  []byte(u.Password),
+		user.ConnectionKeyBrokers:   []byte(strings.Join(c.brokers, ",")),
+		user.ConnectionKeyMechanism: []byte(u.Mechanism.String()),
+	}
+}
@@ -0,0 +1,206 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/cluster/user/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/user"
+)
+
+func TestObserveWrongType(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		want   want
+	}{
+		"NotAUser": {
+			reason: "Should return error when managed resource is not a User",
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.New(errNotUser),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{}
+			got, err := e.Observe(context.Background(), &fake.Managed{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDesired(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cr := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Annotations: map[string]string{meta.AnnotationKeyExternalName: "alice"}},
+		Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{
+			PasswordSecretRef: xpv2.SecretKeySelector{
+				SecretReference: xpv2.SecretReference{Name: "creds", Namespace: "kafka"},
+				Key:             "password",
+			},
+		}},
+	}
+
+	type want struct {
+		u       *user.User
+		version string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		want   want
+	}{
+		"SecretFound": {
+			reason: "Should read the password and resource version from the referenced Secret",
+			kube: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if key != (types.NamespacedName{Namespace: "kafka", Name: "creds"}) {
+					return errBoom
+				}
+				s := obj.(*corev1.Secret)
+				s.ResourceVersion = "7"
+				s.Data = map[string][]byte{"password": []byte("s3cret")}
+				return nil
+			}},
+			want: want{
+				u:       &user.User{Name: "alice", Mechanism: kadm.ScramSha512, Iterations: user.DefaultIterations, Password: "s3cret"},
+				version: "7",
+			},
+		},
+		"KeyMissing": {
+			reason: "Should return an error when the Secret lacks the password key",
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+				return nil
+			}},
+			want: want{err: errors.New(errMissingKey + ": kafka/creds[password]")},
+		},
+		"GetFailed": {
+			reason: "Should return an error when the Secret cannot be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   want{err: fmt.Errorf("%s: %w", errGetPassword, errBoom)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube}
+			u, version, err := e.desired(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.desired(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.u, u); diff != "" {
+				t.Errorf("\n%s\ne.desired(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.version, version); diff != "" {
+				t.Errorf("\n%s\ne.desired(...): -want version, +got version:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	e := &external{brokers: []string{"kafka-0:9092", "kafka-1:9092"}}
+	got := e.connectionDetails(&user.User{Name: "alice", Mechanism: kadm.ScramSha256, Password: "s3cret"})
+	want := managed.ConnectionDetails{
+		user.ConnectionKeyUsername:  []byte("alice"),
+		user.ConnectionKeyPassword:  []byte("s3cret"),
+		user.ConnectionKeyBrokers:   []byte("kafka-0:9092,kafka-1:9092"),
+		user.ConnectionKeyMechanism: []byte("SCRAM-SHA-256"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.connectionDetails(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRecordVersion(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		version string
+		updated bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		applied string
+		update  error
+		want    want
+	}{
+		"Unchanged": {
+			reason:  "Should not update the User when the applied version is already recorded",
+			applied: "7",
+			want:    want{version: "7"},
+		},
+		"Changed": {
+			reason:  "Should record the applied version in an annotation and keep the status",
+			applied: "6",
+			want:    want{version: "7", updated: true},
+		},
+		"UpdateFailed": {
+			reason:  "Should return an error when the annotation cannot be persisted",
+			applied: "6",
+			update:  errBoom,
+			want:    want{version: "7", updated: true, err: fmt.Errorf("%s: cannot update critical annotations: %w", errUpdateAnno, errBoom)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			kube := &test.MockClient{MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				updated = true
+				obj.(*v1alpha1.User).Status = v1alpha1.UserStatus{}
+				return tc.update
+			}}
+			cr := &v1alpha1.User{}
+			meta.AddAnnotations(cr, map[string]string{user.AnnotationKeyPasswordSecretVersion: tc.applied})
+			cr.Status.AtProvider.Credentials = []common.UserCredential{{Mechanism: user.MechanismScramSha512, Iterations: user.DefaultIterations}}
+			status := cr.Status.DeepCopy()
+
+			e := &external{annotations: managed.NewRetryingCriticalAnnotationUpdater(kube)}
+			err := e.recordVersion(context.Background(), cr, "7")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.recordVersion(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("\n%s\ne.recordVersion(...): -want updated, +got updated:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.version, cr.GetAnnotations()[user.AnnotationKeyPasswordSecretVersion]); diff != "" {
+				t.Errorf("\n%s\ne.recordVersion(...): -want version, +got version:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(status, &cr.Status); diff != "" {
+				t.Errorf("\n%s\ne.recordVersion(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/access/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/access"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+)
+
+const (
+	errGetCPC               = "cannot get ClusterProviderConfig"
+	errGetCreds             = "cannot get credentials"
+	errGetPC                = "cannot get ProviderConfig"
+	errListACL              = "cannot List ACLs"
+	errNewClient            = "cannot create new Service"
+	errNotKafkaAccess       = "managed resource is not a KafkaAccess custom resource"
+	errTrackPCUsage         = "cannot track ProviderConfig usage"
+	errCreateBindings       = "cannot create ACL bindings"
+	errDeleteBindings       = "cannot delete ACL bindings"
+	errDeleteUndeclared     = "cannot delete undeclared ACL bindings of the principal"
+	errListPrincipal        = "cannot list the ACL bindings of the principal"
+	errGenerateExternalName = "cannot generate the external name of the ACL bindings"
+	errParseExternalName    = "cannot parse the external name of the ACL bindings"
+	errUpdateExternalName   = "cannot update the external name to the new ACL bindings"
+)
+
+// Setup adds a controller that reconciles KafkaAccess managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha1.KafkaAccessGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			cache:        clients,
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: kafka.NewAdminClient,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))), //nolint:staticcheck // crossplane-runtime doesn't support new events API yet
+		managed.WithInitializers(),
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.KafkaAccessList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.KafkaAccessList: %w", err)
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.KafkaAccessGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.KafkaAccess{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// SetupGated adds a controller that reconciles MyType managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	o.Gate.Register(func() {
+		if err := Setup(mgr, o, clients); err != nil {
+			panic(fmt.Errorf("cannot setup KafkaAccess controller: %w", err))
+		}
+	}, v1alpha1.KafkaAccessGroupVersionKind)
+	return nil
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	cache        *kafka.ClientCache
+	kube         client.Client
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
+	usage        *resource.ProviderConfigUsageTracker
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.KafkaAccess)
+	if !ok {
+		return nil, errors.New(errNotKafkaAccess)
+	}
+
+	if err := c.usage.Track(ctx, cr); err != nil {
+		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
+	}
+
+	var spec apisv1alpha1.ProviderConfigSpec
+	var key kafka.ClientKey
+
+	// Switch to ModernManaged resource to get ProviderConfigRef
+	m := mg.(resource.ModernManaged)
+	ref := m.GetProviderConfigReference()
+
+	switch ref.Kind {
+	case "ProviderConfig":
+		pc := &apisv1alpha1.ProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: m.GetNamespace()}, pc); err != nil {
+			return nil, fmt.Errorf("%s: %w", errGetPC, err)
+		}
+		spec = pc.Spec
+		key = kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Namespace: pc.Namespace, Name: pc.Name}
+	case "ClusterProviderConfig":
+		cpc := &apisv1alpha1.ClusterProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
+			return nil, fmt.Errorf("%s: %w", errGetCPC, err)
+		}
+		spec = cpc.Spec
+		key = kafka.ClientKey{Kind: apisv1alpha1.ClusterProviderConfigGroupKind, Name: cpc.Name}
+	default:
+		return nil, fmt.Errorf("unsupported provider config kind: %s", ref.Kind)
+	}
+
+	cd := spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return c.newServiceFn(ctx, data, c.kube)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+
+	return &external{kafkaClient: svc, release: release, config: key, kube: c.kube, annotations: managed.NewRetryingCriticalAnnotationUpdater(c.kube), log: c.log}, nil
+}
+
+// adminClient is the subset of kafka.Client methods used by the external
+// client.
+type adminClient interface {
+	CreateACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.CreateACLsResults, error)
+	DeleteACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DeleteACLsResults, error)
+	DescribeACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DescribeACLsResults, error)
+	kmsg.Requestor
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kafkaClient adminClient
+	release     func()
+	config      kafka.ClientKey
+	kube        client.Client
+	annotations managed.CriticalAnnotationUpdater
+	log         logging.Logger
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
+	c.kafkaClient = nil
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.KafkaAccess)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotKafkaAccess)
+	}
+
+	// The external name holds the bindings that were last applied, so that
+	// bindings of removed intents can be deleted.
+	ext := meta.GetExternalName(cr)
+	if ext == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	current, err := acl.ConvertAllFromJSON(ext)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errParseExternalName, err)
+	}
+	if meta.WasDeleted(cr) {
+		// Delete keeps the bindings that other resources declare, so that
+		// only the other bindings have to be gone.
+		if current, err = c.deletable(ctx, cr, current); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if len(current) == 0 {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+	observed, err := acl.ListAll(ctx, c.kafkaClient, current)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errListACL, err)
+	}
+
+	cr.Status.AtProvider = observation(current, observed)
+	if len(cr.Status.AtProvider.MissingBindings) == len(current) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.Status.SetConditions(xpv2.Available())
+
+	upToDate := acl.SameBindings(current, access.Expand(&cr.Spec.ForProvider)) && len(cr.Status.AtProvider.MissingBindings) == 0
+
+	if ownership := cr.Spec.ForProvider.ACLOwnership; ownership == common.ACLOwnershipDryRun || ownership == common.ACLOwnershipAuthoritative {
+		undeclared, err := c.undeclared(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		for _, b := range undeclared {
+			cr.Status.AtProvider.UndeclaredBindings = append(cr.Status.AtProvider.UndeclaredBindings, acl.Describe(b))
+		}
+		upToDate = upToDate && (ownership == common.ACLOwnershipDryRun || len(undeclared) == 0)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// undeclared returns the ACL bindings of the principal of the KafkaAccess
+// that no AccessControlList or KafkaAccess of its Kafka cluster declares.
+func (c *external) undeclared(ctx context.Context, cr *v1alpha1.KafkaAccess) ([]*acl.AccessControlList, error) {
+	bindings, err := acl.ListPrincipal(ctx, c.kafkaClient, cr.Spec.ForProvider.Principal)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errListPrincipal, err)
+	}
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+	declared, err := c.declared(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	return acl.Undeclared(bindings, declared), nil
+}
+
+// declared returns the ACL bindings of the KafkaAccess and the ACL bindings
+// that the AccessControlLists and KafkaAccesses of both scopes declare on its
+// Kafka cluster.
+func (c *external) declared(ctx context.Context, cr *v1alpha1.KafkaAccess) ([]*acl.AccessControlList, error) {
+	declared, err := access.Declared(ctx, c.kube, c.config, cr)
+	if err != nil {
+		return nil, err
+	}
+	return append(access.Expand(&cr.Spec.ForProvider), declared...), nil
+}
+
+// deletable returns the bindings that no other AccessControlList or
+// KafkaAccess of its Kafka cluster declares, so that deleting them does not
+// revoke access that another resource grants.
+func (c *external) deletable(ctx context.Context, cr *v1alpha1.KafkaAccess, bindings []*acl.AccessControlList) ([]*acl.AccessControlList, error) {
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+	declared, err := access.Declared(ctx, c.kube, c.config, cr)
+	if err != nil {
+		return nil, err
+	}
+	return acl.Deletable(bindings, declared), nil
+}
+
+// observation returns the observed fields of the bindings of the KafkaAccess,
+// as returned by ListAll.
+func observation(bindings, observed []*acl.AccessControlList) common.KafkaAccessObservation {
+	o := common.KafkaAccessObservation{Bindings: len(bindings)}
+	for i, ae := range observed {
+		if ae == nil {
+			o.MissingBindings = append(o.MissingBindings, acl.Describe(bindings[i]))
+		}
+	}
+	return o
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.KafkaAccess)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotKafkaAccess)
+	}
+
+	desired := access.Expand(&cr.Spec.ForProvider)
+	extName, err := acl.ConvertAllToJSON(desired)
+	if err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf("%s: %w", errGenerateExternalName, err)
+	}
+	meta.SetExternalName(cr, extName)
+	if err := access.Create(ctx, c.kafkaClient, desired); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf("%s: %w", errCreateBindings, err)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update creates the bindings of the intents and then deletes the bindings
+// that were applied before but are no longer desired, so that access that is
+// kept is never interrupted.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.KafkaAccess)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotKafkaAccess)
+	}
+
+	current, err := acl.ConvertAllFromJSON(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errParseExternalName, err)
+	}
+	desired := access.Expand(&cr.Spec.ForProvider)
+
+	if err := access.Create(ctx, c.kafkaClient, desired); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errCreateBindings, err)
+	}
+	removed, err := c.deletable(ctx, cr, acl.Subtract(current, desired))
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := acl.Delete(ctx, c.kafkaClient, removed...); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errDeleteBindings, err)
+	}
+	if cr.Spec.ForProvider.ACLOwnership == common.ACLOwnershipAuthoritative {
+		undeclared, err := c.undeclared(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if err := acl.Delete(ctx, c.kafkaClient, undeclared...); err != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errDeleteUndeclared, err)
+		}
+	}
+
+	extName, err := acl.ConvertAllToJSON(desired)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errGenerateExternalName, err)
+	}
+	if extName != meta.GetExternalName(cr) {
+		// The managed reconciler only persists the status after an update, and
+		// updating the object resets the status to the persisted one.
+		status := cr.Status.DeepCopy()
+		meta.SetExternalName(cr, extName)
+		err = c.annotations.UpdateCriticalAnnotations(ctx, cr)
+		cr.Status = *status
+		if err != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errUpdateExternalName, err)
+		}
+	}
+	cr.Status.AtProvider = common.KafkaAccessObservation{Bindings: len(desired)}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.KafkaAccess)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotKafkaAccess)
+	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	current := access.Expand(&cr.Spec.ForProvider)
+	if a, err := acl.ConvertAllFromJSON(meta.GetExternalName(cr)); err == nil {
+		current = a
+	}
+	current, err := c.deletable(ctx, cr, current)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	if err := acl.Delete(ctx, c.kafkaClient, current...); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errDeleteBindings, err)
+	}
+	return managed.ExternalDelete{}, nil
+}
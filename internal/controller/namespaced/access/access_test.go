@@ -0,0 +1,393 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusteraclv1alpha2 "github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha2"
+	clusterv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/access/v1alpha1"
+	namespacedv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	commonv1alpha2 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/access"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+)
+
+// fakeAdmin is an in-process implementation of adminClient for unit tests.
+type fakeAdmin struct {
+	// bindings are the ACL bindings that DescribeACLs returns.
+	bindings []kadm.DescribedACL
+	// deletes is the number of DeleteACLs requests, raw or not.
+	deletes int
+}
+
+func (f *fakeAdmin) CreateACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.CreateACLsResults, error) {
+	return kadm.CreateACLsResults{{Principal: "User:alice", Permission: kmsg.ACLPermissionTypeAllow}}, nil
+}
+
+func (f *fakeAdmin) DeleteACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.DeleteACLsResults, error) {
+	f.deletes++
+	return nil, nil
+}
+
+func (f *fakeAdmin) DescribeACLs(_ context.Context, _ *kadm.ACLBuilder) (kadm.DescribeACLsResults, error) {
+	return kadm.DescribeACLsResults{{Described: f.bindings}}, nil
+}
+
+func (f *fakeAdmin) Request(_ context.Context, r kmsg.Request) (kmsg.Response, error) {
+	if _, ok := r.(*kmsg.DeleteACLsRequest); !ok {
+		return nil, errors.New("unexpected raw request")
+	}
+	f.deletes++
+	return &kmsg.DeleteACLsResponse{}, nil
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotAKafkaAccess": {
+			reason: "Should return error when managed resource is not a KafkaAccess",
+			mg:     &fake.Managed{},
+			want: want{
+				err: errors.New(errNotKafkaAccess),
+			},
+		},
+		"NoExternalName": {
+			reason: "A KafkaAccess without an external name has no bindings yet",
+			mg:     &v1alpha1.KafkaAccess{},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservation(t *testing.T) {
+	write := &acl.AccessControlList{
+		ResourceName:              "orders",
+		ResourceType:              "Topic",
+		ResourcePrincipal:         "User:alice",
+		ResourceHost:              "*",
+		ResourceOperation:         "Write",
+		ResourcePermissionType:    "Allow",
+		ResourcePatternTypeFilter: "Literal",
+	}
+	describe := *write
+	describe.ResourceOperation = "Describe"
+
+	got := observation([]*acl.AccessControlList{&describe, write}, []*acl.AccessControlList{nil, write})
+	want := common.KafkaAccessObservation{
+		Bindings:        2,
+		MissingBindings: []string{`Allow Describe on Topic "orders" (Literal) for User:alice from host *`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("observation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		deletes int
+		err     error
+	}
+
+	described := func(topic string) kadm.DescribedACL {
+		return kadm.DescribedACL{
+			Principal:  "User:alice",
+			Host:       "*",
+			Type:       kmsg.ACLResourceTypeTopic,
+			Name:       topic,
+			Pattern:    kadm.ACLPatternLiteral,
+			Operation:  kadm.OpRead,
+			Permission: kmsg.ACLPermissionTypeAllow,
+		}
+	}
+	newAccess := func(ownership string) *v1alpha1.KafkaAccess {
+		cr := &v1alpha1.KafkaAccess{Spec: v1alpha1.KafkaAccessSpec{
+			ManagedResourceSpec: xpv2.ManagedResourceSpec{ProviderConfigReference: &xpv2.ProviderConfigReference{Kind: "ClusterProviderConfig", Name: "default"}},
+			ForProvider: common.KafkaAccessParameters{
+				Principal:    "User:alice",
+				ACLOwnership: ownership,
+				Intents:      []common.KafkaAccessIntent{{Role: common.KafkaAccessRoleConsumer, Topic: "orders", Group: "billing"}},
+			},
+		}}
+		cr.SetNamespace("team")
+		extName, _ := acl.ConvertAllToJSON(access.Expand(&cr.Spec.ForProvider))
+		meta.SetExternalName(cr, extName)
+		return cr
+	}
+	// kube has a cluster scoped AccessControlList of another ProviderConfig that
+	// declares Read on payments.
+	kube := func(clusterID string) client.Client {
+		return &test.MockClient{
+			MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				if l, ok := list.(*clusteraclv1alpha2.AccessControlListList); ok {
+					l.Items = []clusteraclv1alpha2.AccessControlList{{Spec: clusteraclv1alpha2.AccessControlListSpec{
+						ClusterManagedResourceSpec: xpv2.ClusterManagedResourceSpec{ProviderConfigReference: &xpv2.Reference{Name: "shared"}},
+						ForProvider: commonv1alpha2.AccessControlListParameters{
+							ResourceName:              "payments",
+							ResourceType:              "Topic",
+							ResourcePrincipal:         "User:alice",
+							ResourceOperation:         "Read",
+							ResourcePermissionType:    "Allow",
+							ResourcePatternTypeFilter: "Literal",
+						},
+					}}}
+				}
+				return nil
+			},
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				switch pc := obj.(type) {
+				case *namespacedv1alpha1.ClusterProviderConfig:
+					pc.Status.Cluster = &common.ClusterObservation{ClusterID: "abc"}
+				case *clusterv1alpha1.ProviderConfig:
+					pc.Status.Cluster = &common.ClusterObservation{ClusterID: clusterID}
+				}
+				return nil
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason   string
+		kube     client.Client
+		bindings []kadm.DescribedACL
+		mg       resource.Managed
+		want     want
+	}{
+		"NotAKafkaAccess": {
+			reason: "Should return error when managed resource is not a KafkaAccess",
+			mg:     &fake.Managed{},
+			want: want{
+				err: errors.New(errNotKafkaAccess),
+			},
+		},
+		"AuthoritativeKeepsBindingsDeclaredAcrossScopes": {
+			reason:   "An authoritative KafkaAccess should keep the bindings that resources of other scopes and ProviderConfigs declare on its cluster",
+			kube:     kube("abc"),
+			bindings: []kadm.DescribedACL{described("payments"), described("stale")},
+			mg:       newAccess(common.ACLOwnershipAuthoritative),
+			want: want{
+				deletes: 1,
+			},
+		},
+		"AuthoritativeDeletesBindingsDeclaredOnOtherClusters": {
+			reason:   "An authoritative KafkaAccess should delete the bindings that are only declared on other clusters",
+			kube:     kube("xyz"),
+			bindings: []kadm.DescribedACL{described("payments"), described("stale")},
+			mg:       newAccess(common.ACLOwnershipAuthoritative),
+			want: want{
+				deletes: 2,
+			},
+		},
+		"SharedKeepsUndeclaredBindings": {
+			reason:   "A shared KafkaAccess should leave the bindings of its principal alone",
+			kube:     kube("xyz"),
+			bindings: []kadm.DescribedACL{described("payments"), described("stale")},
+			mg:       newAccess(common.ACLOwnershipShared),
+			want:     want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeAdmin{bindings: tc.bindings}
+			e := external{kafkaClient: cl, config: kafka.ClientKey{Kind: namespacedv1alpha1.ClusterProviderConfigGroupKind, Name: "default"}, kube: tc.kube}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deletes, cl.deletes); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want deleted bindings, +got deleted bindings:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// TestOverlappingAccess verifies that deleting or narrowing a KafkaAccess keeps
+// the bindings that another KafkaAccess of the same Kafka cluster declares.
+func TestOverlappingAccess(t *testing.T) {
+	consumer := func(uid types.UID, topic, group string) *v1alpha1.KafkaAccess {
+		cr := &v1alpha1.KafkaAccess{Spec: v1alpha1.KafkaAccessSpec{
+			ManagedResourceSpec: xpv2.ManagedResourceSpec{ProviderConfigReference: &xpv2.ProviderConfigReference{Kind: "ClusterProviderConfig", Name: "default"}},
+			ForProvider: common.KafkaAccessParameters{
+				Principal: "User:alice",
+				Intents:   []common.KafkaAccessIntent{{Role: common.KafkaAccessRoleConsumer, Topic: topic, Group: group}},
+			},
+		}}
+		cr.SetNamespace("team")
+		cr.SetUID(uid)
+		extName, _ := acl.ConvertAllToJSON(access.Expand(&cr.Spec.ForProvider))
+		meta.SetExternalName(cr, extName)
+		return cr
+	}
+	// moved consumes payments instead of orders, so that Update removes its
+	// bindings on orders.
+	moved := consumer("a", "orders", "billing")
+	moved.Spec.ForProvider.Intents[0].Topic = "payments"
+
+	// kube lists the KafkaAccesses, whose ProviderConfig the health check
+	// recorded on the same cluster.
+	kube := func(kas ...*v1alpha1.KafkaAccess) client.Client {
+		return &test.MockClient{
+			MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				if l, ok := list.(*v1alpha1.KafkaAccessList); ok {
+					for _, ka := range kas {
+						l.Items = append(l.Items, *ka)
+					}
+				}
+				return nil
+			},
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				if pc, ok := obj.(*namespacedv1alpha1.ClusterProviderConfig); ok {
+					pc.Status.Cluster = &common.ClusterObservation{ClusterID: "abc"}
+				}
+				return nil
+			},
+		}
+	}
+	deleteOp := func(e *external, mg resource.Managed) error {
+		_, err := e.Delete(context.Background(), mg)
+		return err
+	}
+	updateOp := func(e *external, mg resource.Managed) error {
+		_, err := e.Update(context.Background(), mg)
+		return err
+	}
+	// observeOp fails if the KafkaAccess is observed to exist, as the
+	// observed resources are deleted.
+	observeOp := func(e *external, mg resource.Managed) error {
+		o, err := e.Observe(context.Background(), mg)
+		if err == nil && o.ResourceExists {
+			return errors.New("a deleted KafkaAccess should not exist once only shared bindings remain")
+		}
+		return err
+	}
+	deleted := func(cr *v1alpha1.KafkaAccess) *v1alpha1.KafkaAccess {
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason  string
+		kube    client.Client
+		op      func(e *external, mg resource.Managed) error
+		mg      resource.Managed
+		deletes int
+	}{
+		"DeleteKeepsSharedBindings": {
+			reason:  "Delete should keep the topic bindings that another KafkaAccess of the principal declares",
+			kube:    kube(consumer("a", "orders", "billing"), consumer("b", "orders", "audit")),
+			op:      deleteOp,
+			mg:      consumer("a", "orders", "billing"),
+			deletes: 2,
+		},
+		"DeleteKeepsIdenticalBindings": {
+			reason:  "Delete should keep every binding if another KafkaAccess declares the same intents",
+			kube:    kube(consumer("a", "orders", "billing"), consumer("b", "orders", "billing")),
+			op:      deleteOp,
+			mg:      consumer("a", "orders", "billing"),
+			deletes: 0,
+		},
+		"DeleteIgnoresItself": {
+			reason:  "Delete should delete every binding that only the deleted KafkaAccess declares",
+			kube:    kube(consumer("a", "orders", "billing")),
+			op:      deleteOp,
+			mg:      consumer("a", "orders", "billing"),
+			deletes: 4,
+		},
+		"ObserveIgnoresSharedBindings": {
+			reason:  "A deleted KafkaAccess should not exist once only bindings that another KafkaAccess declares remain",
+			kube:    kube(consumer("a", "orders", "billing"), consumer("b", "orders", "billing")),
+			op:      observeOp,
+			mg:      deleted(consumer("a", "orders", "billing")),
+			deletes: 0,
+		},
+		"UpdateKeepsSharedBindings": {
+			reason:  "Update should keep the removed bindings that another KafkaAccess of the principal declares",
+			kube:    kube(moved, consumer("b", "orders", "audit")),
+			op:      updateOp,
+			mg:      moved.DeepCopy(),
+			deletes: 0,
+		},
+		"UpdateDeletesUnsharedBindings": {
+			reason:  "Update should delete the removed bindings that no other KafkaAccess declares",
+			kube:    kube(moved, consumer("b", "invoices", "audit")),
+			op:      updateOp,
+			mg:      moved.DeepCopy(),
+			deletes: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := &fakeAdmin{}
+			e := &external{
+				kafkaClient: cl,
+				config:      kafka.ClientKey{Kind: namespacedv1alpha1.ClusterProviderConfigGroupKind, Name: "default"},
+				kube:        tc.kube,
+				annotations: managed.CriticalAnnotationUpdateFn(func(context.Context, client.Object) error { return nil }),
+			}
+			if err := tc.op(e, tc.mg); err != nil {
+				t.Fatalf("\n%s\nunexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.deletes, cl.deletes); diff != "" {
+				t.Errorf("\n%s\n-want deleted bindings, +got deleted bindings:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
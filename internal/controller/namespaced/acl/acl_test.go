@@ -19,16 +19,26 @@ package acl
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/google/go-cmp/cmp"
-
-	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/acl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/acl/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
 	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	commonv1alpha2 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
 	aclclient "github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
 )
 
@@ -66,7 +76,7 @@ func TestObserveWrongType(t *testing.T) {
 }
 
 func TestObserveNoExternalName(t *testing.T) {
-	cr := &v1alpha1.AccessControlList{}
+	cr := &v1alpha2.AccessControlList{}
 
 	e := external{}
 	got, err := e.Observe(context.Background(), cr)
@@ -80,14 +90,29 @@ func TestObserveNoExternalName(t *testing.T) {
 }
 
 func TestPopulateACLAtProvider(t *testing.T) {
+	read := &aclclient.AccessControlList{
+		ResourceName:              "my-topic",
+		ResourceType:              "Topic",
+		ResourcePrincipal:         "User:alice",
+		ResourceHost:              "*",
+		ResourceOperation:         "Read",
+		ResourcePermissionType:    "Allow",
+		ResourcePatternTypeFilter: "Literal",
+	}
+	describe := *read
+	describe.ResourceOperation = "Describe"
+
 	cases := map[string]struct {
 		reason   string
-		observed *aclclient.AccessControlList
-		want     common.AccessControlListObservation
+		bindings []*aclclient.AccessControlList
+		observed []*aclclient.AccessControlList
+		want     commonv1alpha2.AccessControlListObservation
 	}{
 		"AllFieldsPopulated": {
-			reason: "All observed ACL fields should be mapped to atProvider",
-			observed: &aclclient.AccessControlList{
+			reason:   "All observed ACL fields should be mapped to atProvider",
+			bindings: []*aclclient.AccessControlList{read},
+			observed: []*aclclient.AccessControlList{read},
+			want: commonv1alpha2.AccessControlListObservation{
 				ResourceName:              "my-topic",
 				ResourceType:              "Topic",
 				ResourcePrincipal:         "User:alice",
@@ -95,35 +120,119 @@ func TestPopulateACLAtProvider(t *testing.T) {
 				ResourceOperation:         "Read",
 				ResourcePermissionType:    "Allow",
 				ResourcePatternTypeFilter: "Literal",
+				Bindings:                  1,
 			},
-			want: common.AccessControlListObservation{
-				ResourceName:              "my-topic",
-				ResourceType:              "Topic",
-				ResourcePrincipal:         "User:alice",
-				ResourceHost:              "*",
-				ResourceOperation:         "Read",
-				ResourcePermissionType:    "Allow",
-				ResourcePatternTypeFilter: "Literal",
+		},
+		"MissingBindings": {
+			reason:   "The bindings Kafka does not hold should be reported, without the fields of a single binding",
+			bindings: []*aclclient.AccessControlList{&describe, read},
+			observed: []*aclclient.AccessControlList{nil, read},
+			want: commonv1alpha2.AccessControlListObservation{
+				Bindings:        2,
+				MissingBindings: []string{`Allow Describe on Topic "my-topic" (Literal) for User:alice from host *`},
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			cr := &v1alpha1.AccessControlList{}
-			cr.Status.SetConditions(xpv2.Available())
-
-			cr.Status.AtProvider.ResourceName = tc.observed.ResourceName
-			cr.Status.AtProvider.ResourceType = tc.observed.ResourceType
-			cr.Status.AtProvider.ResourcePrincipal = tc.observed.ResourcePrincipal
-			cr.Status.AtProvider.ResourceHost = tc.observed.ResourceHost
-			cr.Status.AtProvider.ResourceOperation = tc.observed.ResourceOperation
-			cr.Status.AtProvider.ResourcePermissionType = tc.observed.ResourcePermissionType
-			cr.Status.AtProvider.ResourcePatternTypeFilter = tc.observed.ResourcePatternTypeFilter
-
-			if diff := cmp.Diff(tc.want, cr.Status.AtProvider); diff != "" {
+			if diff := cmp.Diff(tc.want, observation(tc.bindings, tc.observed)); diff != "" {
 				t.Errorf("\n%s\natProvider: -want, +got:\n%s", tc.reason, diff)
 			}
 		})
 	}
 }
+
+func TestSetReplacedCondition(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		changed     []string
+		err         error
+		wantStatus  corev1.ConditionStatus
+		wantReason  xpv2.ConditionReason
+		wantMessage string
+	}{
+		"Replaced": {
+			reason:      "The changed fields should be listed in a true condition",
+			changed:     []string{"resourceHost", "resourceOperation"},
+			wantStatus:  corev1.ConditionTrue,
+			wantReason:  common.ReasonReplaced,
+			wantMessage: "replaced binding to change resourceHost, resourceOperation",
+		},
+		"ReplaceFailed": {
+			reason:      "The failed step should be reported in a false condition",
+			changed:     []string{"resourceHost"},
+			err:         fmt.Errorf("%s: %w", errDeleteOldBinding, errors.New("boom")),
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  common.ReasonReplaceFailed,
+			wantMessage: errDeleteOldBinding + ": boom",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.AccessControlList{}
+			setReplacedCondition(cr, tc.changed, tc.err)
+
+			c := cr.Status.GetCondition(common.TypeReplaced)
+			assert.Equal(t, tc.wantStatus, c.Status, tc.reason)
+			assert.Equal(t, tc.wantReason, c.Reason, tc.reason)
+			assert.Equal(t, tc.wantMessage, c.Message, tc.reason)
+		})
+	}
+}
+
+// TestDeleteKeepsDeclaredBindings verifies that deleting an AccessControlList
+// keeps the bindings that another AccessControlList of the same Kafka cluster
+// declares. The external client has no Kafka client, so any call would panic.
+func TestDeleteKeepsDeclaredBindings(t *testing.T) {
+	newACL := func(uid types.UID) *v1alpha2.AccessControlList {
+		cr := &v1alpha2.AccessControlList{Spec: v1alpha2.AccessControlListSpec{
+			ManagedResourceSpec: xpv2.ManagedResourceSpec{ProviderConfigReference: &xpv2.ProviderConfigReference{Kind: "ClusterProviderConfig", Name: "default"}},
+			ForProvider: commonv1alpha2.AccessControlListParameters{
+				ResourceName:              "orders",
+				ResourceType:              "Topic",
+				ResourcePrincipal:         "User:alice",
+				ResourceOperation:         "Read",
+				ResourcePermissionType:    "Allow",
+				ResourcePatternTypeFilter: "Literal",
+			},
+		}}
+		cr.SetNamespace("team")
+		cr.SetUID(uid)
+		extName, _ := aclclient.ConvertAllToJSON(aclclient.Expand(&cr.Spec.ForProvider))
+		meta.SetExternalName(cr, extName)
+		return cr
+	}
+	other := newACL("other")
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			if l, ok := list.(*v1alpha2.AccessControlListList); ok {
+				l.Items = []v1alpha2.AccessControlList{*other}
+			}
+			return nil
+		},
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if pc, ok := obj.(*apisv1alpha1.ClusterProviderConfig); ok {
+				pc.Status.Cluster = &common.ClusterObservation{ClusterID: "abc"}
+			}
+			return nil
+		},
+	}
+	e := &external{config: kafka.ClientKey{Kind: apisv1alpha1.ClusterProviderConfigGroupKind, Name: "default"}, kube: kube}
+
+	cr := newACL("self")
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Errorf("e.Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: false}, got); diff != "" {
+		t.Errorf("e.Observe(...): a deleted AccessControlList whose bindings are declared by another should not exist: -want, +got:\n%s", diff)
+	}
+	if _, err := e.Delete(context.Background(), cr); err != nil {
+		t.Errorf("e.Delete(...): unexpected error: %v", err)
+	}
+}
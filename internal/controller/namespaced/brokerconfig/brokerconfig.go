@@ -0,0 +1,270 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/brokerconfig/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/brokerconfig"
+)
+
+const (
+	errAlterConfigs    = "cannot alter broker configs"
+	errDeleteConfigs   = "cannot delete broker configs"
+	errGetConfigs      = "cannot get broker configs from broker config client"
+	errGetCPC          = "cannot get ClusterProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetPC           = "cannot get ProviderConfig"
+	errNewClient       = "cannot create new Kafka client"
+	errNotBrokerConfig = "managed resource is not a BrokerConfig custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+)
+
+// A connector is expected to produce an ExternalClient when its Connect method is called.
+type connector struct {
+	cache        *kafka.ClientCache
+	kube         client.Client
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
+	usage        *resource.ProviderConfigUsageTracker
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kafkaClient brokerconfig.AdminClient
+	release     func()
+	log         logging.Logger
+}
+
+// Setup adds a controller that reconciles BrokerConfig managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	name := managed.ControllerName(v1alpha1.BrokerConfigGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			cache:        clients,
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: kafka.NewAdminClient,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))), //nolint:staticcheck // crossplane-runtime doesn't support new events API yet
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.BrokerConfigList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return fmt.Errorf("cannot register MR state metrics recorder for kind v1alpha1.BrokerConfigList: %w", err)
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.BrokerConfigGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.BrokerConfig{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// SetupGated adds a controller that reconciles BrokerConfig managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	o.Gate.Register(func() {
+		if err := Setup(mgr, o, clients); err != nil {
+			panic(fmt.Errorf("cannot setup BrokerConfig controller: %w", err))
+		}
+	}, v1alpha1.BrokerConfigGroupVersionKind)
+	return nil
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.BrokerConfig)
+	if !ok {
+		return nil, errors.New(errNotBrokerConfig)
+	}
+
+	if err := c.usage.Track(ctx, cr); err != nil {
+		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
+	}
+
+	var spec apisv1alpha1.ProviderConfigSpec
+	var key kafka.ClientKey
+
+	// Switch to ModernManaged resource to get ProviderConfigRef
+	m := mg.(resource.ModernManaged)
+	ref := m.GetProviderConfigReference()
+
+	switch ref.Kind {
+	case "ProviderConfig":
+		pc := &apisv1alpha1.ProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: m.GetNamespace()}, pc); err != nil {
+			return nil, fmt.Errorf("%s: %w", errGetPC, err)
+		}
+		spec = pc.Spec
+		key = kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Namespace: pc.Namespace, Name: pc.Name}
+	case "ClusterProviderConfig":
+		cpc := &apisv1alpha1.ClusterProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
+			return nil, fmt.Errorf("%s: %w", errGetCPC, err)
+		}
+		spec = cpc.Spec
+		key = kafka.ClientKey{Kind: apisv1alpha1.ClusterProviderConfigGroupKind, Name: cpc.Name}
+	default:
+		return nil, fmt.Errorf("unsupported provider config kind: %s", ref.Kind)
+	}
+
+	cd := spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return c.newServiceFn(ctx, data, c.kube)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+
+	return &external{kafkaClient: svc, release: release, log: c.log}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
+	c.kafkaClient = nil
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BrokerConfig)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBrokerConfig)
+	}
+
+	bc, err := brokerconfig.Get(ctx, c.kafkaClient, cr.Spec.ForProvider.BrokerID)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errGetConfigs, err)
+	}
+	return observation(cr, bc), nil
+}
+
+// observation reports whether the configs of the BrokerConfig exist and are
+// up to date, and records their observed values in its status.
+func observation(cr *v1alpha1.BrokerConfig, bc *brokerconfig.BrokerConfig) managed.ExternalObservation {
+	// Broker configs always exist, so the resource is reported as existing
+	// until its configs have been removed from the dynamic config.
+	if meta.WasDeleted(cr) && len(bc.ManagedKeys(cr.Spec.ForProvider.Config)) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+
+	cr.Status.AtProvider = bc.ToObservation(cr.Spec.ForProvider.Config)
+	cr.Status.SetConditions(xpv2.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: brokerconfig.IsUpToDate(&cr.Spec.ForProvider, bc),
+	}
+}
+
+// Create is never called because Observe always reports the broker configs
+// as existing; they are set by Update.
+func (c *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1alpha1.BrokerConfig); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBrokerConfig)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BrokerConfig)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBrokerConfig)
+	}
+
+	bc, err := brokerconfig.Get(ctx, c.kafkaClient, cr.Spec.ForProvider.BrokerID)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errGetConfigs, err)
+	}
+	if err := brokerconfig.Alter(ctx, c.kafkaClient, cr.Spec.ForProvider.Config, bc); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf("%s: %w", errAlterConfigs, err)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.BrokerConfig)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotBrokerConfig)
+	}
+	cr.Status.SetConditions(xpv2.Deleting())
+
+	bc, err := brokerconfig.Get(ctx, c.kafkaClient, cr.Spec.ForProvider.BrokerID)
+	if err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errGetConfigs, err)
+	}
+	if err := brokerconfig.Delete(ctx, c.kafkaClient, bc.ManagedKeys(cr.Spec.ForProvider.Config), bc); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf("%s: %w", errDeleteConfigs, err)
+	}
+	return managed.ExternalDelete{}, nil
+}
@@ -0,0 +1,125 @@
+package brokerconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/brokerconfig/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/brokerconfig"
+)
+
+const testRetention = "log.retention.ms"
+
+func TestWrongType(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		op     func(e *external, mg resource.Managed) error
+	}{
+		"Observe": {
+			reason: "Observe should return error when managed resource is not a BrokerConfig",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Observe(context.Background(), mg)
+				return err
+			},
+		},
+		"Create": {
+			reason: "Create should return error when managed resource is not a BrokerConfig",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+		},
+		"Update": {
+			reason: "Update should return error when managed resource is not a BrokerConfig",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+		},
+		"Delete": {
+			reason: "Delete should return error when managed resource is not a BrokerConfig",
+			op: func(e *external, mg resource.Managed) error {
+				_, err := e.Delete(context.Background(), mg)
+				return err
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.op(&external{}, &fake.Managed{})
+			if diff := cmp.Diff(errors.New(errNotBrokerConfig), err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\n-want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservation(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	brokerID := int32(1)
+	dynamic := func(value string) kadm.Config {
+		return kadm.Config{Key: testRetention, Value: ptr(value), Source: kmsg.ConfigSourceDynamicBrokerConfig}
+	}
+	static := func(value string) kadm.Config {
+		return kadm.Config{Key: testRetention, Value: ptr(value), Source: kmsg.ConfigSourceStaticBrokerConfig}
+	}
+
+	cases := map[string]struct {
+		reason   string
+		deleted  bool
+		observed kadm.Config
+		want     managed.ExternalObservation
+	}{
+		"UpToDate": {
+			reason:   "A config that is set on the broker with the desired value should be up to date",
+			observed: dynamic("86400000"),
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"Drifted": {
+			reason:   "A config that is set on the broker with another value should not be up to date",
+			observed: dynamic("3600000"),
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"DeletedButStillSet": {
+			reason:   "A deleted resource whose config is still set on the broker should exist until it is removed",
+			deleted:  true,
+			observed: dynamic("86400000"),
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"DeletedAndRemoved": {
+			reason:   "A deleted resource whose config only has its static value should not exist",
+			deleted:  true,
+			observed: static("604800000"),
+			want:     managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.BrokerConfig{}
+			cr.Spec.ForProvider.BrokerID = &brokerID
+			cr.Spec.ForProvider.Config = map[string]*string{testRetention: ptr("86400000")}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+			bc := &brokerconfig.BrokerConfig{BrokerID: &brokerID, Configs: map[string]kadm.Config{testRetention: tc.observed}}
+
+			got := observation(cr, bc)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nobservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -25,11 +25,16 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
 )
 
-// Setup adds a controller that reconciles ProviderConfigs and
-// ClusterProviderConfigs by accounting for their current usage.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+// Setup adds controllers that reconcile ProviderConfigs and
+// ClusterProviderConfigs by accounting for their current usage and checking
+// the health of their Kafka cluster.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	if err := SetupHealth(mgr, o, clients); err != nil {
+		return err
+	}
 	if err := setupNamespacedProviderConfig(mgr, o); err != nil {
 		return err
 	}
@@ -77,11 +82,12 @@ func setupClusterProviderConfig(mgr ctrl.Manager, o controller.Options) error {
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
-// SetupGated adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+// SetupGated adds controllers that reconcile ProviderConfigs and
+// ClusterProviderConfigs by accounting for their current usage and checking
+// the health of their Kafka cluster.
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
 	o.Gate.Register(func() {
-		if err := Setup(mgr, o); err != nil {
+		if err := Setup(mgr, o, clients); err != nil {
 			mgr.GetLogger().Error(err, "unable to setup reconcilers", "gvk", v1alpha1.ClusterProviderConfigGroupVersionKind.String(), "gvk", v1alpha1.ProviderConfigGroupVersionKind.String())
 		}
 	}, v1alpha1.ClusterProviderConfigGroupVersionKind, v1alpha1.ProviderConfigGroupVersionKind, v1alpha1.ProviderConfigUsageGroupVersionKind)
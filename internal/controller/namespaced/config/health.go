@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+)
+
+const (
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errNewClient       = "cannot create new Kafka client"
+	errUpdateStatus    = "cannot update ProviderConfig status"
+	healthCheckTimeout = 30 * time.Second
+)
+
+// SetupHealth adds controllers that periodically check the Kafka cluster of
+// each ProviderConfig and ClusterProviderConfig and report its health in their
+// status.
+func SetupHealth(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	if err := setupHealth(mgr, o, clients, v1alpha1.ProviderConfigGroupKind, func() providerConfig { return &v1alpha1.ProviderConfig{} }); err != nil {
+		return err
+	}
+	return setupHealth(mgr, o, clients, v1alpha1.ClusterProviderConfigGroupKind, func() providerConfig { return &v1alpha1.ClusterProviderConfig{} })
+}
+
+func setupHealth(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache, kind string, newConfig func() providerConfig) error {
+	name := "health/" + strings.ToLower(kind)
+
+	r := &healthReconciler{
+		kube:        mgr.GetClient(),
+		log:         o.Logger.WithValues("controller", name),
+		interval:    o.PollInterval,
+		newConfig:   newConfig,
+		kind:        kind,
+		cache:       clients,
+		newClientFn: kafka.NewAdminClient,
+	}
+
+	// Only spec changes trigger an immediate check, so that the status
+	// updates of the check itself do not.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(newConfig()).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// providerConfig is a ProviderConfig or a ClusterProviderConfig.
+type providerConfig interface {
+	client.Object
+	resource.Conditioned
+}
+
+// specAndStatus returns the spec and status of a ProviderConfig or
+// ClusterProviderConfig.
+func specAndStatus(pc providerConfig) (*v1alpha1.ProviderConfigSpec, *v1alpha1.ProviderConfigStatus) {
+	switch pc := pc.(type) {
+	case *v1alpha1.ProviderConfig:
+		return &pc.Spec, &pc.Status
+	case *v1alpha1.ClusterProviderConfig:
+		return &pc.Spec, &pc.Status
+	default:
+		return nil, nil
+	}
+}
+
+// A healthReconciler checks the health of the Kafka cluster of a
+// ProviderConfig or ClusterProviderConfig.
+type healthReconciler struct {
+	kube        client.Client
+	log         logging.Logger
+	interval    time.Duration
+	newConfig   func() providerConfig
+	kind        string
+	cache       *kafka.ClientCache
+	newClientFn func(ctx context.Context, data []byte, kube client.Client) (*kafka.Client, error)
+}
+
+// Reconcile checks the cluster of the ProviderConfig, records the outcome in
+// its Ready condition and requeues it for the next check.
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	pc := r.newConfig()
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, resource.IgnoreNotFound(fmt.Errorf("%s: %w", errGetPC, err))
+	}
+	if meta.WasDeleted(pc) {
+		return reconcile.Result{}, nil
+	}
+
+	orig := pc.DeepCopyObject().(client.Object)
+	spec, status := specAndStatus(pc)
+	key := kafka.ClientKey{Kind: r.kind, Namespace: pc.GetNamespace(), Name: pc.GetName()}
+	obs, reason, err := r.check(ctx, key, *spec)
+	if err != nil {
+		log.Debug("Kafka cluster health check failed", "error", err)
+		pc.SetConditions(common.ClusterUnavailable(reason, err))
+	} else {
+		status.Cluster = obs
+		pc.SetConditions(common.ClusterAvailable())
+	}
+
+	if err := r.kube.Status().Patch(ctx, pc, client.MergeFrom(orig)); err != nil {
+		return reconcile.Result{}, resource.IgnoreNotFound(fmt.Errorf("%s: %w", errUpdateStatus, err))
+	}
+	return reconcile.Result{RequeueAfter: r.interval}, nil
+}
+
+// check checks the cluster of the ProviderConfig with the cached client of
+// the key, which the managed resources of the ProviderConfig share. On failure
+// it returns the reason of the failure, which is InvalidConfig if no client
+// could be created from the ProviderConfig.
+func (r *healthReconciler) check(ctx context.Context, key kafka.ClientKey, spec v1alpha1.ProviderConfigSpec) (*common.ClusterObservation, xpv2.ConditionReason, error) {
+	cd := spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, r.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, common.ReasonInvalidConfig, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+	data, err = kafka.MergeConnection(ctx, r.kube, data, spec.Connection)
+	if err != nil {
+		return nil, common.ReasonInvalidConfig, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
+
+	cl, release, err := r.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
+		return r.newClientFn(ctx, data, r.kube)
+	})
+	if err != nil {
+		return nil, common.ReasonInvalidConfig, fmt.Errorf("%s: %w", errNewClient, err)
+	}
+	defer release()
+
+	obs, err := kafka.CheckCluster(ctx, cl)
+	if err != nil {
+		return nil, kafka.HealthCheckReason(err), err
+	}
+	return obs, common.ReasonClusterAvailable, nil
+}
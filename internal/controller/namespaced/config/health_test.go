@@ -0,0 +1,134 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+)
+
+func TestHealthReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	interval := time.Minute
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pc"}}
+
+	noneSource := func(obj client.Object) {
+		pc := obj.(*v1alpha1.ProviderConfig)
+		pc.Spec.Credentials.Source = xpv2.CredentialsSourceNone
+	}
+
+	type want struct {
+		result reconcile.Result
+		err    bool
+		// reason is the reason of the patched Ready condition, if any.
+		reason xpv2.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason      string
+		get         func(obj client.Object)
+		getErr      error
+		newClientFn func(context.Context, []byte, client.Client) (*kafka.Client, error)
+		want        want
+	}{
+		"NotFound": {
+			reason: "A deleted ProviderConfig should not be checked or requeued.",
+			getErr: kerrors.NewNotFound(schema.GroupResource{}, "pc"),
+		},
+		"GetError": {
+			reason: "Errors getting the ProviderConfig should be returned.",
+			getErr: errBoom,
+			want:   want{err: true},
+		},
+		"Deleting": {
+			reason: "A ProviderConfig that is being deleted should not be checked.",
+			get: func(obj client.Object) {
+				now := metav1.Now()
+				obj.SetDeletionTimestamp(&now)
+			},
+		},
+		"MissingCredentialsSecret": {
+			reason: "Credentials that cannot be read should be reported as an invalid config.",
+			get: func(obj client.Object) {
+				pc := obj.(*v1alpha1.ProviderConfig)
+				pc.Spec.Credentials.Source = xpv2.CredentialsSourceSecret
+			},
+			want: want{result: reconcile.Result{RequeueAfter: interval}, reason: common.ReasonInvalidConfig},
+		},
+		"NewClientError": {
+			reason: "A client that cannot be created should be reported as an invalid config.",
+			get:    noneSource,
+			newClientFn: func(context.Context, []byte, client.Client) (*kafka.Client, error) {
+				return nil, errBoom
+			},
+			want: want{result: reconcile.Result{RequeueAfter: interval}, reason: common.ReasonInvalidConfig},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patched *v1alpha1.ProviderConfig
+			r := &healthReconciler{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						if tc.getErr != nil {
+							return tc.getErr
+						}
+						if tc.get != nil {
+							tc.get(obj)
+						}
+						return nil
+					},
+					MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						patched = obj.(*v1alpha1.ProviderConfig)
+						return nil
+					},
+				},
+				log:         logging.NewNopLogger(),
+				newConfig:   func() providerConfig { return &v1alpha1.ProviderConfig{} },
+				kind:        v1alpha1.ProviderConfigGroupKind,
+				interval:    interval,
+				cache:       kafka.NewClientCache(),
+				newClientFn: tc.newClientFn,
+			}
+
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nReconcile(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if tc.want.reason == "" {
+				if patched != nil {
+					t.Errorf("\n%s\nReconcile(...): unexpected status patch", tc.reason)
+				}
+				return
+			}
+			if patched == nil {
+				t.Fatalf("\n%s\nReconcile(...): want status patch, got none", tc.reason)
+			}
+			c := patched.GetCondition(xpv2.TypeReady)
+			if c.Status != corev1.ConditionFalse || c.Reason != tc.want.reason {
+				t.Errorf("\n%s\nReconcile(...): want Ready False with reason %s, got %s with reason %s", tc.reason, tc.want.reason, c.Status, c.Reason)
+			}
+		})
+	}
+}
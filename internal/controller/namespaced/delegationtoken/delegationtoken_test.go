@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegationtoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/delegationtoken/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/delegationtoken"
+)
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotADelegationToken": {
+			reason: "Should return error when managed resource is not a DelegationToken",
+			mg:     &fake.Managed{},
+			want: want{
+				err: errors.New(errNotDelegationToken),
+			},
+		},
+		"NoExternalName": {
+			reason: "A DelegationToken without an external name has no token yet",
+			mg:     &v1alpha1.DelegationToken{},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	e := &external{brokers: []string{"kafka-0:9092", "kafka-1:9092"}}
+	got := e.connectionDetails(&kadm.DelegationToken{TokenID: "token-1", HMAC: []byte("secret")})
+	want := managed.ConnectionDetails{
+		delegationtoken.ConnectionKeyTokenID: []byte("token-1"),
+		delegationtoken.ConnectionKeyHMAC:    []byte("c2VjcmV0"),
+		delegationtoken.ConnectionKeyBrokers: []byte("kafka-0:9092,kafka-1:9092"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.connectionDetails(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestObservation(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	token := func(issued, expires, maxLifetime time.Duration) *kadm.DelegationToken {
+		return &kadm.DelegationToken{
+			TokenID:         "token-1",
+			IssueTimestamp:  now.Add(issued),
+			ExpiryTimestamp: now.Add(expires),
+			MaxTimestamp:    now.Add(maxLifetime),
+		}
+	}
+
+	type want struct {
+		exists   bool
+		upToDate bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		deleted bool
+		token   *kadm.DelegationToken
+		want    want
+	}{
+		"Fresh": {
+			reason: "A token that is far from expiring should be up to date",
+			token:  token(-time.Hour, 23*time.Hour, 7*24*time.Hour),
+			want:   want{exists: true, upToDate: true},
+		},
+		"DueForRenewal": {
+			reason: "A token that expires soon but can still be renewed should not be up to date",
+			token:  token(-23*time.Hour, 30*time.Minute, 6*24*time.Hour),
+			want:   want{exists: true},
+		},
+		"DueForReplacement": {
+			reason: "A token that reaches its max lifetime soon should be replaced",
+			token:  token(-7*24*time.Hour, 30*time.Minute, 30*time.Minute),
+		},
+		"Expired": {
+			reason: "An expired token should be replaced",
+			token:  token(-7*24*time.Hour, -time.Minute, -time.Minute),
+		},
+		"ExpiredWhileDeleted": {
+			reason:  "An expired token should still exist while the resource is deleted, so that Delete expires it",
+			deleted: true,
+			token:   token(-7*24*time.Hour, -time.Minute, -time.Minute),
+			want:    want{exists: true, upToDate: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DelegationToken{}
+			if tc.deleted {
+				ts := metav1.Now()
+				cr.SetDeletionTimestamp(&ts)
+			}
+			e := &external{}
+			got := e.observation(cr, tc.token, now)
+			if diff := cmp.Diff(tc.want, want{exists: got.ResourceExists, upToDate: got.ResourceUpToDate}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.observation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
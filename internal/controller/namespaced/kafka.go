@@ -20,20 +20,33 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/access"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/brokerconfig"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/config"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/consumergroup"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/delegationtoken"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/quota"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/topic"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/namespaced/user"
 )
 
-// Setup creates all controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+// Setup creates all controllers with the supplied logger and Kafka client cache
+// and adds them to the supplied manager.
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	for _, setup := range []func(ctrl.Manager, controller.Options, *kafka.ClientCache) error{
 		config.Setup,
 		topic.Setup,
 		acl.Setup,
+		user.Setup,
+		consumergroup.Setup,
+		brokerconfig.Setup,
+		quota.Setup,
+		access.Setup,
+		delegationtoken.Setup,
 	} {
-		if err := setup(mgr, o); err != nil {
+		if err := setup(mgr, o, clients); err != nil {
 			return err
 		}
 	}
@@ -42,13 +55,19 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 // SetupGated creates all Kafka controllers with safe-start support and adds them to
 // the supplied manager.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
+	for _, setup := range []func(ctrl.Manager, controller.Options, *kafka.ClientCache) error{
 		config.SetupGated,
 		topic.SetupGated,
 		acl.SetupGated,
+		user.SetupGated,
+		consumergroup.SetupGated,
+		brokerconfig.SetupGated,
+		quota.SetupGated,
+		access.SetupGated,
+		delegationtoken.SetupGated,
 	} {
-		if err := setup(mgr, o); err != nil {
+		if err := setup(mgr, o, clients); err != nil {
 			return err
 		}
 	}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
@@ -39,18 +41,23 @@ import (
 
 	"github.com/crossplane-contrib/provider-kafka/apis/namespaced/topic/v1alpha1"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/namespaced/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
 	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
 )
 
 const (
-	errGetCPC       = "cannot get ClusterProviderConfig"
-	errGetCreds     = "cannot get credentials"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetTopic     = "cannot get topic spec from topic client"
-	errNewClient    = "cannot create new Kafka client"
-	errNotTopic     = "managed resource is not a Topic custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetCPC           = "cannot get ClusterProviderConfig"
+	errGetCreds         = "cannot get credentials"
+	errGetPC            = "cannot get ProviderConfig"
+	errGetTopic         = "cannot get topic spec from topic client"
+	errNewClient        = "cannot create new Kafka client"
+	errNotTopic         = "managed resource is not a Topic custom resource"
+	errReassignment     = "cannot check partition reassignment"
+	errRecordConfigKeys = "cannot record the managed config keys"
+	errTrackPCUsage     = "cannot track ProviderConfig usage"
+
+	msgPartitionsUnhealthy = "%d partitions offline, %d partitions under-replicated"
 )
 
 // A connector is expected to produce an ExternalClient when its Connect method is called.
@@ -58,7 +65,7 @@ type connector struct {
 	cache        *kafka.ClientCache
 	kube         client.Client
 	log          logging.Logger
-	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kadm.Client, error)
+	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kafka.Client, error)
 	usage        *resource.ProviderConfigUsageTracker
 }
 
@@ -66,16 +73,18 @@ type connector struct {
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	kafkaClient *kadm.Client
+	release     func()
+	annotations managed.CriticalAnnotationUpdater
 	log         logging.Logger
 }
 
 // Setup adds a controller that reconciles Topic managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
 	name := managed.ControllerName(v1alpha1.TopicGroupKind)
 
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnector(&connector{
-			cache:        &kafka.ClientCache{},
+			cache:        clients,
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newServiceFn: kafka.NewAdminClient,
@@ -117,9 +126,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // SetupGated adds a controller that reconciles MyType managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o controller.Options, clients *kafka.ClientCache) error {
 	o.Gate.Register(func() {
-		if err := Setup(mgr, o); err != nil {
+		if err := Setup(mgr, o, clients); err != nil {
 			panic(fmt.Errorf("cannot setup Topic controller: %w", err))
 		}
 	}, v1alpha1.TopicGroupVersionKind)
@@ -141,7 +150,8 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf("%s: %w", errTrackPCUsage, err)
 	}
 
-	var cd apisv1alpha1.ProviderCredentials
+	var spec apisv1alpha1.ProviderConfigSpec
+	var key kafka.ClientKey
 
 	// Switch to ModernManaged resource to get ProviderConfigRef
 	m := mg.(resource.ModernManaged)
@@ -153,33 +163,43 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: m.GetNamespace()}, pc); err != nil {
 			return nil, fmt.Errorf("%s: %w", errGetPC, err)
 		}
-		cd = pc.Spec.Credentials
+		spec = pc.Spec
+		key = kafka.ClientKey{Kind: apisv1alpha1.ProviderConfigGroupKind, Namespace: pc.Namespace, Name: pc.Name}
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, fmt.Errorf("%s: %w", errGetCPC, err)
 		}
-		cd = cpc.Spec.Credentials
+		spec = cpc.Spec
+		key = kafka.ClientKey{Kind: apisv1alpha1.ClusterProviderConfigGroupKind, Name: cpc.Name}
 	default:
 		return nil, fmt.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
 
+	cd := spec.Credentials
 	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
 	}
+	data, err = kafka.MergeConnection(ctx, c.kube, data, spec.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetCreds, err)
+	}
 
-	svc, err := c.cache.GetOrCreate(data, func() (*kadm.Client, error) {
+	svc, release, err := c.cache.GetOrCreate(key, data, func() (*kafka.Client, error) {
 		return c.newServiceFn(ctx, data, c.kube)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errNewClient, err)
 	}
 
-	return &external{kafkaClient: svc, log: c.log}, nil
+	return &external{kafkaClient: svc.Client, release: release, annotations: managed.NewRetryingCriticalAnnotationUpdater(c.kube), log: c.log}, nil
 }
 
 func (c *external) Disconnect(_ context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
 	c.kafkaClient = nil
 	return nil
 }
@@ -198,23 +218,74 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, fmt.Errorf(errGetTopic+": %w", err)
 	}
 
+	// Unset parameters are taken from the existing topic, so that topics can
+	// be imported without copying their current settings into the spec.
+	lateInitialized := topic.LateInitialize(&cr.Spec.ForProvider, tpc)
+
 	// On the first reconcile, AddFinalizer performs a full-object Update that
 	// resets the in-memory status before it can be persisted. Returning
 	// ResourceUpToDate=false forces an Update call which runs after
 	// AddFinalizer and re-populates status there.
 	statusPopulated := cr.Status.AtProvider.ID != ""
 
+	// While a replication factor change is being applied the observed replica
+	// count is a mix of old and new replicas, so the reassignment is tracked in
+	// status until Kafka reports that it has finished.
+	reassigning := false
+	if cr.Status.AtProvider.ReassignmentInProgress || int(tpc.ReplicationFactor) != cr.Spec.ForProvider.ReplicationFactor {
+		reassigning, err = topic.ReassignmentInProgress(ctx, c.kafkaClient, tpc)
+		if err != nil {
+			return managed.ExternalObservation{}, fmt.Errorf("%s: %w", errReassignment, err)
+		}
+	}
+
+	// Keys removed from the spec stay managed until their overrides have been
+	// deleted from the topic.
+	managedKeys := topic.ManagedConfigKeys(&cr.Spec.ForProvider, recordedConfigKeys(cr), tpc)
+
 	cr.Status.AtProvider = tpc.ToObservation()
-	cr.Status.SetConditions(xpv2.Available())
+	cr.Status.AtProvider.ReassignmentInProgress = reassigning
+	cr.Status.SetConditions(availability(cr.Status.AtProvider))
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isResourceUpToDate(cr, statusPopulated, tpc),
+		ResourceExists:          true,
+		ResourceUpToDate:        isResourceUpToDate(cr, statusPopulated, managedKeys, tpc),
+		ResourceLateInitialized: lateInitialized,
 	}, nil
 }
 
-func isResourceUpToDate(cr *v1alpha1.Topic, statusPopulated bool, observed *topic.Topic) bool {
-	return statusPopulated && topic.IsUpToDate(&cr.Spec.ForProvider, observed)
+// isResourceUpToDate returns true if the topic matches the spec and the
+// managed config keys are recorded. A topic whose managed config keys are not
+// recorded yet is updated so that Update records them.
+func isResourceUpToDate(cr *v1alpha1.Topic, statusPopulated bool, managedKeys []string, observed *topic.Topic) bool {
+	return statusPopulated && !cr.Status.AtProvider.ReassignmentInProgress &&
+		len(topic.StaleConfigKeys(&cr.Spec.ForProvider, managedKeys)) == 0 &&
+		slices.Equal(managedKeys, recordedConfigKeys(cr)) &&
+		topic.IsUpToDate(&cr.Spec.ForProvider, observed)
+}
+
+// recordedConfigKeys returns the config keys recorded as managed for the
+// Topic.
+func recordedConfigKeys(cr *v1alpha1.Topic) []string {
+	return topic.ParseConfigKeys(cr.GetAnnotations()[topic.AnnotationKeyManagedConfigKeys])
+}
+
+// recordConfigKeys records the config keys as managed for the Topic.
+func (c *external) recordConfigKeys(ctx context.Context, cr *v1alpha1.Topic, keys []string) error {
+	v := topic.FormatConfigKeys(keys)
+	if cr.GetAnnotations()[topic.AnnotationKeyManagedConfigKeys] == v {
+		return nil
+	}
+	// The managed reconciler only persists the status after an update, and
+	// updating the object resets the status to the persisted one.
+	status := cr.Status.DeepCopy()
+	meta.AddAnnotations(cr, map[string]string{topic.AnnotationKeyManagedConfigKeys: v})
+	err := c.annotations.UpdateCriticalAnnotations(ctx, cr)
+	cr.Status = *status
+	if err != nil {
+		return fmt.Errorf("%s: %w", errRecordConfigKeys, err)
+	}
+	return nil
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -222,7 +293,16 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotTopic)
 	}
-	return managed.ExternalCreation{}, topic.Create(ctx, c.kafkaClient, topic.Generate(meta.GetExternalName(cr), &cr.Spec.ForProvider))
+	if err := topic.ValidateConfig(&cr.Spec.ForProvider, nil); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	desired := topic.Generate(meta.GetExternalName(cr), &cr.Spec.ForProvider)
+	if err := topic.Create(ctx, c.kafkaClient, desired); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	// The managed reconciler persists the annotations after a create.
+	meta.AddAnnotations(cr, map[string]string{topic.AnnotationKeyManagedConfigKeys: topic.FormatConfigKeys(slices.Collect(maps.Keys(cr.Spec.ForProvider.Config)))})
+	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -231,11 +311,36 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotTopic)
 	}
 
+	// The observed config lists every config the broker knows for the topic.
+	if err := topic.ValidateConfig(&cr.Spec.ForProvider, cr.Status.AtProvider.Config); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	name := meta.GetExternalName(cr)
 
-	if err := topic.Update(ctx, c.kafkaClient, topic.Generate(name, &cr.Spec.ForProvider)); err != nil {
+	desired := topic.Generate(name, &cr.Spec.ForProvider)
+	desired.DeleteConfig = topic.StaleConfigKeys(&cr.Spec.ForProvider, recordedConfigKeys(cr))
+
+	// Config keys are recorded before they are set, so that they are deleted
+	// once they are removed from the spec even if the update fails part way.
+	specKeys := slices.Collect(maps.Keys(cr.Spec.ForProvider.Config))
+	if err := c.recordConfigKeys(ctx, cr, append(specKeys, desired.DeleteConfig...)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	res, err := topic.Update(ctx, c.kafkaClient, desired)
+	setChangesCondition(cr, res, err)
+	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
+	if err := c.recordConfigKeys(ctx, cr, specKeys); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// A replication factor change only starts a reassignment; remember it so
+	// that Observe keeps checking until Kafka reports it as finished.
+	reassigning := cr.Status.AtProvider.ReassignmentInProgress ||
+		(cr.Spec.ForProvider.ReplicationFactor != 0 && cr.Spec.ForProvider.ReplicationFactor != cr.Status.AtProvider.ReplicationFactor)
 
 	if cr.Status.AtProvider.ID == "" {
 		tpc, err := topic.Get(ctx, c.kafkaClient, name)
@@ -244,12 +349,35 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		}
 
 		cr.Status.AtProvider = tpc.ToObservation()
-		cr.Status.SetConditions(xpv2.Available())
+		cr.Status.SetConditions(availability(cr.Status.AtProvider))
 	}
+	cr.Status.AtProvider.ReassignmentInProgress = reassigning
 
 	return managed.ExternalUpdate{}, nil
 }
 
+// availability returns the Ready condition of a topic with the observed
+// partition layout. A topic with offline or under-replicated partitions is
+// not ready.
+func availability(o common.TopicObservation) xpv2.Condition {
+	if o.OfflinePartitions > 0 || o.UnderReplicatedPartitions > 0 {
+		return xpv2.Unavailable().WithMessage(fmt.Sprintf(msgPartitionsUnhealthy, o.OfflinePartitions, o.UnderReplicatedPartitions))
+	}
+	return xpv2.Available()
+}
+
+// setChangesCondition reports the outcome of a topic update in the
+// ChangesApplied condition. The condition is left unchanged when the update
+// failed before any change was attempted.
+func setChangesCondition(cr *v1alpha1.Topic, res topic.UpdateResult, err error) {
+	switch {
+	case len(res.Failed) > 0:
+		cr.Status.SetConditions(common.ChangesFailed(res.Applied, res.Failed, err))
+	case err == nil:
+		cr.Status.SetConditions(common.ChangesApplied(res.Applied))
+	}
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	cr, ok := mg.(*v1alpha1.Topic)
 	cr.Status.SetConditions(xpv2.Deleting())
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package discovery generates the managed resources of the topics and ACLs of
+// an existing Kafka cluster, so that the cluster can be imported.
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
+)
+
+const (
+	errCannotListTopics           = "cannot list topics"
+	errCannotDescribeTopicConfigs = "cannot describe topic configs"
+	errCannotDescribeACLs         = "cannot describe ACLs"
+)
+
+// adminClient is the subset of kadm.Client methods used by this package.
+type adminClient interface {
+	ListTopics(ctx context.Context, topics ...string) (kadm.TopicDetails, error)
+	DescribeTopicConfigs(ctx context.Context, topics ...string) (kadm.ResourceConfigs, error)
+	DescribeACLs(ctx context.Context, b *kadm.ACLBuilder) (kadm.DescribeACLsResults, error)
+}
+
+// Topics returns the topics of the cluster, sorted by name. Internal topics
+// are left out.
+func Topics(ctx context.Context, cl adminClient) ([]*topic.Topic, error) {
+	td, err := cl.ListTopics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotListTopics, err)
+	}
+	if err := td.Error(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotListTopics, err)
+	}
+	names := td.Names()
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	rcs, err := cl.DescribeTopicConfigs(ctx, names...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCannotDescribeTopicConfigs, err)
+	}
+
+	topics := make([]*topic.Topic, 0, len(names))
+	for _, name := range names {
+		t := td[name]
+		tpc := &topic.Topic{
+			Name:       name,
+			Partitions: int32(len(t.Partitions)),
+			ID:         t.ID.String(),
+			Config:     map[string]*string{},
+			Overridden: map[string]bool{},
+		}
+		if len(t.Partitions) > 0 {
+			tpc.ReplicationFactor = int16(len(t.Partitions[0].Replicas))
+		}
+
+		rc, err := rcs.On(name, nil)
+		if err == nil {
+			err = rc.Err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", errCannotDescribeTopicConfigs, name, err)
+		}
+		for _, c := range rc.Configs {
+			tpc.Config[c.Key] = c.Value
+			if c.Source == kmsg.ConfigSourceDynamicTopicConfig {
+				tpc.Overridden[c.Key] = true
+			}
+		}
+		topics = append(topics, tpc)
+	}
+	return topics, nil
+}
+
+// ACLs returns the ACLs of the cluster that can be managed by an
+// AccessControlList, sorted by resource and principal, and the ACLs that
+// cannot.
+func ACLs(ctx context.Context, cl adminClient) ([]*acl.AccessControlList, []kadm.DescribedACL, error) {
+	b := kadm.NewACLs().
+		AnyResource().
+		Allow().AllowHosts().
+		Deny().DenyHosts().
+		Operations(kadm.OpAny).
+		ResourcePatternType(kadm.ACLPatternAny)
+
+	resp, err := cl.DescribeACLs(ctx, b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", errCannotDescribeACLs, err)
+	}
+
+	var (
+		acls    []*acl.AccessControlList
+		skipped []kadm.DescribedACL
+	)
+	for _, r := range resp {
+		if r.Err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", errCannotDescribeACLs, r.Err)
+		}
+		for _, d := range r.Described {
+			a, ok := acl.FromDescribed(d)
+			if !ok {
+				skipped = append(skipped, d)
+				continue
+			}
+			acls = append(acls, a)
+		}
+	}
+	acl.Sort(acls)
+	return acls, skipped, nil
+}
@@ -0,0 +1,203 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
+)
+
+// fakeAdmin is an in-process implementation of adminClient for unit tests.
+type fakeAdmin struct {
+	topics      kadm.TopicDetails
+	topicsErr   error
+	configs     kadm.ResourceConfigs
+	configsErr  error
+	acls        kadm.DescribeACLsResults
+	aclsErr     error
+	describedBy *kadm.ACLBuilder
+}
+
+func (f *fakeAdmin) ListTopics(_ context.Context, _ ...string) (kadm.TopicDetails, error) {
+	return f.topics, f.topicsErr
+}
+
+func (f *fakeAdmin) DescribeTopicConfigs(_ context.Context, _ ...string) (kadm.ResourceConfigs, error) {
+	return f.configs, f.configsErr
+}
+
+func (f *fakeAdmin) DescribeACLs(_ context.Context, b *kadm.ACLBuilder) (kadm.DescribeACLsResults, error) {
+	f.describedBy = b
+	return f.acls, f.aclsErr
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestTopics(t *testing.T) {
+	t.Parallel()
+
+	partitions := func(n, rf int) kadm.PartitionDetails {
+		ps := kadm.PartitionDetails{}
+		for i := range n {
+			ps[int32(i)] = kadm.PartitionDetail{Partition: int32(i), Replicas: make([]int32, rf)}
+		}
+		return ps
+	}
+	topics := kadm.TopicDetails{
+		"orders":   {Topic: "orders", Partitions: partitions(6, 3)},
+		"payments": {Topic: "payments", Partitions: partitions(1, 1)},
+	}
+	configs := kadm.ResourceConfigs{
+		{Name: "orders", Configs: []kadm.Config{
+			{Key: "retention.ms", Value: strPtr("86400000"), Source: kmsg.ConfigSourceDynamicTopicConfig},
+			{Key: "cleanup.policy", Value: strPtr("delete"), Source: kmsg.ConfigSourceDefaultConfig},
+		}},
+		{Name: "payments"},
+	}
+
+	cases := map[string]struct {
+		admin   *fakeAdmin
+		want    []*topic.Topic
+		wantErr string
+	}{
+		"Topics": {
+			admin: &fakeAdmin{topics: topics, configs: configs},
+			want: []*topic.Topic{
+				{
+					Name:              "orders",
+					Partitions:        6,
+					ReplicationFactor: 3,
+					Config:            map[string]*string{"retention.ms": strPtr("86400000"), "cleanup.policy": strPtr("delete")},
+					Overridden:        map[string]bool{"retention.ms": true},
+				},
+				{
+					Name:              "payments",
+					Partitions:        1,
+					ReplicationFactor: 1,
+					Config:            map[string]*string{},
+					Overridden:        map[string]bool{},
+				},
+			},
+		},
+		"NoTopics": {
+			admin: &fakeAdmin{topics: kadm.TopicDetails{}},
+		},
+		"ListError": {
+			admin:   &fakeAdmin{topicsErr: errors.New("boom")},
+			wantErr: errCannotListTopics,
+		},
+		"TopicError": {
+			admin:   &fakeAdmin{topics: kadm.TopicDetails{"orders": {Topic: "orders", Err: kerr.TopicAuthorizationFailed}}},
+			wantErr: errCannotListTopics,
+		},
+		"MissingConfigs": {
+			admin:   &fakeAdmin{topics: topics, configs: configs[:1]},
+			wantErr: errCannotDescribeTopicConfigs + ": payments",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Topics(context.Background(), tc.admin)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(topic.Topic{}, "ID")); diff != "" {
+				t.Errorf("Topics(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestACLs(t *testing.T) {
+	t.Parallel()
+
+	read := kadm.DescribedACL{
+		Principal:  kafka.TestACLPrincipal,
+		Host:       "*",
+		Type:       kmsg.ACLResourceTypeTopic,
+		Name:       "orders",
+		Pattern:    kadm.ACLPatternLiteral,
+		Operation:  kadm.OpRead,
+		Permission: kmsg.ACLPermissionTypeAllow,
+	}
+	group := read
+	group.Type = kmsg.ACLResourceTypeGroup
+	user := read
+	user.Type = kmsg.ACLResourceTypeUser
+	user.Operation = kmsg.ACLOperationCreateTokens
+	unknown := read
+	unknown.Operation = kmsg.ACLOperationUnknown
+
+	admin := &fakeAdmin{acls: kadm.DescribeACLsResults{
+		{Permission: kmsg.ACLPermissionTypeAllow, Described: kadm.DescribedACLs{read, user, unknown, group}},
+		{Permission: kmsg.ACLPermissionTypeDeny},
+	}}
+	acls, skipped, err := ACLs(context.Background(), admin)
+	require.NoError(t, err)
+
+	want := []*acl.AccessControlList{
+		{
+			ResourceName:              "orders",
+			ResourceType:              kafka.ACLResourceTypeGroup,
+			ResourcePrincipal:         kafka.TestACLPrincipal,
+			ResourceHost:              "*",
+			ResourceOperation:         kafka.ACLOperationRead,
+			ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+			ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+		},
+		{
+			ResourceName:              "orders",
+			ResourceType:              kafka.ACLResourceTypeTopic,
+			ResourcePrincipal:         kafka.TestACLPrincipal,
+			ResourceHost:              "*",
+			ResourceOperation:         kafka.ACLOperationRead,
+			ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+			ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+		},
+		{
+			ResourceName:              "orders",
+			ResourceType:              kafka.ACLResourceTypeUser,
+			ResourcePrincipal:         kafka.TestACLPrincipal,
+			ResourceHost:              "*",
+			ResourceOperation:         kafka.ACLOperationCreateTokens,
+			ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+			ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+		},
+	}
+	if diff := cmp.Diff(want, acls); diff != "" {
+		t.Errorf("ACLs(...): -want, +got:\n%s", diff)
+	}
+	assert.Equal(t, []kadm.DescribedACL{unknown}, skipped)
+	require.NoError(t, admin.describedBy.ValidateDescribe())
+}
+
+func TestACLsError(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]*fakeAdmin{
+		"DescribeError": {aclsErr: errors.New("boom")},
+		"FilterError":   {acls: kadm.DescribeACLsResults{{Err: kerr.SecurityDisabled}}},
+	}
+	for name, admin := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := ACLs(context.Background(), admin)
+			require.ErrorContains(t, err, errCannotDescribeACLs)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	clusteracl "github.com/crossplane-contrib/provider-kafka/apis/cluster/acl/v1alpha2"
+	clustertopic "github.com/crossplane-contrib/provider-kafka/apis/cluster/topic/v1alpha1"
+	namespacedacl "github.com/crossplane-contrib/provider-kafka/apis/namespaced/acl/v1alpha2"
+	namespacedtopic "github.com/crossplane-contrib/provider-kafka/apis/namespaced/topic/v1alpha1"
+	common "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	commonv1alpha2 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha2"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
+)
+
+const (
+	errCannotGenerateACLName = "cannot generate ACL external name"
+	errCannotMarshalManifest = "cannot marshal manifest"
+	errCannotWriteManifest   = "cannot write manifest"
+)
+
+// maxNameLength is the maximum length of a Kubernetes object name.
+const maxNameLength = 253
+
+// invalidNameChars matches the characters that are not allowed in a
+// Kubernetes object name.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Options configure the generated managed resources.
+type Options struct {
+	// Namespace of the managed resources. Cluster scoped managed resources
+	// are generated if it is empty.
+	Namespace string
+	// ProviderConfigName is the name of the ProviderConfig the managed
+	// resources reference.
+	ProviderConfigName string
+	// ProviderConfigKind is the kind of the ProviderConfig namespaced managed
+	// resources reference.
+	ProviderConfigKind string
+	// ObserveOnly generates managed resources with the Observe management
+	// policy, so that the cluster is never changed.
+	ObserveOnly bool
+}
+
+// Resources returns the Topic and AccessControlList managed resources of the
+// topics and ACLs. Each managed resource has the external name of its topic
+// or ACL, so that it is imported rather than created.
+func Resources(topics []*topic.Topic, acls []*acl.AccessControlList, o Options) ([]client.Object, error) {
+	objs := make([]client.Object, 0, len(topics)+len(acls))
+	for _, t := range topics {
+		var params common.TopicParameters
+		topic.LateInitialize(&params, t)
+		objs = append(objs, o.topic(t.Name, params))
+	}
+	for _, a := range acls {
+		extName, err := acl.ConvertToJSON(a)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errCannotGenerateACLName, err)
+		}
+		params := commonv1alpha2.AccessControlListParameters{
+			ResourceName:              a.ResourceName,
+			ResourceType:              a.ResourceType,
+			ResourcePrincipal:         a.ResourcePrincipal,
+			ResourceHost:              a.ResourceHost,
+			ResourceOperation:         a.ResourceOperation,
+			ResourcePermissionType:    a.ResourcePermissionType,
+			ResourcePatternTypeFilter: a.ResourcePatternTypeFilter,
+		}
+		name := objectName(extName, a.ResourcePrincipal, a.ResourceType, a.ResourceName, a.ResourceOperation, a.ResourcePermissionType)
+		objs = append(objs, o.acl(name, extName, params))
+	}
+	return objs, nil
+}
+
+func (o Options) topic(extName string, params common.TopicParameters) client.Object {
+	var obj client.Object
+	if o.Namespace == "" {
+		t := &clustertopic.Topic{Spec: clustertopic.TopicSpec{ClusterManagedResourceSpec: o.clusterSpec(), ForProvider: params}}
+		t.SetGroupVersionKind(clustertopic.TopicGroupVersionKind)
+		obj = t
+	} else {
+		t := &namespacedtopic.Topic{Spec: namespacedtopic.TopicSpec{ManagedResourceSpec: o.namespacedSpec(), ForProvider: params}}
+		t.SetGroupVersionKind(namespacedtopic.TopicGroupVersionKind)
+		obj = t
+	}
+	o.setMeta(obj, objectName(extName, extName), extName)
+	return obj
+}
+
+func (o Options) acl(name, extName string, params commonv1alpha2.AccessControlListParameters) client.Object {
+	var obj client.Object
+	if o.Namespace == "" {
+		a := &clusteracl.AccessControlList{Spec: clusteracl.AccessControlListSpec{ClusterManagedResourceSpec: o.clusterSpec(), ForProvider: params}}
+		a.SetGroupVersionKind(clusteracl.AccessControlListGroupVersionKind)
+		obj = a
+	} else {
+		a := &namespacedacl.AccessControlList{Spec: namespacedacl.AccessControlListSpec{ManagedResourceSpec: o.namespacedSpec(), ForProvider: params}}
+		a.SetGroupVersionKind(namespacedacl.AccessControlListGroupVersionKind)
+		obj = a
+	}
+	o.setMeta(obj, name, extName)
+	return obj
+}
+
+func (o Options) setMeta(obj client.Object, name, extName string) {
+	obj.SetName(name)
+	obj.SetNamespace(o.Namespace)
+	meta.SetExternalName(obj, extName)
+}
+
+func (o Options) clusterSpec() xpv2.ClusterManagedResourceSpec {
+	return xpv2.ClusterManagedResourceSpec{
+		ProviderConfigReference: &xpv2.Reference{Name: o.ProviderConfigName},
+		ManagementPolicies:      o.policies(),
+	}
+}
+
+func (o Options) namespacedSpec() xpv2.ManagedResourceSpec {
+	return xpv2.ManagedResourceSpec{
+		ProviderConfigReference: &xpv2.ProviderConfigReference{Kind: o.ProviderConfigKind, Name: o.ProviderConfigName},
+		ManagementPolicies:      o.policies(),
+	}
+}
+
+func (o Options) policies() xpv2.ManagementPolicies {
+	if !o.ObserveOnly {
+		return nil
+	}
+	return xpv2.ManagementPolicies{xpv2.ManagementActionObserve}
+}
+
+// objectName returns a valid Kubernetes object name made of the parts. A
+// hash of the id is appended if the parts had to be changed to be valid or
+// if they don't identify the object on their own.
+func objectName(id string, parts ...string) string {
+	valid := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if v := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(p), "-"), "-."); v != "" {
+			valid = append(valid, v)
+		}
+	}
+	name := strings.Join(valid, "-")
+	if len(parts) == 1 && name == parts[0] && len(name) <= maxNameLength {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(id))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	if len(name) > maxNameLength-len(suffix)-1 {
+		name = strings.TrimRight(name[:maxNameLength-len(suffix)-1], "-.")
+	}
+	if name == "" {
+		return suffix
+	}
+	return name + "-" + suffix
+}
+
+// Write writes the objects to w as a multi-document YAML stream. Their status
+// and creation timestamp are left out so that the manifests can be applied.
+func Write(w io.Writer, objs []client.Object) error {
+	for i, obj := range objs {
+		j, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("%s: %w", errCannotMarshalManifest, err)
+		}
+		m := map[string]any{}
+		if err := json.Unmarshal(j, &m); err != nil {
+			return fmt.Errorf("%s: %w", errCannotMarshalManifest, err)
+		}
+		delete(m, "status")
+		if md, ok := m["metadata"].(map[string]any); ok {
+			delete(md, "creationTimestamp")
+		}
+		y, err := yaml.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("%s: %w", errCannotMarshalManifest, err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return fmt.Errorf("%s: %w", errCannotWriteManifest, err)
+			}
+		}
+		if _, err := w.Write(y); err != nil {
+			return fmt.Errorf("%s: %w", errCannotWriteManifest, err)
+		}
+	}
+	return nil
+}
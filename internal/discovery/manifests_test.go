@@ -0,0 +1,177 @@
+package discovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
+)
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+
+	topics := []*topic.Topic{{
+		Name:              "orders",
+		Partitions:        6,
+		ReplicationFactor: 3,
+		Config:            map[string]*string{"retention.ms": strPtr("86400000"), "cleanup.policy": strPtr("delete")},
+		Overridden:        map[string]bool{"retention.ms": true},
+	}}
+	acls := []*acl.AccessControlList{{
+		ResourceName:              "orders",
+		ResourceType:              kafka.ACLResourceTypeTopic,
+		ResourcePrincipal:         kafka.TestACLPrincipal,
+		ResourceHost:              "*",
+		ResourceOperation:         kafka.ACLOperationRead,
+		ResourcePermissionType:    kafka.ACLPermissionTypeAllow,
+		ResourcePatternTypeFilter: kafka.ACLPatternTypeLiteral,
+	}}
+
+	cases := map[string]struct {
+		o    Options
+		want string
+	}{
+		"Namespaced": {
+			o: Options{Namespace: "kafka", ProviderConfigName: "default", ProviderConfigKind: "ClusterProviderConfig"},
+			want: `apiVersion: topic.kafka.m.crossplane.io/v1alpha1
+kind: Topic
+metadata:
+  annotations:
+    crossplane.io/external-name: orders
+  name: orders
+  namespace: kafka
+spec:
+  forProvider:
+    config:
+      retention.ms: "86400000"
+    partitions: 6
+    replicationFactor: 3
+  providerConfigRef:
+    kind: ClusterProviderConfig
+    name: default
+---
+apiVersion: acl.kafka.m.crossplane.io/v1alpha2
+kind: AccessControlList
+metadata:
+  annotations:
+    crossplane.io/external-name: '{"ResourceName":"orders","ResourceType":"Topic","ResourcePrincipal":"User:Ken","ResourceHost":"*","ResourceOperation":"Read","ResourcePermissionType":"Allow","ResourcePatternTypeFilter":"Literal"}'
+  name: user-ken-topic-orders-read-allow-50e57f3b
+  namespace: kafka
+spec:
+  forProvider:
+    resourceHost: '*'
+    resourceName: orders
+    resourceOperation: Read
+    resourcePatternTypeFilter: Literal
+    resourcePermissionType: Allow
+    resourcePrincipal: User:Ken
+    resourceType: Topic
+  providerConfigRef:
+    kind: ClusterProviderConfig
+    name: default
+`,
+		},
+		"ClusterObserveOnly": {
+			o: Options{ProviderConfigName: "example", ObserveOnly: true},
+			want: `apiVersion: topic.kafka.crossplane.io/v1alpha1
+kind: Topic
+metadata:
+  annotations:
+    crossplane.io/external-name: orders
+  name: orders
+spec:
+  forProvider:
+    config:
+      retention.ms: "86400000"
+    partitions: 6
+    replicationFactor: 3
+  managementPolicies:
+  - Observe
+  providerConfigRef:
+    name: example
+---
+apiVersion: acl.kafka.crossplane.io/v1alpha2
+kind: AccessControlList
+metadata:
+  annotations:
+    crossplane.io/external-name: '{"ResourceName":"orders","ResourceType":"Topic","ResourcePrincipal":"User:Ken","ResourceHost":"*","ResourceOperation":"Read","ResourcePermissionType":"Allow","ResourcePatternTypeFilter":"Literal"}'
+  name: user-ken-topic-orders-read-allow-50e57f3b
+spec:
+  forProvider:
+    resourceHost: '*'
+    resourceName: orders
+    resourceOperation: Read
+    resourcePatternTypeFilter: Literal
+    resourcePermissionType: Allow
+    resourcePrincipal: User:Ken
+    resourceType: Topic
+  managementPolicies:
+  - Observe
+  providerConfigRef:
+    name: example
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			objs, err := Resources(topics, acls, tc.o)
+			require.NoError(t, err)
+			var got strings.Builder
+			require.NoError(t, Write(&got, objs))
+			if diff := cmp.Diff(tc.want, got.String()); diff != "" {
+				t.Errorf("Write(Resources(...)): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		parts []string
+		want  string
+	}{
+		"Valid": {
+			parts: []string{"orders.v1"},
+			want:  "orders.v1",
+		},
+		"Invalid": {
+			parts: []string{"_Orders_V1"},
+			want:  "orders-v1-",
+		},
+		"Joined": {
+			parts: []string{"User:Ken", "Topic", "*", "All"},
+			want:  "user-ken-topic-all-",
+		},
+		"Empty": {
+			parts: []string{"__"},
+			want:  "",
+		},
+		"TooLong": {
+			parts: []string{strings.Repeat("a", 300)},
+			want:  strings.Repeat("a", maxNameLength-9) + "-",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := objectName(strings.Join(tc.parts, "/"), tc.parts...)
+			assert.True(t, strings.HasPrefix(got, tc.want), "objectName(...) = %q, want prefix %q", got, tc.want)
+			assert.Empty(t, validation.IsDNS1123Subdomain(got))
+			if tc.want == tc.parts[0] {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}